@@ -1,6 +1,10 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 type Config struct {
 	DBHost     string `mapstructure:"DB_HOST"`
@@ -10,6 +14,80 @@ type Config struct {
 	DBName     string `mapstructure:"DB_NAME"`
 	JWTSecret  string `mapstructure:"JWT_SECRET"`
 	ServerPort string `mapstructure:"SERVER_PORT"`
+	// JWTSigningKey is the path of the PEM-encoded RSA or Ed25519 private
+	// key that signs access tokens. Tokens are signed with JWTSecret
+	// (HS256) when it is empty.
+	JWTSigningKey string `mapstructure:"JWT_SIGNING_KEY"`
+	// JWTVerificationKeys are paths of further PEM-encoded public keys
+	// whose tokens are accepted, such as the previous signing key while
+	// its tokens expire.
+	JWTVerificationKeys []string `mapstructure:"JWT_VERIFICATION_KEYS"`
+	// ReservationTTL is how long a pending purchase order holds its stock
+	// before the reservation is released.
+	ReservationTTL time.Duration `mapstructure:"RESERVATION_TTL"`
+	// SMTP settings for email notifications. Email is disabled when
+	// SMTPHost is empty.
+	SMTPHost     string `mapstructure:"SMTP_HOST"`
+	SMTPPort     string `mapstructure:"SMTP_PORT"`
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+	SMTPFrom     string `mapstructure:"SMTP_FROM"`
+	// WorkerCount is the number of background workers running outbox jobs.
+	WorkerCount int `mapstructure:"WORKER_COUNT"`
+	// JobRetention is how long succeeded jobs are kept before they are
+	// pruned.
+	JobRetention time.Duration `mapstructure:"JOB_RETENTION"`
+	// LoginAttemptRetention is how long login attempts are kept before
+	// they are pruned.
+	LoginAttemptRetention time.Duration `mapstructure:"LOGIN_ATTEMPT_RETENTION"`
+	// AuditCheckpointKey is the path of the PEM-encoded Ed25519 private key
+	// that signs audit checkpoints. Checkpoints are disabled when it is
+	// empty.
+	AuditCheckpointKey string `mapstructure:"AUDIT_CHECKPOINT_KEY"`
+	// AuditCheckpointFile is the file signed checkpoints are appended to.
+	AuditCheckpointFile string `mapstructure:"AUDIT_CHECKPOINT_FILE"`
+	// AuditCheckpointInterval is how often a checkpoint is taken.
+	AuditCheckpointInterval time.Duration `mapstructure:"AUDIT_CHECKPOINT_INTERVAL"`
+	// PasswordResetURL is the page reset links point to; the token is added
+	// as the token query parameter.
+	PasswordResetURL string `mapstructure:"PASSWORD_RESET_URL"`
+	// PasswordResetTTL is how long a reset link stays valid.
+	PasswordResetTTL time.Duration `mapstructure:"PASSWORD_RESET_TTL"`
+	// EmailVerificationSecret signs email verification links. When it is
+	// empty the key is derived from JWTSecret with HKDF.
+	EmailVerificationSecret string `mapstructure:"EMAIL_VERIFICATION_SECRET"`
+	// EmailVerificationURL is the verify-email endpoint as reachable from
+	// the user's mail client.
+	EmailVerificationURL string `mapstructure:"EMAIL_VERIFICATION_URL"`
+	// EmailVerificationTTL is how long a verification link stays valid.
+	EmailVerificationTTL time.Duration `mapstructure:"EMAIL_VERIFICATION_TTL"`
+	// MFAIssuer is the account name authenticator apps show.
+	MFAIssuer string `mapstructure:"MFA_ISSUER"`
+	// MFAEncryptionKey encrypts stored TOTP secrets. It is required and
+	// must differ from JWTSecret. Changing it invalidates every enrollment.
+	MFAEncryptionKey string `mapstructure:"MFA_ENCRYPTION_KEY"`
+	// AccessTokenTTL is how long an access token is valid. Sessions and
+	// changes to the user are checked on every request regardless.
+	AccessTokenTTL time.Duration `mapstructure:"ACCESS_TOKEN_TTL"`
+	// RefreshTokenTTL is how long a session survives without a refresh.
+	RefreshTokenTTL time.Duration `mapstructure:"REFRESH_TOKEN_TTL"`
+	// RateLimitStore keeps the rate limit buckets: "memory" counts on each
+	// replica separately, "postgres" shares the counts between replicas.
+	RateLimitStore string `mapstructure:"RATE_LIMIT_STORE"`
+	// The rate limits are written as "<requests>/<period>", such as "10/1m".
+	// RateLimitPublic applies per IP to the public routes, RateLimitLogin
+	// and RateLimitRegister per IP to login and vendor registration, and
+	// RateLimitUser per user to the authenticated routes.
+	RateLimitPublic   string `mapstructure:"RATE_LIMIT_PUBLIC"`
+	RateLimitLogin    string `mapstructure:"RATE_LIMIT_LOGIN"`
+	RateLimitRegister string `mapstructure:"RATE_LIMIT_REGISTER"`
+	RateLimitUser     string `mapstructure:"RATE_LIMIT_USER"`
+	// ApprovalEscalationAfter is how long an approval may stay pending
+	// without moving before it is escalated.
+	ApprovalEscalationAfter time.Duration `mapstructure:"APPROVAL_ESCALATION_AFTER"`
+	// VendorDocumentReminder is how long before a vendor document expires
+	// the vendor is reminded.
+	VendorDocumentReminder time.Duration `mapstructure:"VENDOR_DOCUMENT_REMINDER"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -17,6 +95,29 @@ func LoadConfig(path string) (config Config, err error) {
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
 
+	viper.SetDefault("RESERVATION_TTL", "30m")
+	viper.SetDefault("SMTP_PORT", "587")
+	viper.SetDefault("SMTP_FROM", "no-reply@e-procurement.local")
+	viper.SetDefault("WORKER_COUNT", 4)
+	viper.SetDefault("JOB_RETENTION", "168h")
+	viper.SetDefault("LOGIN_ATTEMPT_RETENTION", "2160h")
+	viper.SetDefault("AUDIT_CHECKPOINT_FILE", "audit-checkpoints.jsonl")
+	viper.SetDefault("AUDIT_CHECKPOINT_INTERVAL", "1h")
+	viper.SetDefault("PASSWORD_RESET_URL", "http://localhost:3000/reset-password")
+	viper.SetDefault("PASSWORD_RESET_TTL", "1h")
+	viper.SetDefault("EMAIL_VERIFICATION_URL", "http://localhost:8080/api/v1/verify-email")
+	viper.SetDefault("EMAIL_VERIFICATION_TTL", "48h")
+	viper.SetDefault("MFA_ISSUER", "E-Procurement")
+	viper.SetDefault("ACCESS_TOKEN_TTL", "15m")
+	viper.SetDefault("REFRESH_TOKEN_TTL", "168h")
+	viper.SetDefault("RATE_LIMIT_STORE", "memory")
+	viper.SetDefault("RATE_LIMIT_PUBLIC", "120/1m")
+	viper.SetDefault("RATE_LIMIT_LOGIN", "10/1m")
+	viper.SetDefault("RATE_LIMIT_REGISTER", "5/1h")
+	viper.SetDefault("RATE_LIMIT_USER", "600/1m")
+	viper.SetDefault("APPROVAL_ESCALATION_AFTER", "72h")
+	viper.SetDefault("VENDOR_DOCUMENT_REMINDER", "720h")
+
 	viper.AutomaticEnv()
 
 	err = viper.ReadInConfig()
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS users_name_trgm_idx;
+DROP INDEX IF EXISTS products_name_trgm_idx;
+DROP INDEX IF EXISTS products_search_document_idx;
+
+DROP FUNCTION IF EXISTS product_search_document(TEXT, TEXT, TEXT);
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS sku;
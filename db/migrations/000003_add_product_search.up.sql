@@ -0,0 +1,18 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE products
+    ADD COLUMN sku VARCHAR(64),
+    ADD COLUMN description TEXT NOT NULL DEFAULT '';
+
+-- product_search_document builds the weighted document used for full-text
+-- search. It is IMMUTABLE so it can back an expression index.
+CREATE OR REPLACE FUNCTION product_search_document(name TEXT, sku TEXT, description TEXT)
+RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+           setweight(to_tsvector('english', coalesce(sku, '')), 'A') ||
+           setweight(to_tsvector('english', coalesce(description, '')), 'C')
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE INDEX products_search_document_idx ON products USING GIN (product_search_document(name, sku, description));
+CREATE INDEX products_name_trgm_idx ON products USING GIN (name gin_trgm_ops);
+CREATE INDEX users_name_trgm_idx ON users USING GIN (name gin_trgm_ops);
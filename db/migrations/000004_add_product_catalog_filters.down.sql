@@ -0,0 +1,9 @@
+DROP FUNCTION IF EXISTS product_search_rank(TEXT, TEXT, TEXT, TEXT, TEXT);
+DROP FUNCTION IF EXISTS product_search_matches(TEXT, TEXT, TEXT, TEXT, TEXT);
+
+DROP INDEX IF EXISTS products_price_idx;
+DROP INDEX IF EXISTS products_category_idx;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS lead_time_days,
+    DROP COLUMN IF EXISTS category;
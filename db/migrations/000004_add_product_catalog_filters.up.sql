@@ -0,0 +1,25 @@
+ALTER TABLE products
+    ADD COLUMN category VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN lead_time_days INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX products_category_idx ON products (category);
+CREATE INDEX products_price_idx ON products (price);
+
+-- product_search_matches and product_search_rank are inlined by the planner,
+-- so the indexes created for product search are still used.
+CREATE OR REPLACE FUNCTION product_search_matches(name TEXT, sku TEXT, description TEXT, vendor_name TEXT, query TEXT)
+RETURNS boolean AS $$
+    SELECT product_search_document(name, sku, description) @@ websearch_to_tsquery('english', query)
+        OR to_tsvector('english', coalesce(vendor_name, '')) @@ websearch_to_tsquery('english', query)
+        OR name % query
+        OR query <% name
+        OR vendor_name % query
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION product_search_rank(name TEXT, sku TEXT, description TEXT, vendor_name TEXT, query TEXT)
+RETURNS real AS $$
+    SELECT ts_rank(
+        product_search_document(name, sku, description) || setweight(to_tsvector('english', coalesce(vendor_name, '')), 'B'),
+        websearch_to_tsquery('english', query)
+    ) + similarity(name, query)
+$$ LANGUAGE sql STABLE;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS purchase_order_items;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TABLE IF EXISTS cart_items;
+
+ALTER TABLE products DROP COLUMN IF EXISTS min_order_quantity;
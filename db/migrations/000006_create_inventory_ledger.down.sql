@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS inventory_movements;
+DROP TABLE IF EXISTS stock_reservations;
+
+ALTER TABLE purchase_orders DROP COLUMN IF EXISTS expires_at;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_reserved_stock_check;
+ALTER TABLE products DROP COLUMN IF EXISTS reserved_stock;
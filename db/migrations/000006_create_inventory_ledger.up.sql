@@ -0,0 +1,41 @@
+-- products.stock and products.reserved_stock are projections of the
+-- inventory ledger, kept in sync in the same transaction as each movement.
+ALTER TABLE products ADD COLUMN reserved_stock INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE products ADD CONSTRAINT products_reserved_stock_check CHECK (reserved_stock >= 0 AND reserved_stock <= stock);
+
+-- expires_at is the deadline for the buyer to confirm a pending order;
+-- after it the reserved stock is released.
+ALTER TABLE purchase_orders ADD COLUMN expires_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    purchase_order_id INTEGER NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    status VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX stock_reservations_purchase_order_id_idx ON stock_reservations (purchase_order_id);
+
+CREATE TABLE IF NOT EXISTS inventory_movements (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    movement_type VARCHAR(50) NOT NULL,
+    quantity INTEGER NOT NULL,
+    on_hand_delta INTEGER NOT NULL,
+    reserved_delta INTEGER NOT NULL,
+    reservation_id INTEGER REFERENCES stock_reservations(id) ON DELETE SET NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX inventory_movements_product_id_idx ON inventory_movements (product_id, id);
+
+-- Open the ledger with the current stock of every product.
+INSERT INTO inventory_movements (product_id, movement_type, quantity, on_hand_delta, reserved_delta, note)
+SELECT id, 'receipt', stock, stock, 0, 'opening balance'
+FROM products
+WHERE stock > 0;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS stock_alerts;
+DROP TABLE IF EXISTS product_subscriptions;
+ALTER TABLE products DROP COLUMN IF EXISTS low_stock_threshold;
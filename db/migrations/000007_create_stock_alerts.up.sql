@@ -0,0 +1,23 @@
+-- low_stock_threshold is the available quantity at or below which the vendor
+-- is alerted. NULL disables low-stock alerts for the product.
+ALTER TABLE products ADD COLUMN low_stock_threshold INTEGER CHECK (low_stock_threshold >= 0);
+
+CREATE TABLE IF NOT EXISTS product_subscriptions (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, product_id)
+);
+
+CREATE INDEX product_subscriptions_product_id_idx ON product_subscriptions (product_id);
+
+CREATE TABLE IF NOT EXISTS stock_alerts (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    alert_type VARCHAR(50) NOT NULL,
+    available INTEGER NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX stock_alerts_user_id_idx ON stock_alerts (user_id, id);
@@ -0,0 +1,21 @@
+-- jobs is the transactional outbox. Rows are written in the same
+-- transaction as the domain change that causes them and processed by the
+-- in-process worker pool.
+CREATE TABLE IF NOT EXISTS jobs (
+    id SERIAL PRIMARY KEY,
+    job_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL,
+    run_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMPTZ,
+    last_error TEXT NOT NULL DEFAULT '',
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX jobs_pending_idx ON jobs (run_at) WHERE status = 'pending';
+CREATE INDEX jobs_running_idx ON jobs (locked_until) WHERE status = 'running';
+CREATE INDEX jobs_status_idx ON jobs (status, id);
@@ -0,0 +1,34 @@
+-- audit_events is append-only: every create, update and delete of users,
+-- products and purchase orders is recorded in the transaction that makes it.
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id INTEGER,
+    actor_role VARCHAR(50) NOT NULL DEFAULT '',
+    ip VARCHAR(100) NOT NULL DEFAULT '',
+    request_id VARCHAR(100) NOT NULL DEFAULT '',
+    action VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    before JSONB,
+    after JSONB,
+    diff JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX audit_events_actor_idx ON audit_events (actor_id, id);
+CREATE INDEX audit_events_entity_idx ON audit_events (entity_type, entity_id, id);
+CREATE INDEX audit_events_created_at_idx ON audit_events (created_at);
+
+CREATE OR REPLACE FUNCTION audit_events_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit events are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_immutable
+BEFORE UPDATE OR DELETE ON audit_events
+FOR EACH ROW EXECUTE FUNCTION audit_events_immutable();
+
+CREATE TRIGGER audit_events_no_truncate
+BEFORE TRUNCATE ON audit_events
+FOR EACH STATEMENT EXECUTE FUNCTION audit_events_immutable();
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS audit_checkpoints;
+DROP TRIGGER IF EXISTS audit_events_chain ON audit_events;
+DROP FUNCTION IF EXISTS audit_events_chain();
+DROP INDEX IF EXISTS audit_events_seq_idx;
+DROP FUNCTION IF EXISTS audit_event_hash(audit_events);
+ALTER TABLE audit_events
+    DROP COLUMN IF EXISTS hash,
+    DROP COLUMN IF EXISTS prev_hash,
+    DROP COLUMN IF EXISTS seq;
@@ -0,0 +1,91 @@
+-- Every audit event carries the hash of the event before it, so editing,
+-- deleting or reordering an event breaks the chain from that point on.
+ALTER TABLE audit_events
+    ADD COLUMN seq BIGINT,
+    ADD COLUMN prev_hash VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN hash VARCHAR(64) NOT NULL DEFAULT '';
+
+-- audit_event_hash is the SHA-256 of an event's contents and prev_hash.
+-- Columns are separated by the unit separator so that values cannot run
+-- into each other.
+CREATE OR REPLACE FUNCTION audit_event_hash(e audit_events) RETURNS VARCHAR AS $$
+    SELECT encode(sha256(convert_to(concat_ws(E'\x1f',
+        e.seq,
+        e.prev_hash,
+        e.id,
+        coalesce(e.actor_id::text, ''),
+        e.actor_role,
+        e.ip,
+        e.request_id,
+        e.action,
+        e.entity_type,
+        e.entity_id,
+        coalesce(e.before::text, ''),
+        coalesce(e.after::text, ''),
+        e.diff::text,
+        to_char(e.created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')
+    ), 'UTF8')), 'hex')
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Chain the existing events in insertion order.
+ALTER TABLE audit_events DISABLE TRIGGER audit_events_immutable;
+
+DO $$
+DECLARE
+    e audit_events%ROWTYPE;
+    previous VARCHAR(64) := '';
+    n BIGINT := 0;
+BEGIN
+    FOR e IN SELECT * FROM audit_events ORDER BY id LOOP
+        n := n + 1;
+        e.seq := n;
+        e.prev_hash := previous;
+        previous := audit_event_hash(e);
+        UPDATE audit_events SET seq = n, prev_hash = e.prev_hash, hash = previous WHERE id = e.id;
+    END LOOP;
+END $$;
+
+ALTER TABLE audit_events ENABLE TRIGGER audit_events_immutable;
+
+ALTER TABLE audit_events ALTER COLUMN seq SET NOT NULL;
+CREATE UNIQUE INDEX audit_events_seq_idx ON audit_events (seq);
+
+-- The chain position is assigned under a transaction-scoped advisory lock,
+-- so concurrent writers append one at a time in commit order. IDs cannot
+-- be used for this: they are taken from the sequence before the lock.
+CREATE OR REPLACE FUNCTION audit_events_chain() RETURNS trigger AS $$
+DECLARE
+    head audit_events%ROWTYPE;
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('audit_events_chain'));
+    SELECT * INTO head FROM audit_events ORDER BY seq DESC LIMIT 1;
+    NEW.seq := coalesce(head.seq, 0) + 1;
+    NEW.prev_hash := coalesce(head.hash, '');
+    NEW.hash := audit_event_hash(NEW);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_chain
+BEFORE INSERT ON audit_events
+FOR EACH ROW EXECUTE FUNCTION audit_events_chain();
+
+-- audit_checkpoints are signed statements of the chain head at a point in
+-- time. A copy of each is also exported to a file, so a chain rewritten
+-- from scratch still disagrees with the checkpoints kept outside the
+-- database.
+CREATE TABLE IF NOT EXISTS audit_checkpoints (
+    id BIGSERIAL PRIMARY KEY,
+    seq BIGINT NOT NULL,
+    event_id BIGINT NOT NULL,
+    hash VARCHAR(64) NOT NULL,
+    key_id VARCHAR(64) NOT NULL,
+    signature TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX audit_checkpoints_seq_idx ON audit_checkpoints (seq);
+
+CREATE TRIGGER audit_checkpoints_immutable
+BEFORE UPDATE OR DELETE ON audit_checkpoints
+FOR EACH ROW EXECUTE FUNCTION audit_events_immutable();
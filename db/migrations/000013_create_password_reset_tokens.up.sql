@@ -0,0 +1,16 @@
+-- password_changed_at invalidates refresh tokens issued before it.
+ALTER TABLE users ADD COLUMN password_changed_at TIMESTAMPTZ;
+
+-- Only the SHA-256 of a reset token is stored. A token is used once and
+-- expires; issuing a reset does not invalidate earlier tokens, but using
+-- any of them invalidates the rest.
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX password_reset_tokens_user_idx ON password_reset_tokens (user_id) WHERE used_at IS NULL;
@@ -0,0 +1,8 @@
+-- Vendors verify their email before they reach the approval queue.
+-- verification_sent_at throttles resending the verification email.
+ALTER TABLE users
+    ADD COLUMN email_verified_at TIMESTAMPTZ,
+    ADD COLUMN verification_sent_at TIMESTAMPTZ;
+
+-- Accounts created before verification existed count as verified.
+UPDATE users SET email_verified_at = created_at;
@@ -0,0 +1,19 @@
+-- Access tokens carry the token_version they were issued at and are
+-- rejected once it changes. It is bumped whenever a change to the user
+-- must cut off their access at once: role, status or password.
+ALTER TABLE users ADD COLUMN token_version INTEGER NOT NULL DEFAULT 0;
+
+CREATE OR REPLACE FUNCTION users_bump_token_version() RETURNS trigger AS $$
+BEGIN
+    IF NEW.role IS DISTINCT FROM OLD.role
+        OR NEW.status IS DISTINCT FROM OLD.status
+        OR NEW.password IS DISTINCT FROM OLD.password THEN
+        NEW.token_version := OLD.token_version + 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_bump_token_version
+BEFORE UPDATE ON users
+FOR EACH ROW EXECUTE FUNCTION users_bump_token_version();
@@ -0,0 +1,23 @@
+-- failed_logins counts the password failures since the last successful
+-- login. locked_until blocks further attempts, briefly after a few
+-- failures and for progressively longer once the account is locked out.
+ALTER TABLE users ADD COLUMN failed_logins INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN locked_until TIMESTAMPTZ;
+
+-- Every password login attempt, successful or not, for security review
+-- and per-IP throttling. user_id is null when the email is unknown.
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    email VARCHAR(255) NOT NULL,
+    ip VARCHAR(100) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    success BOOLEAN NOT NULL,
+    reason VARCHAR(50) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX login_attempts_ip_failed_idx ON login_attempts (ip, created_at) WHERE NOT success;
+CREATE INDEX login_attempts_user_idx ON login_attempts (user_id, created_at);
+CREATE INDEX login_attempts_email_idx ON login_attempts (email, created_at);
+CREATE INDEX login_attempts_created_at_idx ON login_attempts (created_at);
@@ -0,0 +1,10 @@
+-- Token buckets of the API rate limits, shared by all replicas. A bucket is
+-- stored as the time it will be full again; a bucket that is full, or
+-- missing, allows a burst of the whole limit. Buckets are cheap to lose, so
+-- the table is not written to the WAL.
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_buckets (
+    key VARCHAR(255) PRIMARY KEY,
+    full_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX rate_limit_buckets_full_at_idx ON rate_limit_buckets (full_at);
@@ -0,0 +1,60 @@
+-- Roles are named sets of permissions; a user's permissions are those of
+-- all their roles. The permissions themselves are defined in code.
+-- users.role stays the kind of account (admin, vendor or user).
+CREATE TABLE IF NOT EXISTS roles (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    built_in BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission VARCHAR(100) NOT NULL,
+    PRIMARY KEY (role_id, permission)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX user_roles_role_idx ON user_roles (role_id);
+
+-- The built-in roles grant what each kind of account could do before.
+INSERT INTO roles (name, description, built_in) VALUES
+    ('admin', 'Administers users, vendors, roles and the platform', TRUE),
+    ('vendor', 'Sells products and fulfils purchase orders', TRUE),
+    ('user', 'Buys products', TRUE);
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+JOIN (VALUES
+    ('admin', 'user:read'),
+    ('admin', 'user:write'),
+    ('admin', 'user:delete'),
+    ('admin', 'vendor:approve'),
+    ('admin', 'session:manage'),
+    ('admin', 'role:manage'),
+    ('admin', 'login_attempt:read'),
+    ('admin', 'webhook:manage'),
+    ('admin', 'job:manage'),
+    ('admin', 'audit:read'),
+    ('admin', 'audit:sign'),
+    ('vendor', 'product:write'),
+    ('vendor', 'inventory:manage'),
+    ('vendor', 'po:fulfill'),
+    ('user', 'cart:manage'),
+    ('user', 'po:approve'),
+    ('user', 'product:subscribe')
+) AS p (role, permission) ON p.role = r.name;
+
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.id, r.id
+FROM users u
+JOIN roles r ON r.name = u.role;
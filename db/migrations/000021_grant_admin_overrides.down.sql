@@ -0,0 +1,2 @@
+DELETE FROM role_permissions
+WHERE permission IN ('product:manage', 'po:read');
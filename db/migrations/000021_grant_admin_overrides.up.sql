@@ -0,0 +1,8 @@
+-- Admins may act on resources they do not own: any product and any
+-- purchase order. Owners are checked in the usecases.
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+CROSS JOIN (VALUES ('product:manage'), ('po:read')) AS p (permission)
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,12 @@
+DELETE FROM role_permissions
+WHERE permission = 'organization:manage';
+
+DROP INDEX IF EXISTS purchase_orders_organization_idx;
+
+ALTER TABLE purchase_orders
+    DROP COLUMN IF EXISTS department_id,
+    DROP COLUMN IF EXISTS organization_id;
+
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS departments;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,44 @@
+-- Organizations are the buyer companies. A buyer belongs to at most one
+-- organization, optionally in one of its departments; organization admins
+-- manage its departments and members.
+CREATE TABLE IF NOT EXISTS organizations (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) UNIQUE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS departments (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    cost_center VARCHAR(50) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    organization_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    department_id INTEGER REFERENCES departments(id) ON DELETE SET NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'member',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX organization_members_organization_idx ON organization_members (organization_id);
+
+-- Purchase orders keep the organization and department the buyer was in at
+-- checkout.
+ALTER TABLE purchase_orders
+    ADD COLUMN organization_id INTEGER REFERENCES organizations(id) ON DELETE SET NULL,
+    ADD COLUMN department_id INTEGER REFERENCES departments(id) ON DELETE SET NULL;
+
+CREATE INDEX purchase_orders_organization_idx ON purchase_orders (organization_id, id);
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT id, 'organization:manage' FROM roles
+WHERE name = 'admin'
+ON CONFLICT DO NOTHING;
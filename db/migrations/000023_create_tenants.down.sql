@@ -0,0 +1,18 @@
+DROP INDEX IF EXISTS audit_events_tenant_idx;
+ALTER TABLE audit_events DROP COLUMN IF EXISTS tenant_id;
+
+ALTER TABLE organizations DROP CONSTRAINT IF EXISTS organizations_tenant_name_key;
+ALTER TABLE organizations ADD CONSTRAINT organizations_name_key UNIQUE (name);
+ALTER TABLE organizations DROP COLUMN IF EXISTS tenant_id;
+
+DROP INDEX IF EXISTS purchase_orders_tenant_idx;
+ALTER TABLE purchase_orders DROP COLUMN IF EXISTS tenant_id;
+
+DROP INDEX IF EXISTS products_tenant_idx;
+ALTER TABLE products DROP COLUMN IF EXISTS tenant_id;
+
+DROP INDEX IF EXISTS users_tenant_idx;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_id_tenant_key;
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
+
+DROP TABLE IF EXISTS tenants;
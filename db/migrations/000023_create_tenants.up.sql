@@ -0,0 +1,47 @@
+-- A tenant is one procuring entity, such as a subsidiary, sharing the
+-- deployment with others. Users, products, purchase orders and
+-- organizations belong to one tenant and are only visible within it.
+CREATE TABLE IF NOT EXISTS tenants (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(63) UNIQUE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Everything created before tenants existed belongs to the default tenant.
+INSERT INTO tenants (id, name, slug) VALUES (1, 'Default', 'default');
+SELECT setval(pg_get_serial_sequence('tenants', 'id'), 1);
+
+ALTER TABLE users ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE users ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE users ADD CONSTRAINT users_id_tenant_key UNIQUE (id, tenant_id);
+CREATE INDEX users_tenant_idx ON users (tenant_id, id);
+
+-- Products and purchase orders are in the tenant of their vendor and buyer,
+-- which the composite foreign keys hold to.
+ALTER TABLE products ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE products ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE products ADD CONSTRAINT products_vendor_tenant_fkey
+    FOREIGN KEY (vendor_id, tenant_id) REFERENCES users (id, tenant_id);
+CREATE INDEX products_tenant_idx ON products (tenant_id, id);
+
+ALTER TABLE purchase_orders ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE purchase_orders ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE purchase_orders ADD CONSTRAINT purchase_orders_buyer_tenant_fkey
+    FOREIGN KEY (buyer_id, tenant_id) REFERENCES users (id, tenant_id);
+ALTER TABLE purchase_orders ADD CONSTRAINT purchase_orders_vendor_tenant_fkey
+    FOREIGN KEY (vendor_id, tenant_id) REFERENCES users (id, tenant_id);
+CREATE INDEX purchase_orders_tenant_idx ON purchase_orders (tenant_id, id);
+
+-- Organization names only need to be unique within a tenant.
+ALTER TABLE organizations ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE organizations ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE organizations DROP CONSTRAINT organizations_name_key;
+ALTER TABLE organizations ADD CONSTRAINT organizations_tenant_name_key UNIQUE (tenant_id, name);
+
+-- Audit events are listed per tenant too. The column is not part of the
+-- hash chain: it only decides who may read an event, and events recorded
+-- so far would otherwise no longer verify.
+ALTER TABLE audit_events ADD COLUMN tenant_id INTEGER DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE audit_events ALTER COLUMN tenant_id DROP DEFAULT;
+CREATE INDEX audit_events_tenant_idx ON audit_events (tenant_id, id);
@@ -0,0 +1,13 @@
+INSERT INTO role_permissions (role_id, permission)
+SELECT a.id, rp.permission
+FROM role_permissions rp
+JOIN roles o ON o.id = rp.role_id AND o.name = 'operator'
+CROSS JOIN roles a
+WHERE a.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+UPDATE roles
+SET description = 'Administers users, vendors, roles and the platform', updated_at = CURRENT_TIMESTAMP
+WHERE name = 'admin';
+
+DELETE FROM roles WHERE name = 'operator';
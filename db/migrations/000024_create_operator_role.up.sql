@@ -0,0 +1,24 @@
+-- Roles, webhooks, jobs, login attempts and the audit chain are shared by
+-- every tenant, so managing them is left to the operators of the
+-- deployment rather than to each tenant's admins.
+INSERT INTO roles (name, description, built_in) VALUES
+    ('operator', 'Operates the deployment: roles, webhooks, jobs, login attempts and the audit trail', TRUE);
+
+UPDATE role_permissions
+SET role_id = (SELECT id FROM roles WHERE name = 'operator')
+WHERE role_id = (SELECT id FROM roles WHERE name = 'admin')
+    AND permission IN ('role:manage', 'webhook:manage', 'job:manage', 'login_attempt:read', 'audit:read', 'audit:sign');
+
+UPDATE roles
+SET description = 'Administers the users, vendors and purchasing of a tenant', updated_at = CURRENT_TIMESTAMP
+WHERE name = 'admin';
+
+-- The admins of the default tenant operated the deployment until now.
+INSERT INTO user_roles (user_id, role_id)
+SELECT ur.user_id, o.id
+FROM user_roles ur
+JOIN roles a ON a.id = ur.role_id AND a.name = 'admin'
+JOIN users u ON u.id = ur.user_id AND u.tenant_id = 1
+CROSS JOIN roles o
+WHERE o.name = 'operator'
+ON CONFLICT DO NOTHING;
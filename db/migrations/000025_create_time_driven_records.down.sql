@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS vendor_documents;
+DROP TABLE IF EXISTS approvals;
+DROP TABLE IF EXISTS rfqs;
@@ -0,0 +1,56 @@
+-- The records behind the time-driven procurement rules, kept to what the
+-- scheduler needs. Each belongs to the tenant of its user, which the
+-- composite foreign keys hold to.
+
+-- A request for quotation invites vendors to quote until its deadline, when
+-- the scheduler closes it.
+CREATE TABLE IF NOT EXISTS rfqs (
+    id SERIAL PRIMARY KEY,
+    tenant_id INTEGER NOT NULL REFERENCES tenants(id),
+    buyer_id INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    deadline TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (buyer_id, tenant_id) REFERENCES users (id, tenant_id) ON DELETE CASCADE
+);
+
+CREATE INDEX rfqs_open_deadline_idx ON rfqs (deadline) WHERE status = 'open';
+
+-- An approval asks one approver to sign off a purchase order. idle_since is
+-- when it last moved; a pending approval idle for too long is escalated
+-- once.
+CREATE TABLE IF NOT EXISTS approvals (
+    id SERIAL PRIMARY KEY,
+    tenant_id INTEGER NOT NULL REFERENCES tenants(id),
+    purchase_order_id INTEGER NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    approver_id INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    idle_since TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    escalated_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (approver_id, tenant_id) REFERENCES users (id, tenant_id) ON DELETE CASCADE
+);
+
+CREATE INDEX approvals_pending_idle_idx ON approvals (idle_since)
+    WHERE status = 'pending' AND escalated_at IS NULL;
+
+-- Vendor documents, such as certificates and insurance, expire. The vendor
+-- is reminded once before a document does.
+CREATE TABLE IF NOT EXISTS vendor_documents (
+    id SERIAL PRIMARY KEY,
+    tenant_id INTEGER NOT NULL REFERENCES tenants(id),
+    vendor_id INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    reminded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (vendor_id, tenant_id) REFERENCES users (id, tenant_id) ON DELETE CASCADE
+);
+
+CREATE INDEX vendor_documents_unreminded_expiry_idx ON vendor_documents (expires_at)
+    WHERE reminded_at IS NULL;
@@ -0,0 +1,27 @@
+DROP TRIGGER IF EXISTS user_roles_bump_token_version ON user_roles;
+DROP FUNCTION IF EXISTS user_roles_bump_token_version();
+
+-- Each user gets back the built-in role that best describes their account.
+ALTER TABLE users ADD COLUMN role VARCHAR(50) NOT NULL DEFAULT 'user';
+UPDATE users u
+SET role = r.name
+FROM (
+    SELECT DISTINCT ON (ur.user_id) ur.user_id, r.name
+    FROM user_roles ur
+    JOIN roles r ON r.id = ur.role_id
+    WHERE r.name IN ('admin', 'vendor', 'user')
+    ORDER BY ur.user_id, array_position(ARRAY['admin', 'vendor', 'user']::text[], r.name::text)
+) r
+WHERE r.user_id = u.id;
+ALTER TABLE users ALTER COLUMN role DROP DEFAULT;
+
+CREATE OR REPLACE FUNCTION users_bump_token_version() RETURNS trigger AS $$
+BEGIN
+    IF NEW.role IS DISTINCT FROM OLD.role
+        OR NEW.status IS DISTINCT FROM OLD.status
+        OR NEW.password IS DISTINCT FROM OLD.password THEN
+        NEW.token_version := OLD.token_version + 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
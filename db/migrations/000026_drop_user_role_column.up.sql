@@ -0,0 +1,27 @@
+-- A user's roles are only kept in user_roles. users.role, the kind of
+-- account from before roles existed, was no longer kept in step with them.
+CREATE OR REPLACE FUNCTION users_bump_token_version() RETURNS trigger AS $$
+BEGIN
+    IF NEW.status IS DISTINCT FROM OLD.status
+        OR NEW.password IS DISTINCT FROM OLD.password THEN
+        NEW.token_version := OLD.token_version + 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE users DROP COLUMN role;
+
+-- Access tokens carry the names of the user's roles, so changing them cuts
+-- off the user's access as changing the column did.
+CREATE OR REPLACE FUNCTION user_roles_bump_token_version() RETURNS trigger AS $$
+BEGIN
+    UPDATE users SET token_version = token_version + 1
+    WHERE id = COALESCE(NEW.user_id, OLD.user_id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER user_roles_bump_token_version
+AFTER INSERT OR DELETE ON user_roles
+FOR EACH ROW EXECUTE FUNCTION user_roles_bump_token_version();
@@ -1,21 +1,76 @@
 package app
 
 import (
+	"context"
+
 	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/internal/scheduler"
+	"github.com/zulfikarmuzakir/e_procurement/internal/worker"
 	"github.com/zulfikarmuzakir/e_procurement/pkg/auth"
 
 	"go.uber.org/zap"
 )
 
 type App struct {
-	UserUsecase    domain.UserUsecase
-	ProductUsecase domain.ProductUsecase
-	JWTAuth        *auth.JWTAuth
-	Logger         *zap.Logger
+	UserUsecase              domain.UserUsecase
+	ProductUsecase           domain.ProductUsecase
+	CartUsecase              domain.CartUsecase
+	PurchaseOrderUsecase     domain.PurchaseOrderUsecase
+	InventoryUsecase         domain.InventoryUsecase
+	StockAlertUsecase        domain.StockAlertUsecase
+	NotificationUsecase      domain.NotificationUsecase
+	WebhookUsecase           domain.WebhookUsecase
+	JobUsecase               domain.JobUsecase
+	AuditUsecase             domain.AuditUsecase
+	PasswordResetUsecase     domain.PasswordResetUsecase
+	EmailVerificationUsecase domain.EmailVerificationUsecase
+	MFAUsecase               domain.MFAUsecase
+	SessionUsecase           domain.SessionUsecase
+	LoginAttemptUsecase      domain.LoginAttemptUsecase
+	RoleUsecase              domain.RoleUsecase
+	OrganizationUsecase      domain.OrganizationUsecase
+	TenantUsecase            domain.TenantUsecase
+	RFQUsecase               domain.RFQUsecase
+	ApprovalUsecase          domain.ApprovalUsecase
+	VendorDocumentUsecase    domain.VendorDocumentUsecase
+	RateLimitStore           domain.RateLimitStore
+	RateLimits               domain.RateLimits
+	Workers                  *worker.Pool
+	Scheduler                *scheduler.Scheduler
+	JWTAuth                  *auth.JWTAuth
+	Logger                   *zap.Logger
 }
 
-func NewApp(userUsecase domain.UserUsecase, productUsecase domain.ProductUsecase, jwtAuth *auth.JWTAuth, logger *zap.Logger) *App {
-	return &App{UserUsecase: userUsecase, ProductUsecase: productUsecase, JWTAuth: jwtAuth, Logger: logger}
+func NewApp(userUsecase domain.UserUsecase, productUsecase domain.ProductUsecase, cartUsecase domain.CartUsecase, purchaseOrderUsecase domain.PurchaseOrderUsecase, inventoryUsecase domain.InventoryUsecase, stockAlertUsecase domain.StockAlertUsecase, notificationUsecase domain.NotificationUsecase, webhookUsecase domain.WebhookUsecase, jobUsecase domain.JobUsecase, auditUsecase domain.AuditUsecase, passwordResetUsecase domain.PasswordResetUsecase, emailVerificationUsecase domain.EmailVerificationUsecase, mfaUsecase domain.MFAUsecase, sessionUsecase domain.SessionUsecase, loginAttemptUsecase domain.LoginAttemptUsecase, roleUsecase domain.RoleUsecase, organizationUsecase domain.OrganizationUsecase, tenantUsecase domain.TenantUsecase, rfqUsecase domain.RFQUsecase, approvalUsecase domain.ApprovalUsecase, vendorDocumentUsecase domain.VendorDocumentUsecase, rateLimitStore domain.RateLimitStore, rateLimits domain.RateLimits, workers *worker.Pool, scheduler *scheduler.Scheduler, jwtAuth *auth.JWTAuth, logger *zap.Logger) *App {
+	return &App{
+		UserUsecase:              userUsecase,
+		ProductUsecase:           productUsecase,
+		CartUsecase:              cartUsecase,
+		PurchaseOrderUsecase:     purchaseOrderUsecase,
+		InventoryUsecase:         inventoryUsecase,
+		StockAlertUsecase:        stockAlertUsecase,
+		NotificationUsecase:      notificationUsecase,
+		WebhookUsecase:           webhookUsecase,
+		JobUsecase:               jobUsecase,
+		AuditUsecase:             auditUsecase,
+		PasswordResetUsecase:     passwordResetUsecase,
+		EmailVerificationUsecase: emailVerificationUsecase,
+		MFAUsecase:               mfaUsecase,
+		SessionUsecase:           sessionUsecase,
+		LoginAttemptUsecase:      loginAttemptUsecase,
+		RoleUsecase:              roleUsecase,
+		OrganizationUsecase:      organizationUsecase,
+		TenantUsecase:            tenantUsecase,
+		RFQUsecase:               rfqUsecase,
+		ApprovalUsecase:          approvalUsecase,
+		VendorDocumentUsecase:    vendorDocumentUsecase,
+		RateLimitStore:           rateLimitStore,
+		RateLimits:               rateLimits,
+		Workers:                  workers,
+		Scheduler:                scheduler,
+		JWTAuth:                  jwtAuth,
+		Logger:                   logger,
+	}
 }
 
 // You can add more methods here if needed, such as initialization or shutdown procedures
@@ -25,8 +80,13 @@ func (a *App) Initialize() error {
 	return nil
 }
 
-func (a *App) Shutdown() error {
-	// Perform any necessary cleanup
+// Shutdown stops the scheduler and the background workers, waiting for
+// running jobs until ctx is done.
+func (a *App) Shutdown(ctx context.Context) error {
 	a.Logger.Info("Shutting down application")
-	return nil
+	schedulerErr := a.Scheduler.Shutdown(ctx)
+	if err := a.Workers.Shutdown(ctx); err != nil {
+		return err
+	}
+	return schedulerErr
 }
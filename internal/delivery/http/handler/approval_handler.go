@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/middleware"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type ApprovalHandler struct {
+	ApprovalUsecase domain.ApprovalUsecase
+	Logger          *zap.Logger
+}
+
+func NewApprovalHandler(approvalUsecase domain.ApprovalUsecase, logger *zap.Logger) *ApprovalHandler {
+	return &ApprovalHandler{
+		ApprovalUsecase: approvalUsecase,
+		Logger:          logger,
+	}
+}
+
+// RequestApproval asks an approver to sign off one of the current buyer's
+// pending purchase orders.
+func (h *ApprovalHandler) RequestApproval(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var request domain.ApprovalRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.sendValidationErrorResponse(w, err)
+		return
+	}
+
+	approval, err := h.ApprovalUsecase.Request(id, request, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Approval requested successfully",
+		"data":    approval,
+	})
+}
+
+// ApproveApproval signs off a pending approval assigned to the current user.
+func (h *ApprovalHandler) ApproveApproval(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.ApprovalUsecase.Approve, "Approval approved successfully")
+}
+
+// RejectApproval turns down a pending approval assigned to the current user.
+func (h *ApprovalHandler) RejectApproval(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.ApprovalUsecase.Reject, "Approval rejected successfully")
+}
+
+func (h *ApprovalHandler) decide(w http.ResponseWriter, r *http.Request, decide func(id int64, actor domain.Actor) (*domain.Approval, error), message string) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	approval, err := decide(id, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": message,
+		"data":    approval,
+	})
+}
+
+func (h *ApprovalHandler) sendValidationErrorResponse(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+
+	validationErrors := validator.GetValidationErrors(err)
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": "Validation failed",
+		"data":  validationErrors,
+	})
+}
+
+func (h *ApprovalHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   appErr.Error(),
+		"message": appErr.Message,
+	})
+}
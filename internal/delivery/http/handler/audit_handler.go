@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/middleware"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+
+	"go.uber.org/zap"
+)
+
+type AuditHandler struct {
+	AuditUsecase domain.AuditUsecase
+	Logger       *zap.Logger
+}
+
+func NewAuditHandler(auditUsecase domain.AuditUsecase, logger *zap.Logger) *AuditHandler {
+	return &AuditHandler{
+		AuditUsecase: auditUsecase,
+		Logger:       logger,
+	}
+}
+
+// GetAuditEvents lists audit events, newest first, filtered by tenant_id,
+// actor_id, entity_type, entity_id and the from/to date range.
+func (h *AuditHandler) GetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	filter := domain.AuditFilter{EntityType: params.Get("entity_type")}
+	switch filter.EntityType {
+	case "", domain.AuditEntityUser, domain.AuditEntityProduct, domain.AuditEntityPurchaseOrder, domain.AuditEntityRole, domain.AuditEntityOrganization, domain.AuditEntityDepartment:
+	default:
+		h.sendErrorResponse(w, errors.NewAppError(errors.ErrInvalidInput, "Invalid entity_type parameter", http.StatusBadRequest))
+		return
+	}
+
+	var err error
+	if filter.TenantID, err = parseOptionalInt64(params, "tenant_id"); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+	if filter.ActorID, err = parseOptionalInt64(params, "actor_id"); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+	if filter.EntityID, err = parseOptionalInt64(params, "entity_id"); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+	if filter.From, err = parseOptionalTime(params, "from", false); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+	if filter.To, err = parseOptionalTime(params, "to", true); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	events, err := h.AuditUsecase.GetEvents(filter, pagination.ParseParams(r), middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	pagination.SetLinkHeader(w, r, events.NextCursor)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":     "Audit events retrieved successfully",
+		"data":        events.Items,
+		"total":       events.Total,
+		"next_cursor": events.NextCursor,
+	})
+}
+
+// VerifyAuditLog walks the audit hash chain and reports the first broken
+// link. A broken chain is still a 200: the verification itself succeeded.
+func (h *AuditHandler) VerifyAuditLog(w http.ResponseWriter, r *http.Request) {
+	result, err := h.AuditUsecase.Verify()
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	message := "Audit log verified successfully"
+	if !result.Valid {
+		message = "Audit log verification failed"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": message,
+		"data":    result,
+	})
+}
+
+// CreateCheckpoint signs and exports the current head of the audit chain.
+func (h *AuditHandler) CreateCheckpoint(w http.ResponseWriter, r *http.Request) {
+	checkpoint, err := h.AuditUsecase.CreateCheckpoint()
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Audit checkpoint created successfully",
+		"data":    checkpoint,
+	})
+}
+
+// parseOptionalTime accepts an RFC 3339 timestamp or a date. With endOfDay,
+// a date means the end of that day, so a to=2024-01-31 range includes the
+// whole of January 31.
+func parseOptionalTime(params url.Values, key string, endOfDay bool) (*time.Time, error) {
+	raw := params.Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+	if value, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &value, nil
+	}
+	value, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return nil, errors.NewAppError(err, fmt.Sprintf("Invalid %s parameter", key), http.StatusBadRequest)
+	}
+	if endOfDay {
+		value = value.AddDate(0, 0, 1)
+	}
+	return &value, nil
+}
+
+func (h *AuditHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   appErr.Error(),
+		"message": appErr.Message,
+	})
+}
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/middleware"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type CartHandler struct {
+	CartUsecase domain.CartUsecase
+	Logger      *zap.Logger
+}
+
+func NewCartHandler(cartUsecase domain.CartUsecase, logger *zap.Logger) *CartHandler {
+	return &CartHandler{
+		CartUsecase: cartUsecase,
+		Logger:      logger,
+	}
+}
+
+func (h *CartHandler) GetCart(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	cart, err := h.CartUsecase.GetCart(userID)
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	h.Logger.Info("Cart retrieved successfully", zap.Int64("user_id", userID))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Cart retrieved successfully",
+		"data":    cart,
+	})
+}
+
+func (h *CartHandler) AddItem(w http.ResponseWriter, r *http.Request) {
+	var request domain.CartItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.sendValidationErrorResponse(w, err)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	cart, err := h.CartUsecase.AddItem(userID, request, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	h.Logger.Info("Cart item added successfully", zap.Int64("user_id", userID), zap.Int64("product_id", request.ProductID))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Cart item added successfully",
+		"data":    cart,
+	})
+}
+
+func (h *CartHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	productID, _ := strconv.ParseInt(chi.URLParam(r, "productID"), 10, 64)
+
+	var request domain.CartItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+	request.ProductID = productID
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.sendValidationErrorResponse(w, err)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	cart, err := h.CartUsecase.UpdateItem(userID, request)
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	h.Logger.Info("Cart item updated successfully", zap.Int64("user_id", userID), zap.Int64("product_id", productID))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Cart item updated successfully",
+		"data":    cart,
+	})
+}
+
+func (h *CartHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	productID, _ := strconv.ParseInt(chi.URLParam(r, "productID"), 10, 64)
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	cart, err := h.CartUsecase.RemoveItem(userID, productID)
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	h.Logger.Info("Cart item removed successfully", zap.Int64("user_id", userID), zap.Int64("product_id", productID))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Cart item removed successfully",
+		"data":    cart,
+	})
+}
+
+func (h *CartHandler) ClearCart(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	if err := h.CartUsecase.Clear(userID); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	h.Logger.Info("Cart cleared successfully", zap.Int64("user_id", userID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CartHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	orders, err := h.CartUsecase.Checkout(userID, middleware.GetActor(r))
+	if err != nil {
+		// list the offending items so the buyer can fix the cart
+		if appErr, ok := err.(*errors.AppError); ok {
+			if checkoutErr, ok := appErr.Err.(*domain.CheckoutError); ok {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(appErr.Code)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"error":   appErr.Error(),
+					"message": appErr.Message,
+					"data":    checkoutErr.Issues,
+				})
+				return
+			}
+		}
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	h.Logger.Info("Cart checked out successfully", zap.Int64("user_id", userID), zap.Int("purchase_orders", len(orders)))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Checkout completed successfully",
+		"data":    orders,
+	})
+}
+
+func (h *CartHandler) sendValidationErrorResponse(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+
+	validationErrors := validator.GetValidationErrors(err)
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": "Validation failed",
+		"data":  validationErrors,
+	})
+}
+
+func (h *CartHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   appErr.Error(),
+		"message": appErr.Message,
+	})
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/middleware"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/validator"
+
+	"go.uber.org/zap"
+)
+
+type EmailVerificationHandler struct {
+	EmailVerificationUsecase domain.EmailVerificationUsecase
+	Logger                   *zap.Logger
+}
+
+func NewEmailVerificationHandler(emailVerificationUsecase domain.EmailVerificationUsecase, logger *zap.Logger) *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		EmailVerificationUsecase: emailVerificationUsecase,
+		Logger:                   logger,
+	}
+}
+
+// VerifyEmail is the target of the link in verification emails.
+func (h *EmailVerificationHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		h.sendErrorResponse(w, errors.NewAppError(errors.ErrInvalidInput, "Missing token parameter", http.StatusBadRequest))
+		return
+	}
+
+	if err := h.EmailVerificationUsecase.Verify(token, middleware.GetActor(r)); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Email verified successfully",
+	})
+}
+
+// ResendVerification sends another verification email. The response is
+// the same whether or not the email is registered.
+func (h *EmailVerificationHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	var request domain.ResendVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.Logger.Error("Invalid resend verification data", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid resend verification data", http.StatusBadRequest))
+		return
+	}
+
+	if err := h.EmailVerificationUsecase.Resend(request.Email); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "If the email is registered and not yet verified, a verification link has been sent",
+	})
+}
+
+func (h *EmailVerificationHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   appErr.Error(),
+		"message": appErr.Message,
+	})
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/middleware"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+)
+
+// The fakes below keep rows in memory and honour tenant scoping as the
+// postgres repositories do. Methods the tests do not reach are left to the
+// embedded interface and panic if called.
+
+type fakeUserRepository struct {
+	domain.UserRepository
+	users    map[int64]*domain.User
+	tenantID int64
+}
+
+func (f *fakeUserRepository) ForTenant(tenantID int64) domain.UserRepository {
+	return &fakeUserRepository{users: f.users, tenantID: tenantID}
+}
+
+func (f *fakeUserRepository) GetByID(id int64) (*domain.User, error) {
+	user, ok := f.users[id]
+	if !ok || user.TenantID != f.tenantID {
+		return nil, pgx.ErrNoRows
+	}
+	found := *user
+	return &found, nil
+}
+
+func (f *fakeUserRepository) Update(user *domain.User) error {
+	stored, ok := f.users[user.ID]
+	if !ok || stored.TenantID != f.tenantID {
+		return nil
+	}
+	// Like the postgres repository, it leaves the tenant and roles alone.
+	updated := *user
+	updated.TenantID = stored.TenantID
+	updated.Roles = stored.Roles
+	f.users[user.ID] = &updated
+	return nil
+}
+
+func (f *fakeUserRepository) Delete(id int64) error {
+	if user, ok := f.users[id]; ok && user.TenantID == f.tenantID {
+		delete(f.users, id)
+	}
+	return nil
+}
+
+type fakeProductRepository struct {
+	domain.ProductRepository
+	products map[int64]*domain.Product
+	tenantID int64
+}
+
+func (f *fakeProductRepository) ForTenant(tenantID int64) domain.ProductRepository {
+	return &fakeProductRepository{products: f.products, tenantID: tenantID}
+}
+
+func (f *fakeProductRepository) GetByID(id int64) (*domain.Product, error) {
+	product, ok := f.products[id]
+	if !ok || product.TenantID != f.tenantID {
+		return nil, pgx.ErrNoRows
+	}
+	found := *product
+	return &found, nil
+}
+
+func (f *fakeProductRepository) Delete(id int64) error {
+	if product, ok := f.products[id]; ok && product.TenantID == f.tenantID {
+		delete(f.products, id)
+	}
+	return nil
+}
+
+func (f *fakeProductRepository) Update(product *domain.Product) error {
+	stored, ok := f.products[product.ID]
+	if !ok || stored.TenantID != f.tenantID {
+		return nil
+	}
+	updated := *product
+	updated.TenantID = stored.TenantID
+	f.products[product.ID] = &updated
+	return nil
+}
+
+type fakePurchaseOrderRepository struct {
+	domain.PurchaseOrderRepository
+	orders   map[int64]*domain.PurchaseOrder
+	tenantID int64
+}
+
+func (f *fakePurchaseOrderRepository) ForTenant(tenantID int64) domain.PurchaseOrderRepository {
+	return &fakePurchaseOrderRepository{orders: f.orders, tenantID: tenantID}
+}
+
+func (f *fakePurchaseOrderRepository) GetByID(id int64) (*domain.PurchaseOrder, error) {
+	order, ok := f.orders[id]
+	if !ok || order.TenantID != f.tenantID {
+		return nil, pgx.ErrNoRows
+	}
+	found := *order
+	return &found, nil
+}
+
+func (f *fakePurchaseOrderRepository) Transition(id int64, to string, by *int64) (*domain.PurchaseOrder, error) {
+	order, ok := f.orders[id]
+	if !ok || order.TenantID != f.tenantID {
+		return nil, pgx.ErrNoRows
+	}
+	if !domain.CanTransitionPurchaseOrder(order.Status, to) {
+		return nil, errors.ErrInvalidTransition
+	}
+	order.Status = to
+	updated := *order
+	return &updated, nil
+}
+
+// fakeInventoryRepository keeps stock on the products of a
+// fakeProductRepository, as the postgres one keeps it on the products table.
+type fakeInventoryRepository struct {
+	domain.InventoryRepository
+	products map[int64]*domain.Product
+	tenantID int64
+}
+
+func (f *fakeInventoryRepository) ForTenant(tenantID int64) domain.InventoryRepository {
+	return &fakeInventoryRepository{products: f.products, tenantID: tenantID}
+}
+
+func (f *fakeInventoryRepository) GetBalance(productID int64) (*domain.InventoryBalance, error) {
+	product, ok := f.products[productID]
+	if !ok || product.TenantID != f.tenantID {
+		return nil, pgx.ErrNoRows
+	}
+	return &domain.InventoryBalance{ProductID: productID, OnHand: product.Stock, Reserved: product.ReservedStock, Available: product.Stock - product.ReservedStock}, nil
+}
+
+func (f *fakeInventoryRepository) GetMovements(productID int64, afterID *int64, limit int) ([]domain.InventoryMovement, error) {
+	return nil, nil
+}
+
+func (f *fakeInventoryRepository) CountMovements(productID int64) (int64, error) {
+	return 0, nil
+}
+
+func (f *fakeInventoryRepository) Record(movement *domain.InventoryMovement) error {
+	product, ok := f.products[movement.ProductID]
+	if !ok || product.TenantID != f.tenantID {
+		return pgx.ErrNoRows
+	}
+	if product.Stock+movement.OnHandDelta < product.ReservedStock {
+		return errors.ErrInsufficientStock
+	}
+	product.Stock += movement.OnHandDelta
+	return nil
+}
+
+type fakeJobRepository struct {
+	domain.JobRepository
+	jobs []*domain.Job
+}
+
+func (f *fakeJobRepository) Enqueue(jobs ...*domain.Job) error {
+	f.jobs = append(f.jobs, jobs...)
+	return nil
+}
+
+type fakeAuditRepository struct {
+	domain.AuditRepository
+	events []*domain.AuditEvent
+}
+
+func (f *fakeAuditRepository) Record(event *domain.AuditEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+// fakeTransactor runs fn straight away with the fakes scoped to the tenant.
+type fakeTransactor struct {
+	users    *fakeUserRepository
+	products *fakeProductRepository
+	orders   *fakePurchaseOrderRepository
+	jobs     *fakeJobRepository
+	audit    *fakeAuditRepository
+}
+
+// newFakeTransactor returns a transactor over empty fakes.
+func newFakeTransactor() *fakeTransactor {
+	return &fakeTransactor{
+		users:    &fakeUserRepository{users: map[int64]*domain.User{}},
+		products: &fakeProductRepository{products: map[int64]*domain.Product{}},
+		orders:   &fakePurchaseOrderRepository{orders: map[int64]*domain.PurchaseOrder{}},
+		jobs:     &fakeJobRepository{},
+		audit:    &fakeAuditRepository{},
+	}
+}
+
+func (t *fakeTransactor) WithinTransaction(tenantID int64, fn func(repos domain.Repositories) error) error {
+	return fn(domain.Repositories{
+		Users:          t.users.ForTenant(tenantID),
+		Products:       t.products.ForTenant(tenantID),
+		PurchaseOrders: t.orders.ForTenant(tenantID),
+		Jobs:           t.jobs,
+		Audit:          t.audit,
+	})
+}
+
+func (t *fakeTransactor) WithinSystemTransaction(fn func(repos domain.Repositories) error) error {
+	panic("fakeTransactor: system transactions are not faked")
+}
+
+// newRequest builds a request for a route with an id parameter, made by
+// userID in tenantID holding permissions, as JWTAuth would leave it.
+func newRequest(method, target, id string, body io.Reader, userID, tenantID int64, permissions ...string) *http.Request {
+	r := httptest.NewRequest(method, target, body)
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add("id", id)
+	ctx := context.WithValue(r.Context(), chi.RouteCtxKey, routeContext)
+	ctx = context.WithValue(ctx, middleware.UserIDKey, userID)
+	ctx = context.WithValue(ctx, middleware.PermissionsKey, permissions)
+	ctx = context.WithValue(ctx, middleware.TenantIDKey, tenantID)
+	return r.WithContext(ctx)
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/middleware"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type InventoryHandler struct {
+	InventoryUsecase domain.InventoryUsecase
+	Logger           *zap.Logger
+}
+
+func NewInventoryHandler(inventoryUsecase domain.InventoryUsecase, logger *zap.Logger) *InventoryHandler {
+	return &InventoryHandler{
+		InventoryUsecase: inventoryUsecase,
+		Logger:           logger,
+	}
+}
+
+func (h *InventoryHandler) GetBalance(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	balance, err := h.InventoryUsecase.GetBalance(id, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	h.Logger.Info("Inventory balance retrieved successfully", zap.Int64("product_id", id))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Inventory balance retrieved successfully",
+		"data":    balance,
+	})
+}
+
+func (h *InventoryHandler) GetMovements(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	movements, err := h.InventoryUsecase.GetMovements(id, pagination.ParseParams(r), middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	h.Logger.Info("Inventory movements retrieved successfully", zap.Int64("product_id", id), zap.Int("count", len(movements.Items)))
+	pagination.SetLinkHeader(w, r, movements.NextCursor)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":     "Inventory movements retrieved successfully",
+		"data":        movements.Items,
+		"total":       movements.Total,
+		"next_cursor": movements.NextCursor,
+	})
+}
+
+func (h *InventoryHandler) Receive(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var request domain.InventoryReceiptRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.sendValidationErrorResponse(w, err)
+		return
+	}
+
+	movement, err := h.InventoryUsecase.Receive(id, request, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Stock received successfully",
+		"data":    movement,
+	})
+}
+
+func (h *InventoryHandler) Adjust(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var request domain.InventoryAdjustmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.sendValidationErrorResponse(w, err)
+		return
+	}
+
+	movement, err := h.InventoryUsecase.Adjust(id, request, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Stock adjusted successfully",
+		"data":    movement,
+	})
+}
+
+func (h *InventoryHandler) sendValidationErrorResponse(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+
+	validationErrors := validator.GetValidationErrors(err)
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": "Validation failed",
+		"data":  validationErrors,
+	})
+}
+
+func (h *InventoryHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   appErr.Error(),
+		"message": appErr.Message,
+	})
+}
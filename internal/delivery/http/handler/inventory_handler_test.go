@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/internal/usecase"
+	"go.uber.org/zap"
+)
+
+func TestInventoryHandlerOwnership(t *testing.T) {
+	routes := []struct {
+		name      string
+		method    string
+		handler   func(h *InventoryHandler) http.HandlerFunc
+		body      string
+		okCode    int
+		wantStock int
+	}{
+		{name: "balance", method: http.MethodGet, handler: func(h *InventoryHandler) http.HandlerFunc { return h.GetBalance }, okCode: http.StatusOK, wantStock: 10},
+		{name: "movements", method: http.MethodGet, handler: func(h *InventoryHandler) http.HandlerFunc { return h.GetMovements }, okCode: http.StatusOK, wantStock: 10},
+		{name: "receive", method: http.MethodPost, handler: func(h *InventoryHandler) http.HandlerFunc { return h.Receive }, body: `{"quantity":5}`, okCode: http.StatusCreated, wantStock: 15},
+		{name: "adjust", method: http.MethodPost, handler: func(h *InventoryHandler) http.HandlerFunc { return h.Adjust }, body: `{"quantity":-4,"note":"stock count"}`, okCode: http.StatusCreated, wantStock: 6},
+	}
+	// The routes require inventory:manage, and vendors only manage the
+	// stock of their own products: product:manage does not extend to it.
+	actors := []struct {
+		name        string
+		userID      int64
+		tenantID    int64
+		permissions []string
+		wantOK      bool
+		wantCode    int
+	}{
+		{name: "owner", userID: 7, tenantID: 1, wantOK: true},
+		{name: "non-owner", userID: 8, tenantID: 1, wantCode: http.StatusForbidden},
+		{name: "admin", userID: 1, tenantID: 1, permissions: []string{domain.PermissionProductManage}, wantCode: http.StatusForbidden},
+		{name: "cross-tenant owner id", userID: 7, tenantID: 2, wantCode: http.StatusNotFound},
+	}
+
+	for _, route := range routes {
+		for _, actor := range actors {
+			t.Run(route.name+"/"+actor.name, func(t *testing.T) {
+				transactor := newFakeTransactor()
+				transactor.products.products[3] = &domain.Product{ID: 3, TenantID: 1, VendorID: 7, Name: "Paper", Price: 100, Stock: 10}
+				inventory := &fakeInventoryRepository{products: transactor.products.products}
+				h := NewInventoryHandler(usecase.NewInventoryUsecase(inventory, transactor.products, zap.NewNop()), zap.NewNop())
+
+				var body io.Reader
+				if route.body != "" {
+					body = strings.NewReader(route.body)
+				}
+				permissions := append([]string{domain.PermissionInventoryManage}, actor.permissions...)
+				w := httptest.NewRecorder()
+				route.handler(h)(w, newRequest(route.method, "/products/3/inventory", "3", body, actor.userID, actor.tenantID, permissions...))
+
+				wantCode, wantStock := actor.wantCode, 10
+				if actor.wantOK {
+					wantCode, wantStock = route.okCode, route.wantStock
+				}
+				if w.Code != wantCode {
+					t.Errorf("status = %d, want %d: %s", w.Code, wantCode, w.Body)
+				}
+				if got := transactor.products.products[3].Stock; got != wantStock {
+					t.Errorf("stock = %d, want %d", got, wantStock)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type JobHandler struct {
+	JobUsecase domain.JobUsecase
+	Logger     *zap.Logger
+}
+
+func NewJobHandler(jobUsecase domain.JobUsecase, logger *zap.Logger) *JobHandler {
+	return &JobHandler{
+		JobUsecase: jobUsecase,
+		Logger:     logger,
+	}
+}
+
+// GetJobs lists outbox jobs, newest first, optionally filtered by status.
+func (h *JobHandler) GetJobs(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", domain.JobStatusPending, domain.JobStatusRunning, domain.JobStatusSucceeded, domain.JobStatusDead:
+	default:
+		h.sendErrorResponse(w, errors.NewAppError(errors.ErrInvalidInput, "Invalid status", http.StatusBadRequest))
+		return
+	}
+
+	jobs, err := h.JobUsecase.GetJobs(status, pagination.ParseParams(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	pagination.SetLinkHeader(w, r, jobs.NextCursor)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":     "Jobs retrieved successfully",
+		"data":        jobs.Items,
+		"total":       jobs.Total,
+		"next_cursor": jobs.NextCursor,
+	})
+}
+
+func (h *JobHandler) RetryJob(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.JobUsecase.Retry(id); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Job queued for retry",
+	})
+}
+
+func (h *JobHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   appErr.Error(),
+		"message": appErr.Message,
+	})
+}
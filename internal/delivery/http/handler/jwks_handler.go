@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zulfikarmuzakir/e_procurement/pkg/auth"
+
+	"go.uber.org/zap"
+)
+
+type JWKSHandler struct {
+	JWTAuth *auth.JWTAuth
+	Logger  *zap.Logger
+}
+
+func NewJWKSHandler(jwtAuth *auth.JWTAuth, logger *zap.Logger) *JWKSHandler {
+	return &JWKSHandler{
+		JWTAuth: jwtAuth,
+		Logger:  logger,
+	}
+}
+
+// GetJWKS publishes the public keys access tokens can be verified with.
+// Verifiers may cache the response for 5 minutes.
+func (h *JWKSHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.JWTAuth.JWKS())
+}
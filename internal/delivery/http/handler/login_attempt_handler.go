@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+
+	"go.uber.org/zap"
+)
+
+type LoginAttemptHandler struct {
+	LoginAttemptUsecase domain.LoginAttemptUsecase
+	Logger              *zap.Logger
+}
+
+func NewLoginAttemptHandler(loginAttemptUsecase domain.LoginAttemptUsecase, logger *zap.Logger) *LoginAttemptHandler {
+	return &LoginAttemptHandler{
+		LoginAttemptUsecase: loginAttemptUsecase,
+		Logger:              logger,
+	}
+}
+
+// GetLoginAttempts lists login attempts, newest first, filtered by user_id,
+// email, ip, success and the from/to date range.
+func (h *LoginAttemptHandler) GetLoginAttempts(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	filter := domain.LoginAttemptFilter{
+		Email: params.Get("email"),
+		IP:    params.Get("ip"),
+	}
+
+	var err error
+	if filter.UserID, err = parseOptionalInt64(params, "user_id"); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+	if success := params.Get("success"); success != "" {
+		value, err := strconv.ParseBool(success)
+		if err != nil {
+			h.sendErrorResponse(w, errors.NewAppError(err, "Invalid success parameter", http.StatusBadRequest))
+			return
+		}
+		filter.Success = &value
+	}
+	if filter.From, err = parseOptionalTime(params, "from", false); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+	if filter.To, err = parseOptionalTime(params, "to", true); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	attempts, err := h.LoginAttemptUsecase.GetAttempts(filter, pagination.ParseParams(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	pagination.SetLinkHeader(w, r, attempts.NextCursor)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":     "Login attempts retrieved successfully",
+		"data":        attempts.Items,
+		"total":       attempts.Total,
+		"next_cursor": attempts.NextCursor,
+	})
+}
+
+func (h *LoginAttemptHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   appErr.Error(),
+		"message": appErr.Message,
+	})
+}
@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/middleware"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain/converter"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/validator"
+
+	"go.uber.org/zap"
+)
+
+type MFAHandler struct {
+	MFAUsecase domain.MFAUsecase
+	Logger     *zap.Logger
+}
+
+func NewMFAHandler(mfaUsecase domain.MFAUsecase, logger *zap.Logger) *MFAHandler {
+	return &MFAHandler{
+		MFAUsecase: mfaUsecase,
+		Logger:     logger,
+	}
+}
+
+// VerifyLogin finishes a login that returned mfa_required.
+func (h *MFAHandler) VerifyLogin(w http.ResponseWriter, r *http.Request) {
+	var request domain.MFAVerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.Logger.Error("Invalid MFA data", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid MFA data", http.StatusBadRequest))
+		return
+	}
+
+	result, err := h.MFAUsecase.VerifyLogin(request.ChallengeToken, request.Code, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"access_token":  result.AccessToken,
+		"refresh_token": result.RefreshToken,
+		"data":          converter.UserToUserResponse(result.User),
+	}
+	if len(result.RecoveryCodes) > 0 {
+		response["recovery_codes"] = result.RecoveryCodes
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// EnrollWithChallenge starts enrollment for a login that returned
+// mfa_enrollment_required. The first code is then sent to /login/mfa.
+func (h *MFAHandler) EnrollWithChallenge(w http.ResponseWriter, r *http.Request) {
+	var request domain.MFAChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.Logger.Error("Invalid MFA data", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid MFA data", http.StatusBadRequest))
+		return
+	}
+
+	enrollment, err := h.MFAUsecase.EnrollWithChallenge(request.ChallengeToken)
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Add the secret to an authenticator app, then log in with its code",
+		"data":    enrollment,
+	})
+}
+
+func (h *MFAHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	status, err := h.MFAUsecase.GetStatus(userID)
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "MFA status retrieved successfully",
+		"data":    status,
+	})
+}
+
+func (h *MFAHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	enrollment, err := h.MFAUsecase.Enroll(userID)
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Add the secret to an authenticator app, then confirm with its code",
+		"data":    enrollment,
+	})
+}
+
+func (h *MFAHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	userID, request, ok := h.decodeCodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	recoveryCodes, err := h.MFAUsecase.Confirm(userID, request.Code, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "MFA enabled, store the recovery codes somewhere safe",
+		"data":    map[string][]string{"recovery_codes": recoveryCodes},
+	})
+}
+
+func (h *MFAHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	userID, request, ok := h.decodeCodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.MFAUsecase.Disable(userID, request.Code, middleware.GetActor(r)); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "MFA disabled",
+	})
+}
+
+func (h *MFAHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userID, request, ok := h.decodeCodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	recoveryCodes, err := h.MFAUsecase.RegenerateRecoveryCodes(userID, request.Code)
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Recovery codes regenerated, the old ones no longer work",
+		"data":    map[string][]string{"recovery_codes": recoveryCodes},
+	})
+}
+
+// decodeCodeRequest reads the signed-in user and the code confirming the
+// change. It writes the error response and returns false on failure.
+func (h *MFAHandler) decodeCodeRequest(w http.ResponseWriter, r *http.Request) (int64, domain.MFACodeRequest, bool) {
+	var request domain.MFACodeRequest
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return 0, request, false
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return 0, request, false
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.Logger.Error("Invalid MFA data", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid MFA data", http.StatusBadRequest))
+		return 0, request, false
+	}
+
+	return userID, request, true
+}
+
+func (h *MFAHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   appErr.Error(),
+		"message": appErr.Message,
+	})
+}
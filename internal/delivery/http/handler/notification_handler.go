@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/middleware"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type NotificationHandler struct {
+	NotificationUsecase domain.NotificationUsecase
+	Logger              *zap.Logger
+}
+
+func NewNotificationHandler(notificationUsecase domain.NotificationUsecase, logger *zap.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		NotificationUsecase: notificationUsecase,
+		Logger:              logger,
+	}
+}
+
+// GetNotifications lists the current user's in-app inbox, newest first.
+// Pass unread=true to list only unread notifications.
+func (h *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	var unreadOnly bool
+	if unread := r.URL.Query().Get("unread"); unread != "" {
+		var err error
+		if unreadOnly, err = strconv.ParseBool(unread); err != nil {
+			h.sendErrorResponse(w, errors.NewAppError(err, "Invalid unread, must be a boolean", http.StatusBadRequest))
+			return
+		}
+	}
+
+	inbox, err := h.NotificationUsecase.GetInbox(userID, unreadOnly, pagination.ParseParams(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	pagination.SetLinkHeader(w, r, inbox.NextCursor)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":     "Notifications retrieved successfully",
+		"data":        inbox.Items,
+		"total":       inbox.Total,
+		"unread":      inbox.Unread,
+		"next_cursor": inbox.NextCursor,
+	})
+}
+
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	h.setRead(w, r, true)
+}
+
+func (h *NotificationHandler) MarkUnread(w http.ResponseWriter, r *http.Request) {
+	h.setRead(w, r, false)
+}
+
+func (h *NotificationHandler) setRead(w http.ResponseWriter, r *http.Request, read bool) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	if err := h.NotificationUsecase.SetRead(id, userID, read); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	count, err := h.NotificationUsecase.MarkAllRead(userID)
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Notifications marked as read",
+		"data":    map[string]int64{"updated": count},
+	})
+}
+
+func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	preferences, err := h.NotificationUsecase.GetPreferences(userID)
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Notification preferences retrieved successfully",
+		"data":    preferences,
+	})
+}
+
+func (h *NotificationHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	var preferences domain.NotificationPreferences
+	if err := json.NewDecoder(r.Body).Decode(&preferences); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := h.NotificationUsecase.UpdatePreferences(userID, preferences); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Notification preferences updated successfully",
+		"data":    preferences,
+	})
+}
+
+func (h *NotificationHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   appErr.Error(),
+		"message": appErr.Message,
+	})
+}
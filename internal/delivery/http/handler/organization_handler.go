@@ -0,0 +1,324 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/middleware"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type OrganizationHandler struct {
+	OrganizationUsecase domain.OrganizationUsecase
+	Logger              *zap.Logger
+}
+
+func NewOrganizationHandler(organizationUsecase domain.OrganizationUsecase, logger *zap.Logger) *OrganizationHandler {
+	return &OrganizationHandler{
+		OrganizationUsecase: organizationUsecase,
+		Logger:              logger,
+	}
+}
+
+func (h *OrganizationHandler) GetOrganizations(w http.ResponseWriter, r *http.Request) {
+	organizations, err := h.OrganizationUsecase.GetAll(pagination.ParseParams(r), middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	pagination.SetLinkHeader(w, r, organizations.NextCursor)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":     "Organizations retrieved successfully",
+		"data":        organizations.Items,
+		"total":       organizations.Total,
+		"next_cursor": organizations.NextCursor,
+	})
+}
+
+func (h *OrganizationHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	organization, err := h.OrganizationUsecase.GetByID(id, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Organization retrieved successfully",
+		"data":    organization,
+	})
+}
+
+func (h *OrganizationHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var request domain.OrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.sendValidationErrorResponse(w, err)
+		return
+	}
+
+	organization, err := h.OrganizationUsecase.Create(request, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Organization created successfully",
+		"data":    organization,
+	})
+}
+
+func (h *OrganizationHandler) UpdateOrganization(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var request domain.OrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.sendValidationErrorResponse(w, err)
+		return
+	}
+
+	organization, err := h.OrganizationUsecase.Update(id, request, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Organization updated successfully",
+		"data":    organization,
+	})
+}
+
+func (h *OrganizationHandler) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.OrganizationUsecase.Delete(id, middleware.GetActor(r)); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetMyOrganization returns the organization membership of the current
+// user.
+func (h *OrganizationHandler) GetMyOrganization(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	member, err := h.OrganizationUsecase.GetMembership(userID, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Organization membership retrieved successfully",
+		"data":    member,
+	})
+}
+
+func (h *OrganizationHandler) GetDepartments(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	departments, err := h.OrganizationUsecase.GetDepartments(id, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Departments retrieved successfully",
+		"data":    departments,
+	})
+}
+
+func (h *OrganizationHandler) CreateDepartment(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var request domain.DepartmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.sendValidationErrorResponse(w, err)
+		return
+	}
+
+	department, err := h.OrganizationUsecase.CreateDepartment(id, request, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Department created successfully",
+		"data":    department,
+	})
+}
+
+func (h *OrganizationHandler) UpdateDepartment(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	departmentID, _ := strconv.ParseInt(chi.URLParam(r, "departmentID"), 10, 64)
+
+	var request domain.DepartmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.sendValidationErrorResponse(w, err)
+		return
+	}
+
+	department, err := h.OrganizationUsecase.UpdateDepartment(id, departmentID, request, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Department updated successfully",
+		"data":    department,
+	})
+}
+
+func (h *OrganizationHandler) DeleteDepartment(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	departmentID, _ := strconv.ParseInt(chi.URLParam(r, "departmentID"), 10, 64)
+
+	if err := h.OrganizationUsecase.DeleteDepartment(id, departmentID, middleware.GetActor(r)); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *OrganizationHandler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	members, err := h.OrganizationUsecase.GetMembers(id, pagination.ParseParams(r), middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	pagination.SetLinkHeader(w, r, members.NextCursor)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":     "Organization members retrieved successfully",
+		"data":        members.Items,
+		"total":       members.Total,
+		"next_cursor": members.NextCursor,
+	})
+}
+
+// SetMember adds a buyer to the organization or changes their department
+// and role.
+func (h *OrganizationHandler) SetMember(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	userID, _ := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
+
+	var request domain.OrganizationMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.sendValidationErrorResponse(w, err)
+		return
+	}
+
+	member, err := h.OrganizationUsecase.SetMember(id, userID, request, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Organization member saved successfully",
+		"data":    member,
+	})
+}
+
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	userID, _ := strconv.ParseInt(chi.URLParam(r, "userID"), 10, 64)
+
+	if err := h.OrganizationUsecase.RemoveMember(id, userID, middleware.GetActor(r)); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *OrganizationHandler) sendValidationErrorResponse(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+
+	validationErrors := validator.GetValidationErrors(err)
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": "Validation failed",
+		"data":  validationErrors,
+	})
+}
+
+func (h *OrganizationHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   appErr.Error(),
+		"message": appErr.Message,
+	})
+}
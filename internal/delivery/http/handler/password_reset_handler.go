@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/middleware"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/validator"
+
+	"go.uber.org/zap"
+)
+
+type PasswordResetHandler struct {
+	PasswordResetUsecase domain.PasswordResetUsecase
+	Logger               *zap.Logger
+}
+
+func NewPasswordResetHandler(passwordResetUsecase domain.PasswordResetUsecase, logger *zap.Logger) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		PasswordResetUsecase: passwordResetUsecase,
+		Logger:               logger,
+	}
+}
+
+// ForgotPassword emails a reset link. The response is the same whether or
+// not the email is registered.
+func (h *PasswordResetHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var request domain.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.Logger.Error("Invalid forgot password data", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid forgot password data", http.StatusBadRequest))
+		return
+	}
+
+	if err := h.PasswordResetUsecase.ForgotPassword(request.Email); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "If the email is registered, a password reset link has been sent",
+	})
+}
+
+// ResetPassword sets a new password with a token from a reset link.
+func (h *PasswordResetHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var request domain.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.Logger.Error("Invalid reset password data", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid reset password data", http.StatusBadRequest))
+		return
+	}
+
+	if err := h.PasswordResetUsecase.ResetPassword(request, middleware.GetActor(r)); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Password reset successfully",
+	})
+}
+
+func (h *PasswordResetHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   appErr.Error(),
+		"message": appErr.Message,
+	})
+}
@@ -4,11 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/middleware"
 	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
 	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
 	"github.com/zulfikarmuzakir/e_procurement/pkg/validator"
 
 	"github.com/go-chi/chi/v5"
@@ -49,7 +51,7 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.ProductUsecase.CreateProduct(&product); err != nil {
+	if err := h.ProductUsecase.CreateProduct(&product, middleware.GetActor(r)); err != nil {
 		h.sendErrorResponse(w, err)
 		return
 	}
@@ -64,29 +66,64 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *ProductHandler) GetAllProducts(w http.ResponseWriter, r *http.Request) {
-	name := r.URL.Query().Get("name")
-	limit, _ := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
-	if limit == 0 {
-		limit = 10
+	params := r.URL.Query()
+
+	// q is the full-text search term; name is kept for older clients.
+	query := params.Get("q")
+	if query == "" {
+		query = params.Get("name")
+	}
+	filter := domain.ProductFilter{
+		Query:    query,
+		Category: params.Get("category"),
+		Sort:     params.Get("sort"),
 	}
-	offset, _ := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
 
-	if name == "" {
-		fmt.Println("name is empty")
-		name = "%"
+	var err error
+	if filter.MinPrice, err = parseOptionalInt32(params, "min_price"); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+	if filter.MaxPrice, err = parseOptionalInt32(params, "max_price"); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+	if filter.VendorID, err = parseOptionalInt64(params, "vendor_id"); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+	maxLeadTime, err := parseOptionalInt32(params, "max_lead_time_days")
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+	if maxLeadTime != nil {
+		days := int(*maxLeadTime)
+		filter.MaxLeadTimeDays = &days
+	}
+	if inStock := params.Get("in_stock"); inStock != "" {
+		filter.InStock, err = strconv.ParseBool(inStock)
+		if err != nil {
+			h.sendErrorResponse(w, errors.NewAppError(err, "Invalid in_stock parameter", http.StatusBadRequest))
+			return
+		}
 	}
 
-	products, err := h.ProductUsecase.GetAll(name, int(limit), int(offset))
+	list, err := h.ProductUsecase.GetAll(filter, pagination.ParseParams(r), middleware.GetActor(r))
 	if err != nil {
 		h.sendErrorResponse(w, err)
 		return
 	}
 
-	h.Logger.Info("Products retrieved successfully", zap.Int("count", len(products)))
+	h.Logger.Info("Products retrieved successfully", zap.Int("count", len(list.Items)))
+	pagination.SetLinkHeader(w, r, list.NextCursor)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"message": "Products retrieved successfully",
-		"data":    products,
+		"message":     "Products retrieved successfully",
+		"data":        list.Items,
+		"total":       list.Total,
+		"next_cursor": list.NextCursor,
+		"facets":      list.Facets,
 	})
 }
 
@@ -98,30 +135,27 @@ func (h *ProductHandler) GetMyProducts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	limit, _ := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
-	if limit == 0 {
-		limit = 10
-	}
-	offset, _ := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
-
-	products, err := h.ProductUsecase.GetProductsByVendorID(userID, int(limit), int(offset))
+	products, err := h.ProductUsecase.GetProductsByVendorID(userID, pagination.ParseParams(r), middleware.GetActor(r))
 	if err != nil {
 		h.sendErrorResponse(w, err)
 		return
 	}
 
-	h.Logger.Info("My products retrieved successfully", zap.Int("count", len(products)))
+	h.Logger.Info("My products retrieved successfully", zap.Int("count", len(products.Items)))
+	pagination.SetLinkHeader(w, r, products.NextCursor)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"message": "My products retrieved successfully",
-		"data":    products,
+		"message":     "My products retrieved successfully",
+		"data":        products.Items,
+		"total":       products.Total,
+		"next_cursor": products.NextCursor,
 	})
 }
 
 func (h *ProductHandler) GetProductByID(w http.ResponseWriter, r *http.Request) {
 	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 
-	product, err := h.ProductUsecase.GetProductByID(id)
+	product, err := h.ProductUsecase.GetProductByID(id, middleware.GetActor(r))
 	if err != nil {
 		h.sendErrorResponse(w, err)
 		return
@@ -151,17 +185,9 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID, ok := middleware.GetUserIDFromContext(r.Context())
-	if !ok {
-		h.Logger.Error("Failed to get user ID from context")
-		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
-		return
-	}
-
 	product.ID = id
-	product.VendorID = userID
 
-	if err := h.ProductUsecase.UpdateProduct(&product); err != nil {
+	if err := h.ProductUsecase.UpdateProduct(&product, middleware.GetActor(r)); err != nil {
 		h.sendErrorResponse(w, err)
 		return
 	}
@@ -178,7 +204,7 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
 	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 
-	if err := h.ProductUsecase.DeleteProduct(id); err != nil {
+	if err := h.ProductUsecase.DeleteProduct(id, middleware.GetActor(r)); err != nil {
 		h.sendErrorResponse(w, err)
 		return
 	}
@@ -210,3 +236,30 @@ func (h *ProductHandler) sendErrorResponse(w http.ResponseWriter, err error) {
 	w.WriteHeader(appErr.Code)
 	json.NewEncoder(w).Encode(map[string]string{"error": appErr.Message})
 }
+
+// parseOptionalInt32 returns nil when the query parameter is absent.
+func parseOptionalInt32(params url.Values, key string) (*int32, error) {
+	raw := params.Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil {
+		return nil, errors.NewAppError(err, fmt.Sprintf("Invalid %s parameter", key), http.StatusBadRequest)
+	}
+	v := int32(value)
+	return &v, nil
+}
+
+// parseOptionalInt64 returns nil when the query parameter is absent.
+func parseOptionalInt64(params url.Values, key string) (*int64, error) {
+	raw := params.Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil, errors.NewAppError(err, fmt.Sprintf("Invalid %s parameter", key), http.StatusBadRequest)
+	}
+	return &value, nil
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/internal/usecase"
+	"go.uber.org/zap"
+)
+
+func newProductHandlerTest() (*ProductHandler, *fakeTransactor) {
+	transactor := newFakeTransactor()
+	transactor.products.products[3] = &domain.Product{ID: 3, TenantID: 1, VendorID: 7, Name: "Paper", Price: 100}
+	h := NewProductHandler(usecase.NewProductUsecase(transactor.products, transactor, zap.NewNop()), zap.NewNop())
+	return h, transactor
+}
+
+func TestProductHandlerUpdateProduct(t *testing.T) {
+	const body = `{"name":"Recycled paper","price":120,"vendor_id":8}`
+
+	tests := []struct {
+		name        string
+		userID      int64
+		tenantID    int64
+		permissions []string
+		wantCode    int
+		wantUpdated bool
+	}{
+		{name: "owner", userID: 7, tenantID: 1, permissions: []string{domain.PermissionProductWrite}, wantCode: http.StatusOK, wantUpdated: true},
+		{name: "owner without product:write", userID: 7, tenantID: 1, wantCode: http.StatusForbidden},
+		{name: "non-owner", userID: 8, tenantID: 1, permissions: []string{domain.PermissionProductWrite}, wantCode: http.StatusForbidden},
+		{name: "admin override", userID: 1, tenantID: 1, permissions: []string{domain.PermissionProductManage}, wantCode: http.StatusOK, wantUpdated: true},
+		{name: "cross-tenant admin", userID: 20, tenantID: 2, permissions: []string{domain.PermissionProductManage}, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, transactor := newProductHandlerTest()
+			w := httptest.NewRecorder()
+			h.UpdateProduct(w, newRequest(http.MethodPut, "/products/3", "3", strings.NewReader(body), tt.userID, tt.tenantID, tt.permissions...))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			stored := transactor.products.products[3]
+			wantName := "Paper"
+			if tt.wantUpdated {
+				wantName = "Recycled paper"
+			}
+			if stored.Name != wantName {
+				t.Errorf("stored name = %q, want %q", stored.Name, wantName)
+			}
+			// The vendor stays the same, whoever updates the product.
+			if stored.VendorID != 7 || stored.TenantID != 1 {
+				t.Errorf("stored vendor, tenant = %d, %d, want 7, 1", stored.VendorID, stored.TenantID)
+			}
+			wantEvents := 0
+			if tt.wantUpdated {
+				wantEvents = 1
+			}
+			if len(transactor.audit.events) != wantEvents {
+				t.Errorf("audit events = %d, want %d", len(transactor.audit.events), wantEvents)
+			}
+		})
+	}
+}
+
+func TestProductHandlerDeleteProduct(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      int64
+		tenantID    int64
+		permissions []string
+		wantCode    int
+		wantDeleted bool
+	}{
+		{name: "owner", userID: 7, tenantID: 1, permissions: []string{domain.PermissionProductWrite}, wantCode: http.StatusNoContent, wantDeleted: true},
+		{name: "owner without product:write", userID: 7, tenantID: 1, wantCode: http.StatusForbidden},
+		{name: "non-owner", userID: 8, tenantID: 1, permissions: []string{domain.PermissionProductWrite}, wantCode: http.StatusForbidden},
+		{name: "admin override", userID: 1, tenantID: 1, permissions: []string{domain.PermissionProductManage}, wantCode: http.StatusNoContent, wantDeleted: true},
+		{name: "cross-tenant admin", userID: 20, tenantID: 2, permissions: []string{domain.PermissionProductManage}, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, transactor := newProductHandlerTest()
+			w := httptest.NewRecorder()
+			h.DeleteProduct(w, newRequest(http.MethodDelete, "/products/3", "3", nil, tt.userID, tt.tenantID, tt.permissions...))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			if _, kept := transactor.products.products[3]; kept == tt.wantDeleted {
+				t.Errorf("product deleted = %v, want %v", !kept, tt.wantDeleted)
+			}
+			wantEvents := 0
+			if tt.wantDeleted {
+				wantEvents = 1
+			}
+			if len(transactor.audit.events) != wantEvents {
+				t.Errorf("audit events = %d, want %d", len(transactor.audit.events), wantEvents)
+			}
+		})
+	}
+}
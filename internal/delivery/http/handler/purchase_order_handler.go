@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/middleware"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type PurchaseOrderHandler struct {
+	PurchaseOrderUsecase domain.PurchaseOrderUsecase
+	Logger               *zap.Logger
+}
+
+func NewPurchaseOrderHandler(purchaseOrderUsecase domain.PurchaseOrderUsecase, logger *zap.Logger) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{
+		PurchaseOrderUsecase: purchaseOrderUsecase,
+		Logger:               logger,
+	}
+}
+
+func (h *PurchaseOrderHandler) GetPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	order, err := h.PurchaseOrderUsecase.GetByID(id, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	h.Logger.Info("Purchase order retrieved successfully", zap.Int64("purchase_order_id", id))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Purchase order retrieved successfully",
+		"data":    order,
+	})
+}
+
+// GetMyPurchaseOrders lists the purchase orders placed by the current buyer.
+func (h *PurchaseOrderHandler) GetMyPurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	orders, err := h.PurchaseOrderUsecase.GetByBuyerID(userID, pagination.ParseParams(r), middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	h.Logger.Info("Purchase orders retrieved successfully", zap.Int("count", len(orders.Items)))
+	pagination.SetLinkHeader(w, r, orders.NextCursor)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":     "Purchase orders retrieved successfully",
+		"data":        orders.Items,
+		"total":       orders.Total,
+		"next_cursor": orders.NextCursor,
+	})
+}
+
+// GetOrganizationPurchaseOrders lists the purchase orders of an
+// organization. Pass department_id for one of its departments only.
+func (h *PurchaseOrderHandler) GetOrganizationPurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	departmentID, err := parseOptionalInt64(r.URL.Query(), "department_id")
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	orders, err := h.PurchaseOrderUsecase.GetByOrganizationID(id, departmentID, pagination.ParseParams(r), middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	h.Logger.Info("Purchase orders retrieved successfully", zap.Int("count", len(orders.Items)))
+	pagination.SetLinkHeader(w, r, orders.NextCursor)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":     "Purchase orders retrieved successfully",
+		"data":        orders.Items,
+		"total":       orders.Total,
+		"next_cursor": orders.NextCursor,
+	})
+}
+
+// GetVendorPurchaseOrders lists the purchase orders issued to the current
+// vendor.
+func (h *PurchaseOrderHandler) GetVendorPurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	orders, err := h.PurchaseOrderUsecase.GetByVendorID(userID, pagination.ParseParams(r), middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	h.Logger.Info("Vendor purchase orders retrieved successfully", zap.Int("count", len(orders.Items)))
+	pagination.SetLinkHeader(w, r, orders.NextCursor)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":     "Purchase orders retrieved successfully",
+		"data":        orders.Items,
+		"total":       orders.Total,
+		"next_cursor": orders.NextCursor,
+	})
+}
+
+// ConfirmPurchaseOrder issues a pending purchase order to its vendor.
+func (h *PurchaseOrderHandler) ConfirmPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	h.updateStatus(w, r, h.PurchaseOrderUsecase.Confirm, "Purchase order confirmed successfully")
+}
+
+// CancelPurchaseOrder cancels a pending or issued purchase order and releases
+// its reserved stock.
+func (h *PurchaseOrderHandler) CancelPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	h.updateStatus(w, r, h.PurchaseOrderUsecase.Cancel, "Purchase order cancelled successfully")
+}
+
+// ShipPurchaseOrder marks an issued purchase order as shipped by the current
+// vendor.
+func (h *PurchaseOrderHandler) ShipPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	h.updateStatus(w, r, h.PurchaseOrderUsecase.Ship, "Purchase order shipped successfully")
+}
+
+func (h *PurchaseOrderHandler) updateStatus(w http.ResponseWriter, r *http.Request, update func(id, userID int64, actor domain.Actor) (*domain.PurchaseOrder, error), message string) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	order, err := update(id, userID, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": message,
+		"data":    order,
+	})
+}
+
+func (h *PurchaseOrderHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   appErr.Error(),
+		"message": appErr.Message,
+	})
+}
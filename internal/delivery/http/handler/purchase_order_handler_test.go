@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// newPurchaseOrderHandlerTest returns a handler over order 5, placed by
+// buyer 7 with vendor 9 in tenant 1 and in status.
+func newPurchaseOrderHandlerTest(status string) (*PurchaseOrderHandler, *fakeTransactor) {
+	transactor := newFakeTransactor()
+	transactor.orders.orders[5] = &domain.PurchaseOrder{ID: 5, TenantID: 1, BuyerID: 7, VendorID: 9, Status: status, TotalAmount: 300}
+	h := NewPurchaseOrderHandler(usecase.NewPurchaseOrderUsecase(transactor.orders, nil, transactor, zap.NewNop()), zap.NewNop())
+	return h, transactor
+}
+
+func TestPurchaseOrderHandlerGetPurchaseOrder(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      int64
+		tenantID    int64
+		permissions []string
+		wantCode    int
+	}{
+		{name: "buyer", userID: 7, tenantID: 1, wantCode: http.StatusOK},
+		{name: "vendor", userID: 9, tenantID: 1, wantCode: http.StatusOK},
+		{name: "non-owner", userID: 8, tenantID: 1, wantCode: http.StatusForbidden},
+		{name: "admin override", userID: 1, tenantID: 1, permissions: []string{domain.PermissionPORead}, wantCode: http.StatusOK},
+		{name: "cross-tenant admin", userID: 20, tenantID: 2, permissions: []string{domain.PermissionPORead}, wantCode: http.StatusNotFound},
+		{name: "cross-tenant buyer id", userID: 7, tenantID: 2, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newPurchaseOrderHandlerTest(domain.PurchaseOrderStatusPending)
+			w := httptest.NewRecorder()
+			h.GetPurchaseOrder(w, newRequest(http.MethodGet, "/purchase-orders/5", "5", nil, tt.userID, tt.tenantID, tt.permissions...))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+		})
+	}
+}
+
+func TestPurchaseOrderHandlerTransitions(t *testing.T) {
+	// Transitions belong to one party of the order. po:read lets an admin
+	// see any order, not act on it.
+	tests := []struct {
+		name        string
+		transition  func(h *PurchaseOrderHandler) http.HandlerFunc
+		from        string
+		userID      int64
+		tenantID    int64
+		permissions []string
+		wantCode    int
+		wantStatus  string
+	}{
+		{name: "buyer confirms", transition: confirm, from: domain.PurchaseOrderStatusPending, userID: 7, tenantID: 1, wantCode: http.StatusOK, wantStatus: domain.PurchaseOrderStatusIssued},
+		{name: "vendor confirms", transition: confirm, from: domain.PurchaseOrderStatusPending, userID: 9, tenantID: 1, wantCode: http.StatusForbidden},
+		{name: "non-owner confirms", transition: confirm, from: domain.PurchaseOrderStatusPending, userID: 8, tenantID: 1, wantCode: http.StatusForbidden},
+		{name: "admin confirms", transition: confirm, from: domain.PurchaseOrderStatusPending, userID: 1, tenantID: 1, permissions: []string{domain.PermissionPORead}, wantCode: http.StatusForbidden},
+		{name: "cross-tenant buyer id confirms", transition: confirm, from: domain.PurchaseOrderStatusPending, userID: 7, tenantID: 2, wantCode: http.StatusNotFound},
+		{name: "buyer cancels", transition: cancel, from: domain.PurchaseOrderStatusIssued, userID: 7, tenantID: 1, wantCode: http.StatusOK, wantStatus: domain.PurchaseOrderStatusCancelled},
+		{name: "non-owner cancels", transition: cancel, from: domain.PurchaseOrderStatusIssued, userID: 8, tenantID: 1, wantCode: http.StatusForbidden},
+		{name: "vendor ships", transition: ship, from: domain.PurchaseOrderStatusIssued, userID: 9, tenantID: 1, wantCode: http.StatusOK, wantStatus: domain.PurchaseOrderStatusShipped},
+		{name: "buyer ships", transition: ship, from: domain.PurchaseOrderStatusIssued, userID: 7, tenantID: 1, wantCode: http.StatusForbidden},
+		{name: "cross-tenant vendor id ships", transition: ship, from: domain.PurchaseOrderStatusIssued, userID: 9, tenantID: 2, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, transactor := newPurchaseOrderHandlerTest(tt.from)
+			w := httptest.NewRecorder()
+			tt.transition(h)(w, newRequest(http.MethodPost, "/purchase-orders/5", "5", nil, tt.userID, tt.tenantID, tt.permissions...))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			wantStatus := tt.from
+			if tt.wantStatus != "" {
+				wantStatus = tt.wantStatus
+			}
+			if got := transactor.orders.orders[5].Status; got != wantStatus {
+				t.Errorf("stored status = %q, want %q", got, wantStatus)
+			}
+			wantEvents := 0
+			if tt.wantCode == http.StatusOK {
+				wantEvents = 1
+			}
+			if len(transactor.audit.events) != wantEvents {
+				t.Errorf("audit events = %d, want %d", len(transactor.audit.events), wantEvents)
+			}
+			if wantEvents == 1 && len(transactor.jobs.jobs) == 0 {
+				t.Error("no notification enqueued for the other party")
+			}
+		})
+	}
+}
+
+func confirm(h *PurchaseOrderHandler) http.HandlerFunc { return h.ConfirmPurchaseOrder }
+func cancel(h *PurchaseOrderHandler) http.HandlerFunc  { return h.CancelPurchaseOrder }
+func ship(h *PurchaseOrderHandler) http.HandlerFunc    { return h.ShipPurchaseOrder }
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/middleware"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/validator"
+
+	"go.uber.org/zap"
+)
+
+type RFQHandler struct {
+	RFQUsecase domain.RFQUsecase
+	Logger     *zap.Logger
+}
+
+func NewRFQHandler(rfqUsecase domain.RFQUsecase, logger *zap.Logger) *RFQHandler {
+	return &RFQHandler{
+		RFQUsecase: rfqUsecase,
+		Logger:     logger,
+	}
+}
+
+// CreateRFQ opens a request for quotation for the current buyer. It closes
+// at its deadline.
+func (h *RFQHandler) CreateRFQ(w http.ResponseWriter, r *http.Request) {
+	var request domain.RFQRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.sendValidationErrorResponse(w, err)
+		return
+	}
+
+	rfq, err := h.RFQUsecase.Create(request, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "RFQ created successfully",
+		"data":    rfq,
+	})
+}
+
+func (h *RFQHandler) sendValidationErrorResponse(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+
+	validationErrors := validator.GetValidationErrors(err)
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": "Validation failed",
+		"data":  validationErrors,
+	})
+}
+
+func (h *RFQHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   appErr.Error(),
+		"message": appErr.Message,
+	})
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/middleware"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/validator"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type SessionHandler struct {
+	SessionUsecase domain.SessionUsecase
+	Logger         *zap.Logger
+}
+
+func NewSessionHandler(sessionUsecase domain.SessionUsecase, logger *zap.Logger) *SessionHandler {
+	return &SessionHandler{
+		SessionUsecase: sessionUsecase,
+		Logger:         logger,
+	}
+}
+
+// RefreshToken exchanges a refresh token for a new access token and a new
+// refresh token. The old refresh token stops working.
+func (h *SessionHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	request, ok := h.decodeRefreshTokenRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.SessionUsecase.Refresh(request.RefreshToken, middleware.GetActor(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"access_token":  result.AccessToken,
+		"refresh_token": result.RefreshToken,
+	})
+}
+
+// Logout ends the session of the refresh token.
+func (h *SessionHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	request, ok := h.decodeRefreshTokenRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.SessionUsecase.Logout(request.RefreshToken); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Logged out successfully",
+	})
+}
+
+// LogoutAll ends every session of the signed-in user.
+func (h *SessionHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	revoked, err := h.SessionUsecase.RevokeAll(userID, domain.SessionRevokedLogoutAll)
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Logged out of all sessions",
+		"data":    map[string]int64{"revoked": revoked},
+	})
+}
+
+// GetMySessions lists where the signed-in user is logged in.
+func (h *SessionHandler) GetMySessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+	currentSessionID, _ := middleware.GetSessionIDFromContext(r.Context())
+
+	h.sendSessions(w, userID, currentSessionID)
+}
+
+// RevokeMySession logs the signed-in user out of one of their sessions.
+func (h *SessionHandler) RevokeMySession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+	sessionID, _ := strconv.ParseInt(chi.URLParam(r, "sessionID"), 10, 64)
+
+	if err := h.SessionUsecase.Revoke(userID, sessionID, domain.SessionRevokedByUser); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Session revoked successfully",
+	})
+}
+
+// GetUserSessions lists a user's sessions for an admin.
+func (h *SessionHandler) GetUserSessions(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err := h.SessionUsecase.CheckUser(id, middleware.GetActor(r)); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+	h.sendSessions(w, id, 0)
+}
+
+// RevokeUserSession revokes one of a user's sessions for an admin.
+func (h *SessionHandler) RevokeUserSession(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	sessionID, _ := strconv.ParseInt(chi.URLParam(r, "sessionID"), 10, 64)
+
+	if err := h.SessionUsecase.CheckUser(id, middleware.GetActor(r)); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+	if err := h.SessionUsecase.Revoke(id, sessionID, domain.SessionRevokedByAdmin); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Session revoked successfully",
+	})
+}
+
+// RevokeUserSessions revokes every session of a user for an admin, e.g.
+// when the account is compromised.
+func (h *SessionHandler) RevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.SessionUsecase.CheckUser(id, middleware.GetActor(r)); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+	revoked, err := h.SessionUsecase.RevokeAll(id, domain.SessionRevokedByAdmin)
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Sessions revoked successfully",
+		"data":    map[string]int64{"revoked": revoked},
+	})
+}
+
+func (h *SessionHandler) sendSessions(w http.ResponseWriter, userID, currentSessionID int64) {
+	sessions, err := h.SessionUsecase.GetActive(userID, currentSessionID)
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Sessions retrieved successfully",
+		"data":    sessions,
+	})
+}
+
+func (h *SessionHandler) decodeRefreshTokenRequest(w http.ResponseWriter, r *http.Request) (domain.RefreshTokenRequest, bool) {
+	var request domain.RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return request, false
+	}
+
+	if err := validator.ValidateStruct(request); err != nil {
+		h.Logger.Error("Invalid refresh token data", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid refresh token data", http.StatusBadRequest))
+		return request, false
+	}
+	return request, true
+}
+
+func (h *SessionHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   appErr.Error(),
+		"message": appErr.Message,
+	})
+}
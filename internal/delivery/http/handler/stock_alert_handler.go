@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/middleware"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type StockAlertHandler struct {
+	StockAlertUsecase domain.StockAlertUsecase
+	Logger            *zap.Logger
+}
+
+func NewStockAlertHandler(stockAlertUsecase domain.StockAlertUsecase, logger *zap.Logger) *StockAlertHandler {
+	return &StockAlertHandler{
+		StockAlertUsecase: stockAlertUsecase,
+		Logger:            logger,
+	}
+}
+
+// Subscribe follows a product for back-in-stock alerts.
+func (h *StockAlertHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	if err := h.StockAlertUsecase.Subscribe(userID, id, middleware.GetActor(r)); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Subscribed to product successfully",
+	})
+}
+
+func (h *StockAlertHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	if err := h.StockAlertUsecase.Unsubscribe(userID, id); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetStockAlerts lists the current user's stock alerts, newest first.
+func (h *StockAlertHandler) GetStockAlerts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		h.Logger.Error("Failed to get user ID from context")
+		h.sendErrorResponse(w, errors.NewAppError(nil, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	alerts, err := h.StockAlertUsecase.GetAlerts(userID, pagination.ParseParams(r))
+	if err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	pagination.SetLinkHeader(w, r, alerts.NextCursor)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":     "Stock alerts retrieved successfully",
+		"data":        alerts.Items,
+		"total":       alerts.Total,
+		"next_cursor": alerts.NextCursor,
+	})
+}
+
+func (h *StockAlertHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   appErr.Error(),
+		"message": appErr.Message,
+	})
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"math"
 	"net/http"
 	"strconv"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
 	"github.com/zulfikarmuzakir/e_procurement/internal/domain/converter"
 	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
 	"github.com/zulfikarmuzakir/e_procurement/pkg/validator"
 
 	"github.com/go-chi/chi/v5"
@@ -41,10 +43,10 @@ func (h *UserHandler) RegisterVendor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user.Role = "vendor"
+	user.Roles = []string{domain.RoleVendor}
 	user.Status = "pending"
 
-	if err := h.UserUsecase.Register(&user); err != nil {
+	if err := h.UserUsecase.Register(&user, middleware.GetActor(r)); err != nil {
 		h.sendErrorResponse(w, err)
 		return
 	}
@@ -57,6 +59,39 @@ func (h *UserHandler) RegisterVendor(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// CreateBuyer creates an active buyer account, who can then use the cart
+// and check out. Buyers do not register themselves.
+func (h *UserHandler) CreateBuyer(w http.ResponseWriter, r *http.Request) {
+	var user domain.User
+	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+		h.Logger.Error("Failed to decode request body", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
+		return
+	}
+
+	if err := validator.ValidateStruct(user); err != nil {
+		h.Logger.Error("Invalid user data", zap.Error(err))
+		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid user data", http.StatusBadRequest))
+		return
+	}
+
+	user.Roles = []string{domain.RoleUser}
+	user.Status = "active"
+
+	if err := h.UserUsecase.Register(&user, middleware.GetActor(r)); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	h.Logger.Info("Buyer created successfully", zap.String("email", user.Email))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Buyer created successfully",
+		"data":    converter.UserToUserResponse(&user),
+	})
+}
+
 func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var loginRequest struct {
 		Email    string `json:"email" validate:"required,email"`
@@ -75,19 +110,26 @@ func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.UserUsecase.Login(loginRequest.Email, loginRequest.Password)
+	result, err := h.UserUsecase.Login(loginRequest.Email, loginRequest.Password, middleware.GetActor(r))
 	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			if throttled, ok := appErr.Err.(*domain.LoginThrottledError); ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			}
+		}
 		h.sendErrorResponse(w, err)
 		return
 	}
 
-	user, err := h.UserUsecase.GetByEmail(loginRequest.Email)
-	if err != nil {
-		h.sendErrorResponse(w, err)
+	// The login is finished with a code at /login/mfa.
+	if result.MFARequired {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(result)
 		return
 	}
 
-	userResponse := converter.UserToUserResponse(user)
+	userResponse := converter.UserToUserResponse(result.User)
 
 	h.Logger.Info("User logged in successfully", zap.String("email", loginRequest.Email))
 
@@ -95,8 +137,8 @@ func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"access_token":  accessToken,
-		"refresh_token": refreshToken,
+		"access_token":  result.AccessToken,
+		"refresh_token": result.RefreshToken,
 		"data":          userResponse,
 	})
 }
@@ -104,7 +146,7 @@ func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 func (h *UserHandler) ApproveVendor(w http.ResponseWriter, r *http.Request) {
 	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 
-	if err := h.UserUsecase.ApproveVendor(id); err != nil {
+	if err := h.UserUsecase.ApproveVendor(id, middleware.GetActor(r)); err != nil {
 		h.sendErrorResponse(w, err)
 		return
 	}
@@ -120,7 +162,7 @@ func (h *UserHandler) ApproveVendor(w http.ResponseWriter, r *http.Request) {
 func (h *UserHandler) RejectVendor(w http.ResponseWriter, r *http.Request) {
 	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 
-	if err := h.UserUsecase.RejectVendor(id); err != nil {
+	if err := h.UserUsecase.RejectVendor(id, middleware.GetActor(r)); err != nil {
 		h.sendErrorResponse(w, err)
 		return
 	}
@@ -133,10 +175,27 @@ func (h *UserHandler) RejectVendor(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// UnlockUser lifts a login lockout and clears the user's failed logins.
+func (h *UserHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.UserUsecase.Unlock(id, middleware.GetActor(r)); err != nil {
+		h.sendErrorResponse(w, err)
+		return
+	}
+
+	h.Logger.Info("User unlocked successfully", zap.Int64("user_id", id))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "User unlocked successfully",
+	})
+}
+
 func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 
-	user, err := h.UserUsecase.GetByID(id)
+	user, err := h.UserUsecase.GetByID(id, middleware.GetActor(r))
 	if err != nil {
 		h.sendErrorResponse(w, err)
 		return
@@ -145,20 +204,34 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	h.Logger.Info("User retrieved successfully", zap.Int64("user_id", id))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(user)
+	json.NewEncoder(w).Encode(converter.UserToUserResponse(user))
 }
 
+// GetAllVendor lists vendors with a verified email, the approval queue.
+// Pass include_unverified=true to list every vendor.
 func (h *UserHandler) GetAllVendor(w http.ResponseWriter, r *http.Request) {
-	users, err := h.UserUsecase.GetAllByRole("vendor")
+	verifiedOnly := r.URL.Query().Get("include_unverified") != "true"
+	users, err := h.UserUsecase.GetAllByRole("vendor", verifiedOnly, pagination.ParseParams(r), middleware.GetActor(r))
 	if err != nil {
 		h.sendErrorResponse(w, err)
 		return
 	}
 
+	vendors := make([]*domain.UserResponse, len(users.Items))
+	for i, user := range users.Items {
+		vendors[i] = converter.UserToUserResponse(user)
+	}
+
 	h.Logger.Info("Get all vendors successfully")
+	pagination.SetLinkHeader(w, r, users.NextCursor)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(users)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"message":     "Vendors retrieved successfully",
+		"data":        vendors,
+		"total":       users.Total,
+		"next_cursor": users.NextCursor,
+	})
 }
 
 func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
@@ -173,7 +246,7 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 
 	user.ID = id
 
-	if err := h.UserUsecase.Update(&user); err != nil {
+	if err := h.UserUsecase.Update(&user, middleware.GetActor(r)); err != nil {
 		h.sendErrorResponse(w, err)
 		return
 	}
@@ -204,7 +277,7 @@ func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.UserUsecase.Delete(id); err != nil {
+	if err := h.UserUsecase.Delete(id, middleware.GetActor(r)); err != nil {
 		h.sendErrorResponse(w, err)
 		return
 	}
@@ -225,7 +298,7 @@ func (h *UserHandler) GetUserMe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.UserUsecase.GetByID(currentUserID)
+	user, err := h.UserUsecase.GetByID(currentUserID, middleware.GetActor(r))
 	if err != nil {
 		h.sendErrorResponse(w, err)
 		return
@@ -234,39 +307,7 @@ func (h *UserHandler) GetUserMe(w http.ResponseWriter, r *http.Request) {
 	h.Logger.Info("User me retrieved successfully", zap.Int64("user_id", currentUserID))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(user)
-}
-
-// New feature: Refresh token endpoint
-func (h *UserHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
-	var refreshRequest struct {
-		RefreshToken string `json:"refresh_token" validate:"required"`
-	}
-
-	if err := json.NewDecoder(r.Body).Decode(&refreshRequest); err != nil {
-		h.Logger.Error("Failed to decode request body", zap.Error(err))
-		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid request body", http.StatusBadRequest))
-		return
-	}
-
-	if err := validator.ValidateStruct(refreshRequest); err != nil {
-		h.Logger.Error("Invalid refresh token data", zap.Error(err))
-		h.sendErrorResponse(w, errors.NewAppError(err, "Invalid refresh token data", http.StatusBadRequest))
-		return
-	}
-
-	newAccessToken, err := h.UserUsecase.RefreshToken(refreshRequest.RefreshToken)
-	if err != nil {
-		h.sendErrorResponse(w, err)
-		return
-	}
-
-	h.Logger.Info("Access token refreshed successfully")
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{
-		"access_token": newAccessToken,
-	})
+	json.NewEncoder(w).Encode(converter.UserToUserResponse(user))
 }
 
 func (h *UserHandler) sendErrorResponse(w http.ResponseWriter, err error) {
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/internal/usecase"
+	"go.uber.org/zap"
+)
+
+func newUserHandlerTest() (*UserHandler, *fakeUserRepository, *fakeAuditRepository) {
+	transactor := newFakeTransactor()
+	transactor.users.users[7] = &domain.User{ID: 7, TenantID: 1, Name: "Owner", Email: "owner@example.com", Password: "hash", Status: "active", Roles: []string{domain.RoleUser}}
+	transactor.users.users[8] = &domain.User{ID: 8, TenantID: 1, Name: "Other", Email: "other@example.com", Password: "hash", Status: "active", Roles: []string{domain.RoleUser}}
+	userUsecase := usecase.NewUserUsecase(transactor.users, nil, nil, nil, transactor, nil, zap.NewNop())
+	return NewUserHandler(userUsecase, zap.NewNop()), transactor.users, transactor.audit
+}
+
+func TestUserHandlerGetUser(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      int64
+		tenantID    int64
+		permissions []string
+		wantCode    int
+	}{
+		{name: "owner", userID: 7, tenantID: 1, wantCode: http.StatusOK},
+		{name: "non-owner", userID: 8, tenantID: 1, wantCode: http.StatusForbidden},
+		{name: "admin override", userID: 1, tenantID: 1, permissions: []string{domain.PermissionUserRead}, wantCode: http.StatusOK},
+		{name: "cross-tenant admin", userID: 20, tenantID: 2, permissions: []string{domain.PermissionUserRead}, wantCode: http.StatusNotFound},
+		{name: "cross-tenant owner id", userID: 7, tenantID: 2, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _, _ := newUserHandlerTest()
+			w := httptest.NewRecorder()
+			h.GetUser(w, newRequest(http.MethodGet, "/users/7", "7", nil, tt.userID, tt.tenantID, tt.permissions...))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			if w.Code == http.StatusOK {
+				assertNoPassword(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestUserHandlerGetUserMe(t *testing.T) {
+	h, _, _ := newUserHandlerTest()
+	w := httptest.NewRecorder()
+	h.GetUserMe(w, newRequest(http.MethodGet, "/users/me", "", nil, 7, 1))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var got domain.UserResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.ID != 7 || !slices.Equal(got.Roles, []string{domain.RoleUser}) {
+		t.Errorf("response = %+v, want user 7 with roles [%s]", got, domain.RoleUser)
+	}
+	assertNoPassword(t, w.Body.String())
+}
+
+// assertNoPassword fails when a response body carries the password field
+// or the stored hash.
+func assertNoPassword(t *testing.T, body string) {
+	t.Helper()
+	if strings.Contains(body, `"password"`) || strings.Contains(body, `"hash"`) {
+		t.Errorf("response leaks the password hash: %s", body)
+	}
+}
+
+func TestUserHandlerUpdateUser(t *testing.T) {
+	const body = `{"name":"Renamed","email":"owner@example.com","role":"admin","status":"suspended"}`
+
+	tests := []struct {
+		name           string
+		userID         int64
+		tenantID       int64
+		permissions    []string
+		wantCode       int
+		wantName       string
+		wantUserStatus string
+	}{
+		{name: "owner keeps status", userID: 7, tenantID: 1, wantCode: http.StatusOK, wantName: "Renamed", wantUserStatus: "active"},
+		{name: "non-owner", userID: 8, tenantID: 1, wantCode: http.StatusForbidden, wantName: "Owner", wantUserStatus: "active"},
+		{name: "admin override", userID: 1, tenantID: 1, permissions: []string{domain.PermissionUserWrite}, wantCode: http.StatusOK, wantName: "Renamed", wantUserStatus: "suspended"},
+		{name: "cross-tenant admin", userID: 20, tenantID: 2, permissions: []string{domain.PermissionUserWrite}, wantCode: http.StatusNotFound, wantName: "Owner", wantUserStatus: "active"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, users, audit := newUserHandlerTest()
+			w := httptest.NewRecorder()
+			h.UpdateUser(w, newRequest(http.MethodPut, "/users/7", "7", strings.NewReader(body), tt.userID, tt.tenantID, tt.permissions...))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			stored := users.users[7]
+			if stored.Name != tt.wantName || stored.Status != tt.wantUserStatus {
+				t.Errorf("stored user = %q %q, want %q %q", stored.Name, stored.Status, tt.wantName, tt.wantUserStatus)
+			}
+			// Roles only change through the role endpoints.
+			if !slices.Equal(stored.Roles, []string{domain.RoleUser}) {
+				t.Errorf("stored roles = %v, want [%s]", stored.Roles, domain.RoleUser)
+			}
+			if stored.Password != "hash" {
+				t.Errorf("stored password = %q, want it kept", stored.Password)
+			}
+			if stored.TenantID != 1 {
+				t.Errorf("stored tenant = %d, want 1", stored.TenantID)
+			}
+			wantEvents := 0
+			if tt.wantCode == http.StatusOK {
+				wantEvents = 1
+			}
+			if len(audit.events) != wantEvents {
+				t.Errorf("audit events = %d, want %d", len(audit.events), wantEvents)
+			}
+		})
+	}
+}
+
+func TestUserHandlerDeleteUser(t *testing.T) {
+	// The route requires user:delete; the handler and usecase add the
+	// self and tenant checks.
+	tests := []struct {
+		name        string
+		userID      int64
+		tenantID    int64
+		wantCode    int
+		wantDeleted bool
+	}{
+		{name: "admin", userID: 1, tenantID: 1, wantCode: http.StatusOK, wantDeleted: true},
+		{name: "self", userID: 7, tenantID: 1, wantCode: http.StatusForbidden},
+		{name: "cross-tenant admin", userID: 20, tenantID: 2, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, users, audit := newUserHandlerTest()
+			w := httptest.NewRecorder()
+			h.DeleteUser(w, newRequest(http.MethodDelete, "/users/7", "7", nil, tt.userID, tt.tenantID, domain.PermissionUserDelete))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			if _, kept := users.users[7]; kept == tt.wantDeleted {
+				t.Errorf("user deleted = %v, want %v", !kept, tt.wantDeleted)
+			}
+			wantEvents := 0
+			if tt.wantDeleted {
+				wantEvents = 1
+			}
+			if len(audit.events) != wantEvents {
+				t.Errorf("audit events = %d, want %d", len(audit.events), wantEvents)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// GetActor describes who is making the request for the audit trail. The IP
+// is the client address as resolved by chi's RealIP middleware, and the
+// request ID the one assigned by its RequestID middleware. Permissions are
+// those loaded by JWTAuth, and the tenant the one set by JWTAuth or Tenant,
+// or the default tenant when neither ran; a request never acts as the
+// system in every tenant.
+func GetActor(r *http.Request) domain.Actor {
+	actor := domain.Actor{
+		IP:        ClientIP(r),
+		RequestID: chiMiddleware.GetReqID(r.Context()),
+		UserAgent: r.UserAgent(),
+		TenantID:  domain.DefaultTenantID,
+	}
+	if userID, ok := GetUserIDFromContext(r.Context()); ok {
+		actor.UserID = userID
+	}
+	if role, ok := GetRoleFromContext(r.Context()); ok {
+		actor.Role = role
+	}
+	if permissions, ok := GetPermissionsFromContext(r.Context()); ok {
+		actor.Permissions = permissions
+	}
+	if tenantID, ok := GetTenantIDFromContext(r.Context()); ok {
+		actor.TenantID = tenantID
+	}
+	return actor
+}
+
+// ClientIP returns the client address without its port. Behind a proxy it
+// relies on chi's RealIP middleware having rewritten RemoteAddr.
+func ClientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -5,7 +5,9 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
 	"github.com/zulfikarmuzakir/e_procurement/pkg/auth"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
 )
 
 type ContextKey string
@@ -14,7 +16,16 @@ const UserIDKey ContextKey = "user_id"
 
 const RoleKey ContextKey = "role"
 
-func JWTAuth(jwtAuth *auth.JWTAuth) func(http.Handler) http.Handler {
+const SessionIDKey ContextKey = "session_id"
+
+const PermissionsKey ContextKey = "permissions"
+
+// JWTAuth accepts requests with a valid access token whose session and
+// user still allow it, as checked by sessions on every request, and adds
+// the user's current permissions to the context. The tenant is the one of
+// the token; tokens issued before tenants existed are for the default
+// tenant.
+func JWTAuth(jwtAuth *auth.JWTAuth, sessions domain.SessionUsecase) func(http.Handler) http.Handler {
 	return func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
@@ -29,14 +40,31 @@ func JWTAuth(jwtAuth *auth.JWTAuth) func(http.Handler) http.Handler {
 				return
 			}
 
-			claims, err := jwtAuth.ValidateToken(bearerToken[1], true)
+			claims, err := jwtAuth.ValidateToken(bearerToken[1])
 			if err != nil {
 				http.Error(w, "Invalid token", http.StatusUnauthorized)
 				return
 			}
 
+			permissions, err := sessions.CheckAccess(claims.UserID, claims.SessionID, claims.TokenVersion)
+			if err != nil {
+				appErr, ok := err.(*errors.AppError)
+				if !ok {
+					appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+				}
+				http.Error(w, appErr.Message, appErr.Code)
+				return
+			}
+
 			ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
-			ctx = context.WithValue(ctx, RoleKey, claims.Role)
+			ctx = context.WithValue(ctx, RoleKey, strings.Join(claims.Roles, ","))
+			ctx = context.WithValue(ctx, SessionIDKey, claims.SessionID)
+			ctx = context.WithValue(ctx, PermissionsKey, permissions)
+			tenantID := claims.TenantID
+			if tenantID == 0 {
+				tenantID = domain.DefaultTenantID
+			}
+			ctx = context.WithValue(ctx, TenantIDKey, tenantID)
 			h.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -51,3 +79,13 @@ func GetRoleFromContext(ctx context.Context) (string, bool) {
 	role, ok := ctx.Value(RoleKey).(string)
 	return role, ok
 }
+
+func GetSessionIDFromContext(ctx context.Context) (int64, bool) {
+	sessionID, ok := ctx.Value(SessionIDKey).(int64)
+	return sessionID, ok
+}
+
+func GetPermissionsFromContext(ctx context.Context) ([]string, bool) {
+	permissions, ok := ctx.Value(PermissionsKey).([]string)
+	return permissions, ok
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+)
+
+// RequirePermission lets through users whose roles grant permission. It
+// must run after JWTAuth.
+func RequirePermission(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			permissions, ok := GetPermissionsFromContext(r.Context())
+			if !ok {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			if !slices.Contains(permissions, permission) {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
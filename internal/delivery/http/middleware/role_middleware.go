@@ -1,26 +0,0 @@
-package middleware
-
-import (
-	"net/http"
-)
-
-func RoleMiddleware(requiredRoles ...string) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			userRole, ok := GetRoleFromContext(r.Context())
-			if !ok {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
-				return
-			}
-
-			for _, role := range requiredRoles {
-				if userRole == role {
-					next.ServeHTTP(w, r)
-					return
-				}
-			}
-
-			http.Error(w, "Forbidden", http.StatusForbidden)
-		})
-	}
-}
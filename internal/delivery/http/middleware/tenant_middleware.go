@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+)
+
+const TenantIDKey ContextKey = "tenant_id"
+
+// TenantHeader names the tenant of an anonymous request by its slug.
+const TenantHeader = "X-Tenant"
+
+// Tenant puts the tenant named by the X-Tenant header in the context, or
+// the default tenant when there is no header. It is for anonymous
+// requests: JWTAuth replaces it with the tenant of the token, so a user
+// cannot reach another tenant by naming it.
+func Tenant(tenants domain.TenantUsecase) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenantID := domain.DefaultTenantID
+			if slug := r.Header.Get(TenantHeader); slug != "" {
+				tenant, err := tenants.GetBySlug(slug)
+				if err != nil {
+					appErr, ok := err.(*errors.AppError)
+					if !ok {
+						appErr = errors.NewAppError(err, "Internal server error", http.StatusInternalServerError)
+					}
+					http.Error(w, appErr.Message, appErr.Code)
+					return
+				}
+				tenantID = tenant.ID
+			}
+
+			ctx := context.WithValue(r.Context(), TenantIDKey, tenantID)
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func GetTenantIDFromContext(ctx context.Context) (int64, bool) {
+	tenantID, ok := ctx.Value(TenantIDKey).(int64)
+	return tenantID, ok
+}
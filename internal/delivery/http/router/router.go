@@ -1,8 +1,11 @@
 package router
 
 import (
+	"time"
+
 	"github.com/zulfikarmuzakir/e_procurement/internal/app"
 	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/handler"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -19,8 +22,8 @@ func SetupRouter(app *app.App) *chi.Mux {
 		AllowedOrigins: []string{"https://*", "http://*"},
 		// AllowOriginFunc:  func(r *http.Request, origin string) bool { return true },
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", customMiddleware.TenantHeader},
+		ExposedHeaders:   []string{"Link", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset"},
 		AllowCredentials: false,
 		MaxAge:           300, // Maximum value not ignored by any of major browsers
 	}))
@@ -32,36 +35,215 @@ func SetupRouter(app *app.App) *chi.Mux {
 
 	userHandler := handler.NewUserHandler(app.UserUsecase, app.Logger)
 	productHandler := handler.NewProductHandler(app.ProductUsecase, app.Logger)
+	cartHandler := handler.NewCartHandler(app.CartUsecase, app.Logger)
+	purchaseOrderHandler := handler.NewPurchaseOrderHandler(app.PurchaseOrderUsecase, app.Logger)
+	inventoryHandler := handler.NewInventoryHandler(app.InventoryUsecase, app.Logger)
+	stockAlertHandler := handler.NewStockAlertHandler(app.StockAlertUsecase, app.Logger)
+	notificationHandler := handler.NewNotificationHandler(app.NotificationUsecase, app.Logger)
+	webhookHandler := handler.NewWebhookHandler(app.WebhookUsecase, app.Logger)
+	jobHandler := handler.NewJobHandler(app.JobUsecase, app.Logger)
+	auditHandler := handler.NewAuditHandler(app.AuditUsecase, app.Logger)
+	passwordResetHandler := handler.NewPasswordResetHandler(app.PasswordResetUsecase, app.Logger)
+	emailVerificationHandler := handler.NewEmailVerificationHandler(app.EmailVerificationUsecase, app.Logger)
+	mfaHandler := handler.NewMFAHandler(app.MFAUsecase, app.Logger)
+	sessionHandler := handler.NewSessionHandler(app.SessionUsecase, app.Logger)
+	loginAttemptHandler := handler.NewLoginAttemptHandler(app.LoginAttemptUsecase, app.Logger)
+	roleHandler := handler.NewRoleHandler(app.RoleUsecase, app.Logger)
+	organizationHandler := handler.NewOrganizationHandler(app.OrganizationUsecase, app.Logger)
+	rfqHandler := handler.NewRFQHandler(app.RFQUsecase, app.Logger)
+	approvalHandler := handler.NewApprovalHandler(app.ApprovalUsecase, app.Logger)
+	vendorDocumentHandler := handler.NewVendorDocumentHandler(app.VendorDocumentUsecase, app.Logger)
+	jwksHandler := handler.NewJWKSHandler(app.JWTAuth, app.Logger)
+
+	rateLimiter := customMiddleware.NewRateLimiter(app.RateLimitStore, app.Logger)
+
+	r.Get("/.well-known/jwks.json", jwksHandler.GetJWKS)
 
 	r.Route("/api/v1", func(r chi.Router) {
-		r.Post("/login", userHandler.Login)
-		r.Post("/register-vendor", userHandler.RegisterVendor)
-		r.Get("/products", productHandler.GetAllProducts)
-		r.Get("/products/{id}", productHandler.GetProductByID)
+		r.Use(customMiddleware.Tenant(app.TenantUsecase))
+
+		// public routes
+		r.Group(func(r chi.Router) {
+			r.Use(rateLimiter.Limit("public", app.RateLimits.Public, customMiddleware.ByIP))
+
+			r.With(rateLimiter.Limit("login", app.RateLimits.Login, customMiddleware.ByIP)).Post("/login", userHandler.Login)
+			r.With(rateLimiter.Limit("login-mfa", domain.RateLimit{Requests: 20, Period: time.Minute}, customMiddleware.ByIP)).Post("/login/mfa", mfaHandler.VerifyLogin)
+			r.With(rateLimiter.Limit("login-mfa-enroll", domain.RateLimit{Requests: 10, Period: time.Minute}, customMiddleware.ByIP)).Post("/login/mfa/enroll", mfaHandler.EnrollWithChallenge)
+			r.With(rateLimiter.Limit("refresh", domain.RateLimit{Requests: 30, Period: time.Minute}, customMiddleware.ByIP)).Post("/refresh", sessionHandler.RefreshToken)
+			r.Post("/logout", sessionHandler.Logout)
+			r.With(rateLimiter.Limit("register", app.RateLimits.Register, customMiddleware.ByIP)).Post("/register-vendor", userHandler.RegisterVendor)
+			r.Post("/forgot-password", passwordResetHandler.ForgotPassword)
+			r.Post("/reset-password", passwordResetHandler.ResetPassword)
+			r.With(rateLimiter.Limit("verify-email", domain.RateLimit{Requests: 10, Period: time.Minute}, customMiddleware.ByIP)).Get("/verify-email", emailVerificationHandler.VerifyEmail)
+			r.With(rateLimiter.Limit("resend-verification", domain.RateLimit{Requests: 5, Period: time.Hour}, customMiddleware.ByIP)).Post("/resend-verification", emailVerificationHandler.ResendVerification)
+			r.Get("/products", productHandler.GetAllProducts)
+			r.Get("/products/{id}", productHandler.GetProductByID)
+		})
 
 		//protected routes
 		r.Group(func(r chi.Router) {
-			r.Use(customMiddleware.JWTAuth(app.JWTAuth))
+			r.Use(customMiddleware.JWTAuth(app.JWTAuth, app.SessionUsecase))
+			r.Use(rateLimiter.Limit("user", app.RateLimits.User, customMiddleware.ByUser))
 
 			r.Get("/users/{id}", userHandler.GetUser)
 			r.Put("/users/{id}", userHandler.UpdateUser)
 			r.Get("/users/me", userHandler.GetUserMe)
+			r.Post("/logout-all", sessionHandler.LogoutAll)
+			r.Get("/users/me/sessions", sessionHandler.GetMySessions)
+			r.Delete("/users/me/sessions/{sessionID}", sessionHandler.RevokeMySession)
+			r.Get("/users/me/mfa", mfaHandler.GetStatus)
+			r.Delete("/users/me/mfa", mfaHandler.Disable)
+			r.Post("/users/me/mfa/enroll", mfaHandler.Enroll)
+			r.Post("/users/me/mfa/confirm", mfaHandler.Confirm)
+			r.Post("/users/me/mfa/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
+			r.Get("/purchase-orders/{id}", purchaseOrderHandler.GetPurchaseOrder)
+			// Owners and holders of product:manage, as checked by the usecase.
+			r.Put("/products/{id}", productHandler.UpdateProduct)
+			r.Delete("/products/{id}", productHandler.DeleteProduct)
+			// Organization members and admins, as checked by the usecase.
+			r.Get("/users/me/organization", organizationHandler.GetMyOrganization)
+			r.Get("/organizations/{id}", organizationHandler.GetOrganization)
+			r.Put("/organizations/{id}", organizationHandler.UpdateOrganization)
+			r.Get("/organizations/{id}/departments", organizationHandler.GetDepartments)
+			r.Post("/organizations/{id}/departments", organizationHandler.CreateDepartment)
+			r.Put("/organizations/{id}/departments/{departmentID}", organizationHandler.UpdateDepartment)
+			r.Delete("/organizations/{id}/departments/{departmentID}", organizationHandler.DeleteDepartment)
+			r.Get("/organizations/{id}/members", organizationHandler.GetMembers)
+			r.Put("/organizations/{id}/members/{userID}", organizationHandler.SetMember)
+			r.Delete("/organizations/{id}/members/{userID}", organizationHandler.RemoveMember)
+			r.Get("/organizations/{id}/purchase-orders", purchaseOrderHandler.GetOrganizationPurchaseOrders)
+			r.Get("/stock-alerts", stockAlertHandler.GetStockAlerts)
+			r.Get("/notifications", notificationHandler.GetNotifications)
+			r.Put("/notifications/read-all", notificationHandler.MarkAllRead)
+			r.Put("/notifications/{id}/read", notificationHandler.MarkRead)
+			r.Put("/notifications/{id}/unread", notificationHandler.MarkUnread)
+			r.Get("/notification-preferences", notificationHandler.GetPreferences)
+			r.Put("/notification-preferences", notificationHandler.UpdatePreferences)
 
 			r.Group(func(r chi.Router) {
-				r.Use(customMiddleware.RoleMiddleware("admin"))
+				r.Use(customMiddleware.RequirePermission(domain.PermissionVendorApprove))
 				r.Put("/users/{id}/approve", userHandler.ApproveVendor)
 				r.Put("/users/{id}/reject", userHandler.RejectVendor)
-				r.Delete("/users/{id}", userHandler.DeleteUser)
 				r.Get("/vendors", userHandler.GetAllVendor)
 			})
 
 			r.Group(func(r chi.Router) {
-				r.Use(customMiddleware.RoleMiddleware("vendor"))
+				r.Use(customMiddleware.RequirePermission(domain.PermissionUserWrite))
+				r.Post("/buyers", userHandler.CreateBuyer)
+				r.Post("/users/{id}/unlock", userHandler.UnlockUser)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequirePermission(domain.PermissionUserDelete))
+				r.Delete("/users/{id}", userHandler.DeleteUser)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequirePermission(domain.PermissionSessionManage))
+				r.Get("/users/{id}/sessions", sessionHandler.GetUserSessions)
+				r.Delete("/users/{id}/sessions", sessionHandler.RevokeUserSessions)
+				r.Delete("/users/{id}/sessions/{sessionID}", sessionHandler.RevokeUserSession)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequirePermission(domain.PermissionLoginAttemptRead))
+				r.Get("/login-attempts", loginAttemptHandler.GetLoginAttempts)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequirePermission(domain.PermissionRoleManage))
+				r.Get("/permissions", roleHandler.GetPermissions)
+				r.Get("/roles", roleHandler.GetRoles)
+				r.Post("/roles", roleHandler.CreateRole)
+				r.Get("/roles/{id}", roleHandler.GetRole)
+				r.Put("/roles/{id}", roleHandler.UpdateRole)
+				r.Delete("/roles/{id}", roleHandler.DeleteRole)
+				r.Get("/users/{id}/roles", roleHandler.GetUserRoles)
+				r.Put("/users/{id}/roles", roleHandler.SetUserRoles)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequirePermission(domain.PermissionOrganizationManage))
+				r.Get("/organizations", organizationHandler.GetOrganizations)
+				r.Post("/organizations", organizationHandler.CreateOrganization)
+				r.Delete("/organizations/{id}", organizationHandler.DeleteOrganization)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequirePermission(domain.PermissionWebhookManage))
+				r.Post("/webhooks", webhookHandler.CreateWebhook)
+				r.Get("/webhooks", webhookHandler.GetWebhooks)
+				r.Get("/webhooks/{id}", webhookHandler.GetWebhook)
+				r.Put("/webhooks/{id}", webhookHandler.UpdateWebhook)
+				r.Delete("/webhooks/{id}", webhookHandler.DeleteWebhook)
+				r.Get("/webhooks/{id}/deliveries", webhookHandler.GetDeliveries)
+				r.Post("/webhooks/deliveries/{id}/redeliver", webhookHandler.Redeliver)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequirePermission(domain.PermissionJobManage))
+				r.Get("/jobs", jobHandler.GetJobs)
+				r.Post("/jobs/{id}/retry", jobHandler.RetryJob)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequirePermission(domain.PermissionAuditRead))
+				r.Get("/audit-events", auditHandler.GetAuditEvents)
+				r.Get("/audit-events/verify", auditHandler.VerifyAuditLog)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequirePermission(domain.PermissionAuditSign))
+				r.Post("/audit-events/checkpoints", auditHandler.CreateCheckpoint)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequirePermission(domain.PermissionProductWrite))
 				r.Post("/products", productHandler.CreateProduct)
-				r.Put("/products/{id}", productHandler.UpdateProduct)
-				r.Delete("/products/{id}", productHandler.DeleteProduct)
 				r.Get("/my-products", productHandler.GetMyProducts)
 			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequirePermission(domain.PermissionInventoryManage))
+				r.Get("/products/{id}/inventory", inventoryHandler.GetBalance)
+				r.Get("/products/{id}/inventory/movements", inventoryHandler.GetMovements)
+				r.Post("/products/{id}/inventory/receipts", inventoryHandler.Receive)
+				r.Post("/products/{id}/inventory/adjustments", inventoryHandler.Adjust)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequirePermission(domain.PermissionPOFulfill))
+				r.Get("/vendor/purchase-orders", purchaseOrderHandler.GetVendorPurchaseOrders)
+				r.Post("/vendor/purchase-orders/{id}/ship", purchaseOrderHandler.ShipPurchaseOrder)
+				r.Post("/vendor/documents", vendorDocumentHandler.CreateVendorDocument)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequirePermission(domain.PermissionCartManage))
+				r.Get("/cart", cartHandler.GetCart)
+				r.Delete("/cart", cartHandler.ClearCart)
+				r.Post("/cart/items", cartHandler.AddItem)
+				r.Put("/cart/items/{productID}", cartHandler.UpdateItem)
+				r.Delete("/cart/items/{productID}", cartHandler.RemoveItem)
+				r.Post("/cart/checkout", cartHandler.Checkout)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequirePermission(domain.PermissionPOApprove))
+				r.Get("/purchase-orders", purchaseOrderHandler.GetMyPurchaseOrders)
+				r.Post("/purchase-orders/{id}/confirm", purchaseOrderHandler.ConfirmPurchaseOrder)
+				r.Post("/purchase-orders/{id}/cancel", purchaseOrderHandler.CancelPurchaseOrder)
+				r.Post("/purchase-orders/{id}/approvals", approvalHandler.RequestApproval)
+				r.Post("/approvals/{id}/approve", approvalHandler.ApproveApproval)
+				r.Post("/approvals/{id}/reject", approvalHandler.RejectApproval)
+				r.Post("/rfqs", rfqHandler.CreateRFQ)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.RequirePermission(domain.PermissionProductSubscribe))
+				r.Post("/products/{id}/subscription", stockAlertHandler.Subscribe)
+				r.Delete("/products/{id}/subscription", stockAlertHandler.Unsubscribe)
+			})
 		})
 
 	})
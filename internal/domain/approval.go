@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+const (
+	ApprovalStatusPending  = "pending"
+	ApprovalStatusApproved = "approved"
+	ApprovalStatusRejected = "rejected"
+)
+
+// Approval asks one approver to sign off a purchase order. IdleSince is
+// when it last moved; a pending approval idle for too long is escalated
+// once.
+type Approval struct {
+	ID              int64     `json:"id"`
+	PurchaseOrderID int64     `json:"purchase_order_id"`
+	ApproverID      int64     `json:"approver_id"`
+	Status          string    `json:"status"`
+	IdleSince       time.Time `json:"idle_since"`
+	// TenantID is the tenant of the approver.
+	TenantID    int64      `json:"-"`
+	EscalatedAt *time.Time `json:"escalated_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ApprovalRequest asks a user of the buyer's tenant who holds po:approve to
+// sign off a purchase order.
+type ApprovalRequest struct {
+	ApproverID int64 `json:"approver_id" validate:"required"`
+}
+
+type ApprovalRepository interface {
+	// ForTenant returns the repository scoped to a tenant's approvals, and
+	// SystemScope the one spanning every tenant. A repository that is
+	// neither matches nothing.
+	ForTenant(tenantID int64) ApprovalRepository
+	SystemScope() ApprovalRepository
+	Create(approval *Approval) error
+	// GetByID returns nil when the approval does not exist.
+	GetByID(id int64) (*Approval, error)
+	// Decide moves a pending approval to status and restarts its idle
+	// time. It returns nil when the approval is no longer pending.
+	Decide(id int64, status string) (*Approval, error)
+	// EscalateIdle marks up to limit pending approvals idle since before
+	// idleBefore escalated and returns them. Approvals already escalated
+	// or locked by another transaction are skipped.
+	EscalateIdle(idleBefore time.Time, limit int) ([]Approval, error)
+}
+
+type ApprovalUsecase interface {
+	// Request asks an approver to sign off a purchase order. Only the
+	// order's buyer may ask, and not themselves.
+	Request(purchaseOrderID int64, request ApprovalRequest, actor Actor) (*Approval, error)
+	// Approve and Reject decide a pending approval. Only its approver may
+	// decide it.
+	Approve(id int64, actor Actor) (*Approval, error)
+	Reject(id int64, actor Actor) (*Approval, error)
+	// EscalateIdle escalates the approvals left pending for longer than
+	// idleFor, in every tenant, to the holders of organization:manage.
+	EscalateIdle(idleFor time.Duration) (int, error)
+}
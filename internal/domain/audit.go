@@ -0,0 +1,259 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+)
+
+// Audit actions.
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// Audited entity types.
+const (
+	AuditEntityUser           = "user"
+	AuditEntityProduct        = "product"
+	AuditEntityPurchaseOrder  = "purchase_order"
+	AuditEntityRole           = "role"
+	AuditEntityOrganization   = "organization"
+	AuditEntityDepartment     = "department"
+	AuditEntityRFQ            = "rfq"
+	AuditEntityApproval       = "approval"
+	AuditEntityVendorDocument = "vendor_document"
+)
+
+// Actor is who made a change and from where. UserID is zero for anonymous
+// requests, such as vendor registration, and for the system.
+type Actor struct {
+	UserID int64
+	// Role is the actor's role names, comma separated, or "system".
+	Role      string
+	IP        string
+	RequestID string
+	// UserAgent is kept for sessions; the audit trail does not record it.
+	UserAgent string
+	// Permissions are what the actor's roles grant, for authorization. The
+	// audit trail does not record them.
+	Permissions []string
+	// TenantID is the tenant the actor acts in, and the repositories it
+	// uses are scoped to. It is zero for the system, which sees every
+	// tenant.
+	TenantID int64
+}
+
+// Can reports whether the actor's roles grant permission.
+func (a Actor) Can(permission string) bool {
+	return slices.Contains(a.Permissions, permission)
+}
+
+// SystemActor makes the changes of background and scheduled jobs.
+var SystemActor = Actor{Role: "system"}
+
+// AuditEvent records one change to one entity. Before is null for creates
+// and After is null for deletes. Diff maps every changed field to its old
+// and new value.
+//
+// Events form a hash chain: Seq is the event's position, PrevHash the Hash
+// of the event before it, and Hash covers the event's contents and
+// PrevHash. All three are assigned by the database when the event is
+// recorded.
+type AuditEvent struct {
+	ID         int64           `json:"id"`
+	ActorID    *int64          `json:"actor_id"`
+	ActorRole  string          `json:"actor_role"`
+	IP         string          `json:"ip"`
+	RequestID  string          `json:"request_id"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   int64           `json:"entity_id"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	Diff       json.RawMessage `json:"diff"`
+	CreatedAt  time.Time       `json:"created_at"`
+	Seq        int64           `json:"seq"`
+	PrevHash   string          `json:"prev_hash"`
+	Hash       string          `json:"hash"`
+	// TenantID is the tenant of the actor. It is not part of Hash.
+	TenantID *int64 `json:"tenant_id"`
+}
+
+// AuditChange is the old and new value of one field.
+type AuditChange struct {
+	From json.RawMessage `json:"from"`
+	To   json.RawMessage `json:"to"`
+}
+
+// NewAuditEvent snapshots before and after as JSON and diffs them. Pass nil
+// for the missing side of a create or delete. Snapshots are taken through
+// the values' JSON encoding, so secrets must not be part of it.
+func NewAuditEvent(actor Actor, action, entityType string, entityID int64, before, after interface{}) (*AuditEvent, error) {
+	event := &AuditEvent{
+		ActorRole:  actor.Role,
+		IP:         actor.IP,
+		RequestID:  actor.RequestID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+	if actor.UserID != 0 {
+		actorID := actor.UserID
+		event.ActorID = &actorID
+	}
+	if actor.TenantID != 0 {
+		tenantID := actor.TenantID
+		event.TenantID = &tenantID
+	}
+
+	var err error
+	if event.Before, err = auditSnapshot(before); err != nil {
+		return nil, err
+	}
+	if event.After, err = auditSnapshot(after); err != nil {
+		return nil, err
+	}
+	if event.Diff, err = auditDiff(event.Before, event.After); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func auditSnapshot(value interface{}) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
+
+// auditDiff compares the top-level fields of two JSON objects.
+func auditDiff(before, after json.RawMessage) (json.RawMessage, error) {
+	var beforeFields, afterFields map[string]json.RawMessage
+	if before != nil {
+		if err := json.Unmarshal(before, &beforeFields); err != nil {
+			return nil, err
+		}
+	}
+	if after != nil {
+		if err := json.Unmarshal(after, &afterFields); err != nil {
+			return nil, err
+		}
+	}
+
+	null := json.RawMessage("null")
+	diff := make(map[string]AuditChange)
+	for field, from := range beforeFields {
+		to, ok := afterFields[field]
+		if !ok {
+			to = null
+		}
+		if !bytes.Equal(from, to) {
+			diff[field] = AuditChange{From: from, To: to}
+		}
+	}
+	for field, to := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			diff[field] = AuditChange{From: null, To: to}
+		}
+	}
+
+	return json.Marshal(diff)
+}
+
+// AuditFilter narrows the audit log. Zero fields match everything; the date
+// range includes From and excludes To.
+type AuditFilter struct {
+	TenantID   *int64
+	ActorID    *int64
+	EntityType string
+	EntityID   *int64
+	From       *time.Time
+	To         *time.Time
+}
+
+// AuditChainLink is an event's place in the hash chain. ComputedHash is the
+// hash of the event as it is stored now; it differs from Hash when the
+// event was edited after it was recorded.
+type AuditChainLink struct {
+	ID           int64
+	Seq          int64
+	PrevHash     string
+	Hash         string
+	ComputedHash string
+}
+
+// AuditCheckpoint is a signed statement that the event at Seq had Hash at
+// CreatedAt. Checkpoints are also exported outside the database, so a
+// chain rewritten with valid hashes still contradicts them.
+type AuditCheckpoint struct {
+	ID        int64     `json:"id"`
+	Seq       int64     `json:"seq"`
+	EventID   int64     `json:"event_id"`
+	Hash      string    `json:"hash"`
+	KeyID     string    `json:"key_id"`
+	Signature string    `json:"signature"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SignedPayload returns the bytes the checkpoint signature covers.
+func (c *AuditCheckpoint) SignedPayload() []byte {
+	return []byte(fmt.Sprintf("audit-checkpoint:%d:%d:%s:%s", c.Seq, c.EventID, c.Hash, c.CreatedAt.UTC().Format(time.RFC3339Nano)))
+}
+
+// AuditChainBreak is the first place where the audit chain does not hold.
+type AuditChainBreak struct {
+	Seq     int64  `json:"seq"`
+	EventID int64  `json:"event_id,omitempty"`
+	Reason  string `json:"reason"`
+}
+
+// AuditVerification is the result of walking the audit chain.
+// UnverifiedCheckpoints counts checkpoints signed with a key other than the
+// current one, whose signatures could not be checked.
+type AuditVerification struct {
+	Valid                 bool             `json:"valid"`
+	Events                int64            `json:"events"`
+	HeadSeq               int64            `json:"head_seq"`
+	HeadEventID           int64            `json:"head_event_id"`
+	HeadHash              string           `json:"head_hash"`
+	Checkpoints           int              `json:"checkpoints"`
+	UnverifiedCheckpoints int              `json:"unverified_checkpoints"`
+	BrokenAt              *AuditChainBreak `json:"broken_at,omitempty"`
+}
+
+type AuditRepository interface {
+	// ForTenant returns the repository listing only the events of a
+	// tenant, and SystemScope the one listing every event. A repository
+	// that is neither lists none. The chain and checkpoints span all
+	// tenants either way.
+	ForTenant(tenantID int64) AuditRepository
+	SystemScope() AuditRepository
+	Record(event *AuditEvent) error
+	GetEvents(filter AuditFilter, afterID *int64, limit int) ([]AuditEvent, error)
+	CountEvents(filter AuditFilter) (int64, error)
+	// GetChain returns up to limit links after afterSeq, in chain order.
+	GetChain(afterSeq int64, limit int) ([]AuditChainLink, error)
+	CreateCheckpoint(checkpoint *AuditCheckpoint) error
+	// GetCheckpoints returns every checkpoint, oldest first.
+	GetCheckpoints() ([]AuditCheckpoint, error)
+}
+
+// AuditCheckpointExporter keeps a copy of each checkpoint outside the
+// database.
+type AuditCheckpointExporter interface {
+	Export(checkpoint AuditCheckpoint) error
+}
+
+type AuditUsecase interface {
+	GetEvents(filter AuditFilter, page pagination.Params, actor Actor) (*pagination.Page[AuditEvent], error)
+	// Verify walks the whole chain and checks it against the checkpoints.
+	Verify() (*AuditVerification, error)
+	// CreateCheckpoint signs and exports the current chain head.
+	CreateCheckpoint() (*AuditCheckpoint, error)
+}
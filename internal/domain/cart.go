@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CartItem is a product in a buyer's cart, joined with the current product
+// and vendor data.
+type CartItem struct {
+	ID               int64     `json:"id"`
+	ProductID        int64     `json:"product_id"`
+	ProductName      string    `json:"product_name"`
+	VendorID         int64     `json:"vendor_id"`
+	VendorName       string    `json:"vendor_name"`
+	UnitPrice        int32     `json:"unit_price"`
+	Quantity         int       `json:"quantity"`
+	Subtotal         int64     `json:"subtotal"`
+	AvailableStock   int       `json:"available_stock"`
+	MinOrderQuantity int       `json:"min_order_quantity"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// VendorCart groups the cart items sold by one vendor. Each vendor cart
+// becomes one purchase order on checkout.
+type VendorCart struct {
+	VendorID   int64      `json:"vendor_id"`
+	VendorName string     `json:"vendor_name"`
+	Items      []CartItem `json:"items"`
+	Subtotal   int64      `json:"subtotal"`
+}
+
+type Cart struct {
+	Vendors   []VendorCart `json:"vendors"`
+	ItemCount int          `json:"item_count"`
+	Total     int64        `json:"total"`
+}
+
+type CartItemRequest struct {
+	ProductID int64 `json:"product_id" validate:"required"`
+	Quantity  int   `json:"quantity" validate:"required,gt=0"`
+}
+
+// CheckoutIssue explains why a cart item cannot be ordered.
+type CheckoutIssue struct {
+	ProductID        int64  `json:"product_id"`
+	ProductName      string `json:"product_name"`
+	Reason           string `json:"reason"`
+	Requested        int    `json:"requested"`
+	Available        int    `json:"available"`
+	MinOrderQuantity int    `json:"min_order_quantity"`
+}
+
+// CheckoutError is returned when stock or MOQ validation fails for one or
+// more cart items. Nothing is ordered in that case.
+type CheckoutError struct {
+	Issues []CheckoutIssue
+}
+
+func (e *CheckoutError) Error() string {
+	reasons := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		reasons[i] = fmt.Sprintf("product %d: %s", issue.ProductID, issue.Reason)
+	}
+	return "checkout rejected: " + strings.Join(reasons, "; ")
+}
+
+// CheckoutPlanner validates the locked cart items and turns them into the
+// purchase orders to create.
+type CheckoutPlanner func(items []CartItem) ([]PurchaseOrder, error)
+
+type CartRepository interface {
+	GetItems(userID int64) ([]CartItem, error)
+	AddItem(userID, productID int64, quantity int) error
+	UpdateItemQuantity(userID, productID int64, quantity int) (bool, error)
+	RemoveItem(userID, productID int64) (bool, error)
+	Clear(userID int64) error
+	// Checkout locks the cart items and their products, asks plan for the
+	// purchase orders to create, then stores them, reserves the stock and
+	// empties the cart in a single transaction.
+	Checkout(userID int64, plan CheckoutPlanner) ([]PurchaseOrder, error)
+}
+
+type CartUsecase interface {
+	GetCart(userID int64) (*Cart, error)
+	AddItem(userID int64, request CartItemRequest, actor Actor) (*Cart, error)
+	UpdateItem(userID int64, request CartItemRequest) (*Cart, error)
+	RemoveItem(userID, productID int64) (*Cart, error)
+	Clear(userID int64) error
+	Checkout(userID int64, actor Actor) ([]PurchaseOrder, error)
+}
@@ -4,13 +4,15 @@ import "github.com/zulfikarmuzakir/e_procurement/internal/domain"
 
 func UserToUserResponse(user *domain.User) *domain.UserResponse {
 	return &domain.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Username:  user.Username,
-		Email:     user.Email,
-		Role:      user.Role,
-		Status:    user.Status,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:              user.ID,
+		Name:            user.Name,
+		Username:        user.Username,
+		Email:           user.Email,
+		Roles:           user.Roles,
+		Status:          user.Status,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		LockedUntil:     user.LockedUntil,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
 	}
 }
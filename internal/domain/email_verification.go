@@ -0,0 +1,26 @@
+package domain
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// VerificationEmailJob sends a fresh verification link to a user. The link
+// is signed when the job runs, so it always carries the current email.
+type VerificationEmailJob struct {
+	UserID int64 `json:"user_id"`
+}
+
+func NewVerificationEmailJob(userID int64) (*Job, error) {
+	return NewJob(JobEmailVerification, VerificationEmailJob{UserID: userID})
+}
+
+type EmailVerificationUsecase interface {
+	// SendVerificationEmail runs a VerificationEmailJob.
+	SendVerificationEmail(job VerificationEmailJob) error
+	// Verify marks the email in a signed verification link verified.
+	Verify(token string, actor Actor) error
+	// Resend queues another verification email. It reports success whether
+	// or not email belongs to an unverified user, so callers cannot probe
+	// for accounts.
+	Resend(email string) error
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+)
+
+// Inventory movement types. Every change to a product's stock is recorded as
+// one movement in the ledger.
+const (
+	MovementReceipt     = "receipt"
+	MovementReservation = "reservation"
+	MovementRelease     = "release"
+	MovementAdjustment  = "adjustment"
+	MovementShipment    = "shipment"
+)
+
+// Stock reservation statuses.
+const (
+	ReservationActive    = "active"
+	ReservationReleased  = "released"
+	ReservationFulfilled = "fulfilled"
+)
+
+// InventoryMovement is one ledger entry. OnHandDelta and ReservedDelta are
+// the signed changes it applies to the product's on-hand and reserved
+// quantities.
+type InventoryMovement struct {
+	ID            int64     `json:"id"`
+	ProductID     int64     `json:"product_id"`
+	MovementType  string    `json:"movement_type"`
+	Quantity      int       `json:"quantity"`
+	OnHandDelta   int       `json:"on_hand_delta"`
+	ReservedDelta int       `json:"reserved_delta"`
+	ReservationID *int64    `json:"reservation_id,omitempty"`
+	Note          string    `json:"note"`
+	CreatedBy     *int64    `json:"created_by,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// InventoryBalance is derived from the sum of a product's movements.
+// Available is what buyers can still order.
+type InventoryBalance struct {
+	ProductID int64 `json:"product_id"`
+	OnHand    int   `json:"on_hand"`
+	Reserved  int   `json:"reserved"`
+	Available int   `json:"available"`
+}
+
+type InventoryReceiptRequest struct {
+	Quantity int    `json:"quantity" validate:"required,gt=0"`
+	Note     string `json:"note"`
+}
+
+// InventoryAdjustmentRequest corrects the on-hand quantity, e.g. after a
+// stock count. Quantity is signed.
+type InventoryAdjustmentRequest struct {
+	Quantity int    `json:"quantity" validate:"required"`
+	Note     string `json:"note" validate:"required"`
+}
+
+type InventoryRepository interface {
+	// ForTenant returns the repository scoped to the stock of a tenant's
+	// products. A repository that is not scoped matches nothing.
+	ForTenant(tenantID int64) InventoryRepository
+	GetBalance(productID int64) (*InventoryBalance, error)
+	GetMovements(productID int64, afterID *int64, limit int) ([]InventoryMovement, error)
+	CountMovements(productID int64) (int64, error)
+	// Record appends movement to the ledger and updates the product's stock
+	// in one transaction. It fails with errors.ErrInsufficientStock when the
+	// on-hand quantity would drop below zero or below the reserved quantity.
+	Record(movement *InventoryMovement) error
+}
+
+// InventoryUsecase manages the stock of the actor's own products.
+type InventoryUsecase interface {
+	GetBalance(productID int64, actor Actor) (*InventoryBalance, error)
+	GetMovements(productID int64, page pagination.Params, actor Actor) (*pagination.Page[InventoryMovement], error)
+	Receive(productID int64, request InventoryReceiptRequest, actor Actor) (*InventoryMovement, error)
+	Adjust(productID int64, request InventoryAdjustmentRequest, actor Actor) (*InventoryMovement, error)
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+)
+
+// Why a login attempt failed. Successful attempts have an empty reason, or
+// LoginMFARequired when a second factor is still needed.
+const (
+	LoginFailedUnknownEmail = "unknown_email"
+	LoginFailedPassword     = "invalid_password"
+	LoginFailedInactive     = "inactive"
+	LoginFailedLocked       = "locked"
+	LoginFailedIPBlocked    = "ip_blocked"
+	LoginMFARequired        = "mfa_required"
+)
+
+// Brute-force protection for password logins. After LoginThrottleFailures
+// failed attempts in a row an account must wait before trying again, twice
+// as long after each further failure; from LoginMaxFailures on it is locked
+// for LoginLockoutDuration, doubling up to LoginMaxLockoutDuration. An IP
+// address with LoginIPMaxFailures failures within LoginIPWindow is blocked
+// until the oldest of them leaves the window.
+const (
+	LoginThrottleFailures   = 3
+	LoginThrottleDelay      = 2 * time.Second
+	LoginMaxFailures        = 5
+	LoginLockoutDuration    = 15 * time.Minute
+	LoginMaxLockoutDuration = 24 * time.Hour
+	LoginIPMaxFailures      = 20
+	LoginIPWindow           = 15 * time.Minute
+)
+
+// LoginBackoff returns how long an account must wait after failures failed
+// logins in a row, and whether that amounts to a lockout.
+func LoginBackoff(failures int) (wait time.Duration, locked bool) {
+	switch {
+	case failures < LoginThrottleFailures:
+		return 0, false
+	case failures < LoginMaxFailures:
+		return LoginThrottleDelay << (failures - LoginThrottleFailures), false
+	}
+	wait = LoginLockoutDuration
+	for i := LoginMaxFailures; i < failures && wait < LoginMaxLockoutDuration; i++ {
+		wait *= 2
+	}
+	return min(wait, LoginMaxLockoutDuration), true
+}
+
+// LoginAttempt is one password login attempt. UserID is nil when the email
+// is unknown.
+type LoginAttempt struct {
+	ID        int64     `json:"id"`
+	UserID    *int64    `json:"user_id"`
+	Email     string    `json:"email"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	Success   bool      `json:"success"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LoginAttemptFilter narrows the login attempts. Zero fields match
+// everything; the date range includes From and excludes To.
+type LoginAttemptFilter struct {
+	UserID  *int64
+	Email   string
+	IP      string
+	Success *bool
+	From    *time.Time
+	To      *time.Time
+}
+
+// LoginThrottledError is returned while an account or IP address may not
+// try to log in.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("login throttled for %s", e.RetryAfter)
+}
+
+type LoginAttemptRepository interface {
+	Record(attempt *LoginAttempt) error
+	// CountRecentFailuresByIP counts the failed attempts from ip since
+	// since, and returns when the oldest of them was made.
+	CountRecentFailuresByIP(ip string, since time.Time) (count int64, oldest time.Time, err error)
+	GetAll(filter LoginAttemptFilter, afterID *int64, limit int) ([]LoginAttempt, error)
+	Count(filter LoginAttemptFilter) (int64, error)
+	DeleteBefore(before time.Time) (int64, error)
+}
+
+type LoginAttemptUsecase interface {
+	GetAttempts(filter LoginAttemptFilter, page pagination.Params) (*pagination.Page[LoginAttempt], error)
+	// Prune deletes attempts older than retention.
+	Prune(retention time.Duration) (int64, error)
+}
@@ -0,0 +1,105 @@
+package domain
+
+import "time"
+
+// MFAChallengeTTL is how long a login challenge can be answered.
+const MFAChallengeTTL = 5 * time.Minute
+
+// MFAChallengeMaxAttempts is how many codes can be tried per challenge.
+const MFAChallengeMaxAttempts = 5
+
+// MFA is a user's TOTP enrollment. Secret is stored encrypted. The
+// enrollment only counts once ConfirmedAt is set. LastStep is the time
+// step of the last accepted code.
+type MFA struct {
+	UserID      int64
+	Secret      string
+	ConfirmedAt *time.Time
+	LastStep    int64
+}
+
+// MFAChallenge is issued by a password login that still needs a second
+// factor.
+type MFAChallenge struct {
+	ID        int64
+	UserID    int64
+	Attempts  int
+	ExpiresAt time.Time
+}
+
+// MFAEnrollment is what an authenticator app needs: the base32 secret for
+// manual entry and the otpauth:// URI to show as a QR code.
+type MFAEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type MFAStatus struct {
+	Enabled           bool  `json:"enabled"`
+	Required          bool  `json:"required"`
+	RecoveryCodesLeft int64 `json:"recovery_codes_left"`
+	PendingEnrollment bool  `json:"pending_enrollment"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type MFAChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+}
+
+type MFAVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	// Code is a TOTP code or a recovery code.
+	Code string `json:"code" validate:"required"`
+}
+
+type MFARepository interface {
+	// Get returns the user's enrollment, or nil if there is none.
+	Get(userID int64) (*MFA, error)
+	// SavePending stores a new unconfirmed enrollment, replacing an
+	// unconfirmed one. ok is false when a confirmed enrollment exists.
+	SavePending(userID int64, secret string) (ok bool, err error)
+	Confirm(userID int64) error
+	// UseStep records step as the last accepted one. ok is false when it is
+	// not after the previous one, i.e. the code is being replayed.
+	UseStep(userID int64, step int64) (ok bool, err error)
+	// Delete removes the enrollment and the recovery codes.
+	Delete(userID int64) error
+	// ReplaceRecoveryCodes swaps the user's recovery codes for codeHashes.
+	ReplaceRecoveryCodes(userID int64, codeHashes []string) error
+	// UseRecoveryCode marks an unused code used; ok is false if there is
+	// none with codeHash.
+	UseRecoveryCode(userID int64, codeHash string) (ok bool, err error)
+	CountRecoveryCodes(userID int64) (int64, error)
+	CreateChallenge(userID int64, tokenHash string, expiresAt time.Time) error
+	// GetChallenge returns a challenge that can still be answered, or nil.
+	GetChallenge(tokenHash string) (*MFAChallenge, error)
+	// CountChallengeAttempt is GetChallenge that also uses up an attempt.
+	CountChallengeAttempt(tokenHash string) (*MFAChallenge, error)
+	// UseChallenge marks the challenge answered; ok is false if it already
+	// was.
+	UseChallenge(id int64) (ok bool, err error)
+	DeleteExpiredChallenges(before time.Time) (int64, error)
+}
+
+type MFAUsecase interface {
+	GetStatus(userID int64) (*MFAStatus, error)
+	// Enroll starts enrollment, replacing an unconfirmed one.
+	Enroll(userID int64) (*MFAEnrollment, error)
+	// Confirm completes enrollment with a first code and returns the
+	// recovery codes, which are not shown again.
+	Confirm(userID int64, code string, actor Actor) ([]string, error)
+	// Disable removes MFA after checking a current code. Admins cannot
+	// disable it.
+	Disable(userID int64, code string, actor Actor) error
+	RegenerateRecoveryCodes(userID int64, code string) ([]string, error)
+	// EnrollWithChallenge starts enrollment for a user whose login requires
+	// MFA they have not set up yet.
+	EnrollWithChallenge(challengeToken string) (*MFAEnrollment, error)
+	// VerifyLogin answers a login challenge and returns the tokens.
+	VerifyLogin(challengeToken, code string, actor Actor) (*LoginResult, error)
+	// Prune deletes expired challenges.
+	Prune() (int64, error)
+}
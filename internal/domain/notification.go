@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+)
+
+// Notification events. Each event has a subject and body template.
+const (
+	NotificationVendorPendingApproval  = "vendor_pending_approval"
+	NotificationVendorApproved         = "vendor_approved"
+	NotificationVendorRejected         = "vendor_rejected"
+	NotificationPurchaseOrderIssued    = "purchase_order_issued"
+	NotificationPurchaseOrderCancelled = "purchase_order_cancelled"
+	NotificationPurchaseOrderShipped   = "purchase_order_shipped"
+	NotificationPasswordChanged        = "password_changed"
+	NotificationAccountLocked          = "account_locked"
+	NotificationRFQClosed              = "rfq_closed"
+	NotificationApprovalRequested      = "approval_requested"
+	NotificationApprovalEscalated      = "approval_escalated"
+	NotificationVendorDocumentExpiring = "vendor_document_expiring"
+	NotificationLowStock               = "low_stock"
+	NotificationOutOfStock             = "out_of_stock"
+	NotificationBackInStock            = "back_in_stock"
+)
+
+type Notification struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	EventType string     `json:"event_type"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NotificationPreferences are the channels a user receives notifications
+// on. Both channels are enabled by default.
+type NotificationPreferences struct {
+	Email bool `json:"email"`
+	InApp bool `json:"in_app"`
+}
+
+// NotificationInbox is a page of the in-app inbox with the unread count.
+type NotificationInbox struct {
+	pagination.Page[Notification]
+	Unread int64 `json:"unread"`
+}
+
+type NotificationRepository interface {
+	Create(notification *Notification) error
+	GetByUserID(userID int64, unreadOnly bool, afterID *int64, limit int) ([]Notification, error)
+	CountByUserID(userID int64, unreadOnly bool) (int64, error)
+	// SetRead marks a notification read or unread. It reports false when the
+	// notification does not belong to the user.
+	SetRead(id, userID int64, read bool) (bool, error)
+	MarkAllRead(userID int64) (int64, error)
+	GetPreferences(userID int64) (*NotificationPreferences, error)
+	UpdatePreferences(userID int64, preferences NotificationPreferences) error
+}
+
+type NotificationUsecase interface {
+	// Deliver handles JobNotificationDeliver jobs.
+	Deliver(job NotificationJob) error
+	// SendEmail handles JobEmailSend jobs.
+	SendEmail(job EmailJob) error
+	GetInbox(userID int64, unreadOnly bool, page pagination.Params) (*NotificationInbox, error)
+	SetRead(id, userID int64, read bool) error
+	MarkAllRead(userID int64) (int64, error)
+	GetPreferences(userID int64) (*NotificationPreferences, error)
+	UpdatePreferences(userID int64, preferences NotificationPreferences) error
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+)
+
+// Roles within an organization. Organization admins manage its departments
+// and members; they need not be system admins.
+const (
+	OrganizationRoleAdmin  = "admin"
+	OrganizationRoleMember = "member"
+)
+
+// Organization is a buyer company.
+type Organization struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	TenantID  int64     `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Department struct {
+	ID             int64     `json:"id"`
+	OrganizationID int64     `json:"organization_id"`
+	Name           string    `json:"name"`
+	Location       string    `json:"location"`
+	CostCenter     string    `json:"cost_center"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// OrganizationMember places a buyer in an organization. A buyer belongs to
+// at most one organization.
+type OrganizationMember struct {
+	UserID         int64     `json:"user_id"`
+	OrganizationID int64     `json:"organization_id"`
+	DepartmentID   *int64    `json:"department_id"`
+	Role           string    `json:"role"`
+	Name           string    `json:"name"`
+	Email          string    `json:"email"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type OrganizationRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+type DepartmentRequest struct {
+	Name       string `json:"name" validate:"required,max=255"`
+	Location   string `json:"location" validate:"max=255"`
+	CostCenter string `json:"cost_center" validate:"max=50"`
+}
+
+type OrganizationMemberRequest struct {
+	DepartmentID *int64 `json:"department_id"`
+	Role         string `json:"role" validate:"required,oneof=admin member"`
+}
+
+type OrganizationRepository interface {
+	// ForTenant returns the repository scoped to a tenant's organizations,
+	// with their departments and members, and SystemScope the one spanning
+	// every tenant. A repository that is neither matches nothing.
+	ForTenant(tenantID int64) OrganizationRepository
+	SystemScope() OrganizationRepository
+	GetAll(afterID *int64, limit int) ([]Organization, error)
+	Count() (int64, error)
+	GetByID(id int64) (*Organization, error)
+	GetByName(name string) (*Organization, error)
+	Create(organization *Organization) error
+	Update(organization *Organization) error
+	Delete(id int64) error
+	GetDepartments(organizationID int64) ([]Department, error)
+	GetDepartment(id int64) (*Department, error)
+	GetDepartmentByName(organizationID int64, name string) (*Department, error)
+	CreateDepartment(department *Department) error
+	UpdateDepartment(department *Department) error
+	DeleteDepartment(id int64) error
+	GetMembers(organizationID int64, afterID *int64, limit int) ([]OrganizationMember, error)
+	CountMembers(organizationID int64) (int64, error)
+	// GetMember returns the membership of a user, or nil if the user is in
+	// no organization.
+	GetMember(userID int64) (*OrganizationMember, error)
+	// SaveMember adds a user to an organization, or changes the department
+	// and role of a member of the same organization.
+	SaveMember(member *OrganizationMember) error
+	DeleteMember(userID int64) error
+	CountAdmins(organizationID int64) (int64, error)
+}
+
+// OrganizationUsecase manages organizations. Apart from GetAll, Create and
+// Delete, which are for holders of organization:manage, every method checks
+// that actor is an admin of the organization, or for reads a member, unless
+// it holds organization:manage.
+type OrganizationUsecase interface {
+	GetAll(page pagination.Params, actor Actor) (*pagination.Page[Organization], error)
+	GetByID(id int64, actor Actor) (*Organization, error)
+	Create(req OrganizationRequest, actor Actor) (*Organization, error)
+	Update(id int64, req OrganizationRequest, actor Actor) (*Organization, error)
+	Delete(id int64, actor Actor) error
+	// GetMembership returns the organization membership of a user in the
+	// actor's tenant.
+	GetMembership(userID int64, actor Actor) (*OrganizationMember, error)
+	GetDepartments(organizationID int64, actor Actor) ([]Department, error)
+	CreateDepartment(organizationID int64, req DepartmentRequest, actor Actor) (*Department, error)
+	UpdateDepartment(organizationID, departmentID int64, req DepartmentRequest, actor Actor) (*Department, error)
+	DeleteDepartment(organizationID, departmentID int64, actor Actor) error
+	GetMembers(organizationID int64, page pagination.Params, actor Actor) (*pagination.Page[OrganizationMember], error)
+	// SetMember adds a buyer to the organization or changes their
+	// department and role.
+	SetMember(organizationID, userID int64, req OrganizationMemberRequest, actor Actor) (*OrganizationMember, error)
+	RemoveMember(organizationID, userID int64, actor Actor) error
+}
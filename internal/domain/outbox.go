@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/webhook"
+)
+
+// Job types. Jobs are written to the outbox in the same transaction as the
+// change that causes them and run by the background worker pool.
+const (
+	JobNotificationDeliver = "notification.deliver"
+	JobEmailSend           = "email.send"
+	JobEmailVerification   = "email.verification"
+	JobWebhookPublish      = "webhook.publish"
+)
+
+// Job statuses. A job that fails is retried with exponential backoff until
+// it runs out of attempts and is moved to the dead letter status.
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusDead      = "dead"
+)
+
+// DefaultJobMaxAttempts is used for jobs enqueued without MaxAttempts.
+const DefaultJobMaxAttempts = 10
+
+type Job struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+	LastError   string          `json:"last_error"`
+	CompletedAt *time.Time      `json:"completed_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// NewJob returns a job of jobType with payload encoded as JSON, due now.
+func NewJob(jobType string, payload interface{}) (*Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &Job{Type: jobType, Payload: data, MaxAttempts: DefaultJobMaxAttempts}, nil
+}
+
+// NotificationJob delivers a templated notification for Event to a user.
+type NotificationJob struct {
+	UserID int64                  `json:"user_id"`
+	Event  string                 `json:"event"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+}
+
+func NewNotificationJob(userID int64, event string, data map[string]interface{}) (*Job, error) {
+	return NewJob(JobNotificationDeliver, NotificationJob{UserID: userID, Event: event, Data: data})
+}
+
+// EmailJob sends one email.
+type EmailJob struct {
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	Body    string   `json:"body"`
+}
+
+// WebhookEventJob fans an event out to the webhook subscriptions. Envelope
+// is the exact body posted to subscribers, built when the event happened so
+// its id and timestamp survive retries.
+type WebhookEventJob struct {
+	Event    string          `json:"event"`
+	Envelope json.RawMessage `json:"envelope"`
+}
+
+// WebhookEnvelope is the JSON body posted to webhook subscribers.
+type WebhookEnvelope struct {
+	ID         string      `json:"id"`
+	Event      string      `json:"event"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+func NewWebhookEventJob(event string, data interface{}) (*Job, error) {
+	id, err := webhook.NewEventID()
+	if err != nil {
+		return nil, err
+	}
+
+	envelope, err := json.Marshal(WebhookEnvelope{ID: id, Event: event, OccurredAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		return nil, err
+	}
+	return NewJob(JobWebhookPublish, WebhookEventJob{Event: event, Envelope: envelope})
+}
+
+// PermanentJobError marks a job failure that retrying cannot fix. The job
+// is dead-lettered without further attempts.
+type PermanentJobError struct {
+	Err error
+}
+
+func (e *PermanentJobError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentJobError) Unwrap() error {
+	return e.Err
+}
+
+type JobRepository interface {
+	Enqueue(jobs ...*Job) error
+	// Claim leases the next due job for lease, or returns nil when there is
+	// none. A job whose lease ran out, because its worker died, is claimed
+	// again. Claiming counts as an attempt.
+	Claim(lease time.Duration) (*Job, error)
+	Complete(id int64) error
+	// Fail records a failed attempt and moves the job to status, pending to
+	// retry at runAt or dead.
+	Fail(id int64, status string, runAt time.Time, lastError string) error
+	GetJobs(status string, afterID *int64, limit int) ([]Job, error)
+	CountJobs(status string) (int64, error)
+	// Requeue resets a dead job so it runs again. It reports false when the
+	// job does not exist or is not dead.
+	Requeue(id int64) (bool, error)
+	// DeleteSucceeded deletes the jobs that succeeded before before.
+	DeleteSucceeded(before time.Time) (int64, error)
+}
+
+type JobUsecase interface {
+	GetJobs(status string, page pagination.Params) (*pagination.Page[Job], error)
+	Retry(id int64) error
+	// Prune deletes succeeded jobs older than retention and returns how
+	// many were deleted.
+	Prune(retention time.Duration) (int64, error)
+}
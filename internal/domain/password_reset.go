@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+// PasswordResetRepository stores the hashes of password reset tokens.
+type PasswordResetRepository interface {
+	Create(userID int64, tokenHash string, expiresAt time.Time) error
+	// Consume marks the token with tokenHash used and returns its user. ok
+	// is false when the token is unknown, already used or expired.
+	Consume(tokenHash string) (userID int64, ok bool, err error)
+	// InvalidateAll marks every unused token of the user used.
+	InvalidateAll(userID int64) error
+	DeleteExpired(before time.Time) (int64, error)
+}
+
+type PasswordResetUsecase interface {
+	// ForgotPassword emails a reset link if email belongs to a user. It
+	// reports success either way, so callers cannot probe for accounts.
+	ForgotPassword(email string) error
+	ResetPassword(request ResetPasswordRequest, actor Actor) error
+	// Prune deletes expired tokens.
+	Prune() (int64, error)
+}
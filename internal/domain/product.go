@@ -2,42 +2,143 @@ package domain
 
 import (
 	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+)
+
+const (
+	ProductSortRelevance = "relevance"
+	ProductSortNewest    = "newest"
+	ProductSortPriceAsc  = "price_asc"
+	ProductSortPriceDesc = "price_desc"
+	ProductSortName      = "name"
 )
 
+// PriceBucketBounds are the lower bounds of the price facet buckets. Prices
+// below the first bound fall into the first bucket and prices at or above
+// the last bound fall into an open-ended last bucket.
+var PriceBucketBounds = []int32{100000, 500000, 1000000, 5000000}
+
 type Product struct {
-	ID        int64     `json:"id"`
-	VendorID  int64     `json:"vendor_id"`
-	Name      string    `json:"name" validate:"required"`
-	Price     int32     `json:"price" validate:"required,gt=0"`
-	Stock     int       `json:"stock" validate:"required,gt=0"`
+	ID          int64  `json:"id"`
+	VendorID    int64  `json:"vendor_id"`
+	Name        string `json:"name" validate:"required"`
+	SKU         string `json:"sku" validate:"max=64"`
+	Description string `json:"description"`
+	Category    string `json:"category" validate:"max=100"`
+	Price       int32  `json:"price" validate:"required,gt=0"`
+	// Stock is the on-hand quantity. It sets the opening balance when a
+	// product is created; afterwards it only changes through inventory
+	// movements.
+	Stock int `json:"stock" validate:"gte=0"`
+	// ReservedStock is held by pending and issued purchase orders.
+	ReservedStock int `json:"reserved_stock"`
+	LeadTimeDays  int `json:"lead_time_days" validate:"gte=0"`
+	// MinOrderQuantity is the smallest quantity a buyer may order (MOQ).
+	MinOrderQuantity int `json:"min_order_quantity" validate:"gte=0"`
+	// LowStockThreshold alerts the vendor when available stock falls to or
+	// below it. Nil disables the alert.
+	LowStockThreshold *int `json:"low_stock_threshold" validate:"omitempty,gte=0"`
+	// TenantID is the tenant of the vendor.
+	TenantID  int64     `json:"-"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 type ProductWithVendor struct {
-	ID          int64     `json:"id"`
-	VendorID    int64     `json:"vendor_id"`
-	ProductName string    `json:"product_name" validate:"required"`
-	Price       int32     `json:"price" validate:"required,gt=0"`
-	Stock       int       `json:"stock" validate:"required,gt=0"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	VendorName  string    `json:"vendor_name"`
+	ID               int64     `json:"id"`
+	VendorID         int64     `json:"vendor_id"`
+	ProductName      string    `json:"product_name" validate:"required"`
+	SKU              string    `json:"sku"`
+	Description      string    `json:"description"`
+	Category         string    `json:"category"`
+	Price            int32     `json:"price" validate:"required,gt=0"`
+	Stock            int       `json:"stock"`
+	ReservedStock    int       `json:"reserved_stock"`
+	LeadTimeDays     int       `json:"lead_time_days"`
+	MinOrderQuantity int       `json:"min_order_quantity"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	VendorName       string    `json:"vendor_name"`
+	// Rank and Snippet are only set for full-text search results.
+	Rank    float32 `json:"rank,omitempty"`
+	Snippet string  `json:"snippet,omitempty"`
+}
+
+// ProductFilter narrows and orders the product catalog. Nil pointers and
+// empty strings mean the filter is not applied.
+type ProductFilter struct {
+	Query           string
+	MinPrice        *int32
+	MaxPrice        *int32
+	VendorID        *int64
+	Category        string
+	InStock         bool
+	MaxLeadTimeDays *int
+	Sort            string
+}
+
+// ProductCursor is the keyset position of a catalog page. Which fields are
+// compared besides ID depends on Sort.
+type ProductCursor struct {
+	Sort  string  `json:"sort"`
+	ID    int64   `json:"id"`
+	Price int32   `json:"price,omitempty"`
+	Name  string  `json:"name,omitempty"`
+	Rank  float32 `json:"rank,omitempty"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type VendorFacetCount struct {
+	VendorID   int64  `json:"vendor_id"`
+	VendorName string `json:"vendor_name"`
+	Count      int64  `json:"count"`
+}
+
+type PriceBucketCount struct {
+	Min   int32  `json:"min"`
+	Max   *int32 `json:"max"`
+	Count int64  `json:"count"`
+}
+
+// ProductFacets holds the counts used to render the catalog filter sidebar.
+// Each facet ignores its own filter so the other options stay selectable.
+type ProductFacets struct {
+	Categories   []FacetCount       `json:"categories"`
+	Vendors      []VendorFacetCount `json:"vendors"`
+	PriceBuckets []PriceBucketCount `json:"price_buckets"`
+}
+
+type ProductList struct {
+	pagination.Page[ProductWithVendor]
+	Facets *ProductFacets `json:"facets"`
 }
 
 type ProductRepository interface {
+	// ForTenant returns the repository scoped to a tenant's products, and
+	// SystemScope the one spanning every tenant. A repository that is
+	// neither matches nothing.
+	ForTenant(tenantID int64) ProductRepository
+	SystemScope() ProductRepository
 	Create(product *Product) error
 	GetByID(id int64) (*Product, error)
 	Update(product *Product) error
 	Delete(id int64) error
-	GetAll(name string, limit int, offset int) ([]ProductWithVendor, error)
-	GetProductsByVendorID(vendorID int64, limit int, offset int) ([]Product, error)
+	GetAll(filter ProductFilter, cursor *ProductCursor, limit int) ([]ProductWithVendor, error)
+	Count(filter ProductFilter) (int64, error)
+	GetFacets(filter ProductFilter) (*ProductFacets, error)
+	GetProductsByVendorID(vendorID int64, afterID *int64, limit int) ([]Product, error)
+	CountByVendorID(vendorID int64) (int64, error)
 }
 
 type ProductUsecase interface {
-	CreateProduct(product *Product) error
-	GetProductByID(id int64) (*Product, error)
-	UpdateProduct(product *Product) error
-	DeleteProduct(id int64) error
-	GetAll(name string, limit int, offset int) ([]ProductWithVendor, error)
-	GetProductsByVendorID(vendorID int64, limit int, offset int) ([]Product, error)
+	CreateProduct(product *Product, actor Actor) error
+	GetProductByID(id int64, actor Actor) (*Product, error)
+	UpdateProduct(product *Product, actor Actor) error
+	DeleteProduct(id int64, actor Actor) error
+	GetAll(filter ProductFilter, page pagination.Params, actor Actor) (*ProductList, error)
+	GetProductsByVendorID(vendorID int64, page pagination.Params, actor Actor) (*pagination.Page[Product], error)
 }
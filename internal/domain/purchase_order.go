@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+)
+
+// Purchase order statuses. Checkout creates pending orders that hold a stock
+// reservation until the buyer confirms them or they expire.
+const (
+	PurchaseOrderStatusPending   = "pending"
+	PurchaseOrderStatusIssued    = "issued"
+	PurchaseOrderStatusShipped   = "shipped"
+	PurchaseOrderStatusCancelled = "cancelled"
+	PurchaseOrderStatusExpired   = "expired"
+)
+
+var purchaseOrderTransitions = map[string][]string{
+	PurchaseOrderStatusPending: {PurchaseOrderStatusIssued, PurchaseOrderStatusCancelled, PurchaseOrderStatusExpired},
+	PurchaseOrderStatusIssued:  {PurchaseOrderStatusShipped, PurchaseOrderStatusCancelled},
+}
+
+// CanTransitionPurchaseOrder reports whether a purchase order may move from
+// one status to another.
+func CanTransitionPurchaseOrder(from, to string) bool {
+	for _, next := range purchaseOrderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+type PurchaseOrder struct {
+	ID          int64               `json:"id"`
+	BuyerID     int64               `json:"buyer_id"`
+	VendorID    int64               `json:"vendor_id"`
+	Status      string              `json:"status"`
+	TotalAmount int64               `json:"total_amount"`
+	Items       []PurchaseOrderItem `json:"items,omitempty"`
+	// OrganizationID and DepartmentID are where the buyer was at checkout.
+	OrganizationID *int64 `json:"organization_id,omitempty"`
+	DepartmentID   *int64 `json:"department_id,omitempty"`
+	// TenantID is the tenant of the buyer and the vendor.
+	TenantID int64 `json:"-"`
+	// ExpiresAt is when a pending order's reservation is released.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type PurchaseOrderItem struct {
+	ID              int64  `json:"id"`
+	PurchaseOrderID int64  `json:"purchase_order_id"`
+	ProductID       int64  `json:"product_id"`
+	ProductName     string `json:"product_name"`
+	UnitPrice       int32  `json:"unit_price"`
+	Quantity        int    `json:"quantity"`
+	Subtotal        int64  `json:"subtotal"`
+}
+
+type PurchaseOrderRepository interface {
+	// ForTenant returns the repository scoped to a tenant's purchase
+	// orders, and SystemScope the one spanning every tenant. A repository
+	// that is neither matches nothing.
+	ForTenant(tenantID int64) PurchaseOrderRepository
+	SystemScope() PurchaseOrderRepository
+	GetByID(id int64) (*PurchaseOrder, error)
+	GetByBuyerID(buyerID int64, afterID *int64, limit int) ([]PurchaseOrder, error)
+	CountByBuyerID(buyerID int64) (int64, error)
+	GetByVendorID(vendorID int64, afterID *int64, limit int) ([]PurchaseOrder, error)
+	CountByVendorID(vendorID int64) (int64, error)
+	// GetByOrganizationID lists the orders of an organization, of one of
+	// its departments if departmentID is set.
+	GetByOrganizationID(organizationID int64, departmentID *int64, afterID *int64, limit int) ([]PurchaseOrder, error)
+	CountByOrganizationID(organizationID int64, departmentID *int64) (int64, error)
+	// Transition locks the order, checks the move with
+	// CanTransitionPurchaseOrder and applies it together with the matching
+	// reservation changes: cancelling releases the reserved stock, shipping
+	// takes it off hand. by is recorded on the ledger movements.
+	Transition(id int64, to string, by *int64) (*PurchaseOrder, error)
+	// ExpirePending expires up to limit pending orders past their deadline
+	// and releases their reservations. It returns the expired orders.
+	ExpirePending(limit int) ([]PurchaseOrder, error)
+}
+
+type PurchaseOrderUsecase interface {
+	// GetByID returns the purchase order if actor is its buyer or vendor,
+	// or may read any order.
+	GetByID(id int64, actor Actor) (*PurchaseOrder, error)
+	// GetByBuyerID and GetByVendorID list the orders of a buyer or vendor
+	// in the actor's tenant.
+	GetByBuyerID(buyerID int64, page pagination.Params, actor Actor) (*pagination.Page[PurchaseOrder], error)
+	GetByVendorID(vendorID int64, page pagination.Params, actor Actor) (*pagination.Page[PurchaseOrder], error)
+	// GetByOrganizationID lists the orders of an organization for its
+	// admins and holders of po:read.
+	GetByOrganizationID(organizationID int64, departmentID *int64, page pagination.Params, actor Actor) (*pagination.Page[PurchaseOrder], error)
+	// Confirm issues a pending order to its vendor. Only the buyer may
+	// confirm, and only before the order expires.
+	Confirm(id, buyerID int64, actor Actor) (*PurchaseOrder, error)
+	Cancel(id, buyerID int64, actor Actor) (*PurchaseOrder, error)
+	Ship(id, vendorID int64, actor Actor) (*PurchaseOrder, error)
+	// ExpirePending releases the stock held by abandoned pending orders.
+	ExpirePending() (int, error)
+}
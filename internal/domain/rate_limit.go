@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit allows Requests requests per Period as a token bucket: a bucket
+// holds Requests tokens, each request takes one, and tokens refill evenly
+// over Period. A bucket is represented by the time it will be full again.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// RateLimits are the configurable limits of the API.
+type RateLimits struct {
+	// Public limits each IP address on the public routes.
+	Public RateLimit
+	// Login limits each IP address on POST /login.
+	Login RateLimit
+	// Register limits each IP address on vendor registration.
+	Register RateLimit
+	// User limits each user on the authenticated routes.
+	User RateLimit
+}
+
+// RateLimitResult is the state of a bucket after a request.
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is how long until a rejected request would be allowed.
+	RetryAfter time.Duration
+}
+
+// ParseRateLimit parses a limit written as "<requests>/<period>", such as
+// "10/1m".
+func ParseRateLimit(s string) (RateLimit, error) {
+	requests, period, ok := strings.Cut(s, "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("rate limit %q is not <requests>/<period>", s)
+	}
+	n, err := strconv.Atoi(requests)
+	if err != nil || n <= 0 {
+		return RateLimit{}, fmt.Errorf("rate limit %q needs a positive number of requests", s)
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return RateLimit{}, fmt.Errorf("rate limit %q needs a positive period", s)
+	}
+	return RateLimit{Requests: n, Period: d}, nil
+}
+
+// Interval is how long one token takes to refill.
+func (l RateLimit) Interval() time.Duration {
+	return l.Period / time.Duration(l.Requests)
+}
+
+// Take takes a token from a bucket that is full at fullAt, if it has one,
+// and returns when the bucket is full afterwards.
+func (l RateLimit) Take(fullAt, now time.Time) (time.Time, RateLimitResult) {
+	if fullAt.Before(now) {
+		fullAt = now
+	}
+	next := fullAt.Add(l.Interval())
+	if next.Sub(now) > l.Period {
+		return fullAt, l.Result(fullAt, now, false)
+	}
+	return next, l.Result(next, now, true)
+}
+
+// Result describes a bucket that is full at fullAt after a request that
+// was allowed or not.
+func (l RateLimit) Result(fullAt, now time.Time, allowed bool) RateLimitResult {
+	reset := max(fullAt.Sub(now), 0)
+	result := RateLimitResult{
+		Allowed:   allowed,
+		Remaining: int((l.Period - reset) / l.Interval()),
+		Reset:     reset,
+	}
+	if !allowed {
+		result.RetryAfter = max(reset+l.Interval()-l.Period, 0)
+	}
+	return result
+}
+
+// RateLimitStore keeps the buckets of the rate limits.
+type RateLimitStore interface {
+	// Take takes a token from the bucket of key under limit.
+	Take(key string, limit RateLimit, now time.Time) (RateLimitResult, error)
+	// Prune forgets buckets that are full by now, which is the same as
+	// having no bucket.
+	Prune(now time.Time) (int64, error)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+const (
+	RFQStatusOpen   = "open"
+	RFQStatusClosed = "closed"
+)
+
+// RFQ is a request for quotation: a buyer invites vendors to quote until
+// the deadline, when it closes.
+type RFQ struct {
+	ID       int64     `json:"id"`
+	BuyerID  int64     `json:"buyer_id"`
+	Title    string    `json:"title"`
+	Status   string    `json:"status"`
+	Deadline time.Time `json:"deadline"`
+	// TenantID is the tenant of the buyer.
+	TenantID  int64      `json:"-"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// RFQRequest opens an RFQ. The deadline must be in the future.
+type RFQRequest struct {
+	Title    string    `json:"title" validate:"required,max=255"`
+	Deadline time.Time `json:"deadline" validate:"required"`
+}
+
+type RFQRepository interface {
+	// ForTenant returns the repository scoped to a tenant's RFQs, and
+	// SystemScope the one spanning every tenant. A repository that is
+	// neither matches nothing.
+	ForTenant(tenantID int64) RFQRepository
+	SystemScope() RFQRepository
+	Create(rfq *RFQ) error
+	// CloseDue closes up to limit open RFQs past their deadline and returns
+	// them. RFQs locked by another transaction are skipped.
+	CloseDue(limit int) ([]RFQ, error)
+}
+
+type RFQUsecase interface {
+	// Create opens an RFQ for the actor, as its buyer.
+	Create(request RFQRequest, actor Actor) (*RFQ, error)
+	// CloseDue closes the RFQs whose deadline has passed, in every tenant,
+	// and notifies their buyers.
+	CloseDue() (int, error)
+}
@@ -0,0 +1,129 @@
+package domain
+
+import "time"
+
+// Permissions guard the routes of the API. Roles grant them to users.
+const (
+	PermissionUserRead           = "user:read"
+	PermissionUserWrite          = "user:write"
+	PermissionUserDelete         = "user:delete"
+	PermissionVendorApprove      = "vendor:approve"
+	PermissionSessionManage      = "session:manage"
+	PermissionRoleManage         = "role:manage"
+	PermissionLoginAttemptRead   = "login_attempt:read"
+	PermissionWebhookManage      = "webhook:manage"
+	PermissionJobManage          = "job:manage"
+	PermissionAuditRead          = "audit:read"
+	PermissionAuditSign          = "audit:sign"
+	PermissionProductWrite       = "product:write"
+	PermissionProductManage      = "product:manage"
+	PermissionInventoryManage    = "inventory:manage"
+	PermissionPOFulfill          = "po:fulfill"
+	PermissionPORead             = "po:read"
+	PermissionCartManage         = "cart:manage"
+	PermissionPOApprove          = "po:approve"
+	PermissionProductSubscribe   = "product:subscribe"
+	PermissionOrganizationManage = "organization:manage"
+)
+
+// Built-in roles. They cannot be renamed or deleted, and the operator role
+// cannot be changed at all, so there is always a role that can manage
+// roles. Admins administer their tenant; operators run the deployment and
+// hold the permissions over what all tenants share.
+const (
+	RoleAdmin    = "admin"
+	RoleVendor   = "vendor"
+	RoleUser     = "user"
+	RoleOperator = "operator"
+)
+
+type Permission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Permissions lists every permission a role may grant.
+var Permissions = []Permission{
+	{PermissionUserRead, "View any user"},
+	{PermissionUserWrite, "Update any user and lift login lockouts"},
+	{PermissionUserDelete, "Delete users"},
+	{PermissionVendorApprove, "List, approve and reject vendors"},
+	{PermissionSessionManage, "List and revoke the sessions of any user"},
+	{PermissionRoleManage, "Manage roles and assign them to users"},
+	{PermissionLoginAttemptRead, "Review login attempts"},
+	{PermissionWebhookManage, "Manage webhook subscriptions and deliveries"},
+	{PermissionJobManage, "List and retry background jobs"},
+	{PermissionAuditRead, "Query and verify the audit trail"},
+	{PermissionAuditSign, "Sign audit checkpoints"},
+	{PermissionProductWrite, "Create, update and delete own products"},
+	{PermissionProductManage, "Update and delete any vendor's products"},
+	{PermissionInventoryManage, "View and change the stock of own products"},
+	{PermissionPOFulfill, "List and ship purchase orders issued to the vendor"},
+	{PermissionPORead, "View any purchase order"},
+	{PermissionCartManage, "Fill the cart and check out"},
+	{PermissionPOApprove, "List, confirm and cancel own purchase orders"},
+	{PermissionProductSubscribe, "Follow products for back-in-stock alerts"},
+	{PermissionOrganizationManage, "Create and delete organizations and manage any of them"},
+}
+
+// IsPermission reports whether name is a known permission.
+func IsPermission(name string) bool {
+	for _, permission := range Permissions {
+		if permission.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Role is a named set of permissions.
+type Role struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	BuiltIn     bool      `json:"built_in"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type RoleRequest struct {
+	Name        string   `json:"name" validate:"required,max=50"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type UserRolesRequest struct {
+	Roles []string `json:"roles" validate:"required,min=1"`
+}
+
+type RoleRepository interface {
+	GetAll() ([]Role, error)
+	// GetByID and GetByName return nil when the role does not exist.
+	GetByID(id int64) (*Role, error)
+	GetByName(name string) (*Role, error)
+	Create(role *Role) error
+	Update(role *Role) error
+	Delete(id int64) error
+	// SetPermissions replaces the permissions of a role.
+	SetPermissions(roleID int64, permissions []string) error
+	GetByUserID(userID int64) ([]Role, error)
+	// GetUserPermissions returns the permissions of all of a user's roles.
+	GetUserPermissions(userID int64) ([]string, error)
+	// SetUserRoles replaces the roles of a user.
+	SetUserRoles(userID int64, roleIDs []int64) error
+	// AddUserRoleByName gives a user the named role, if it exists.
+	AddUserRoleByName(userID int64, name string) error
+	CountUsers(roleID int64) (int64, error)
+}
+
+type RoleUsecase interface {
+	GetAll() ([]Role, error)
+	GetByID(id int64) (*Role, error)
+	Create(req RoleRequest, actor Actor) (*Role, error)
+	Update(id int64, req RoleRequest, actor Actor) (*Role, error)
+	Delete(id int64, actor Actor) error
+	GetUserRoles(userID int64, actor Actor) ([]Role, error)
+	// SetUserRoles replaces the roles of a user with the named ones.
+	SetUserRoles(userID int64, names []string, actor Actor) ([]Role, error)
+}
@@ -0,0 +1,13 @@
+package domain
+
+// LeaderLock elects one process among the replicas to run each scheduled
+// job. Locks are held by this process until released, closed, or the
+// process loses its database session.
+type LeaderLock interface {
+	// TryAcquire takes the named lock unless another process holds it and
+	// reports whether this process holds it. A lock already held is kept.
+	TryAcquire(name string) (bool, error)
+	Release(name string) error
+	// Close releases every lock held by this process.
+	Close() error
+}
@@ -0,0 +1,107 @@
+package domain
+
+import "time"
+
+// Reasons a session was revoked.
+const (
+	SessionRevokedLogout        = "logout"
+	SessionRevokedLogoutAll     = "logout_all"
+	SessionRevokedTokenReuse    = "token_reuse"
+	SessionRevokedPasswordReset = "password_reset"
+	SessionRevokedByUser        = "revoked_by_user"
+	SessionRevokedByAdmin       = "revoked_by_admin"
+)
+
+// Session is one login on one device. It stays alive while its refresh
+// tokens are used before ExpiresAt, which moves forward on every refresh.
+type Session struct {
+	ID            int64      `json:"id"`
+	UserID        int64      `json:"user_id"`
+	UserAgent     string     `json:"user_agent"`
+	IP            string     `json:"ip"`
+	LastUsedAt    time.Time  `json:"last_used_at"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	RevokedReason string     `json:"revoked_reason,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	// Current marks the session of the request listing the sessions.
+	Current bool `json:"current"`
+}
+
+// RefreshToken is a stored refresh token with the state of its session.
+type RefreshToken struct {
+	ID               int64
+	SessionID        int64
+	UserID           int64
+	UsedAt           *time.Time
+	SessionExpiresAt time.Time
+	SessionRevokedAt *time.Time
+}
+
+// AccessState is what decides whether a signed, unexpired access token can
+// still be used.
+type AccessState struct {
+	TokenVersion  int
+	Status        string
+	SessionActive bool
+	// Permissions are those of the user's roles as they are now.
+	Permissions []string
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type SessionRepository interface {
+	// Create stores a new session and sets its ID.
+	Create(session *Session) error
+	AddRefreshToken(sessionID int64, tokenHash string) error
+	// GetRefreshToken returns the token with tokenHash, or nil, and locks it
+	// and its session until the transaction ends.
+	GetRefreshToken(tokenHash string) (*RefreshToken, error)
+	// UseRefreshToken marks the token used; ok is false if it already was.
+	UseRefreshToken(id int64) (ok bool, err error)
+	// Touch records a refresh of the session and extends it to expiresAt.
+	Touch(id int64, ip, userAgent string, expiresAt time.Time) error
+	// Revoke revokes the session; ok is false if it already was.
+	Revoke(id int64, reason string) (ok bool, err error)
+	// RevokeAll revokes every active session of the user.
+	RevokeAll(userID int64, reason string) (int64, error)
+	// GetActive returns the user's sessions that are neither revoked nor
+	// expired, most recently used first.
+	GetActive(userID int64) ([]*Session, error)
+	// RevokeForUser revokes an active session of the user; ok is false if
+	// the user has no such session.
+	RevokeForUser(id, userID int64, reason string) (ok bool, err error)
+	DeleteExpired(before time.Time) (int64, error)
+	// GetAccessState returns the state of the user and their session, or
+	// nil if either no longer exists.
+	GetAccessState(userID, sessionID int64) (*AccessState, error)
+}
+
+type SessionUsecase interface {
+	// Start opens a session for a user who has just signed in and returns
+	// the access and refresh tokens.
+	Start(user *User, actor Actor) (*LoginResult, error)
+	// Refresh exchanges a refresh token for new tokens. A refresh token
+	// works once; presenting it again revokes the session.
+	Refresh(refreshToken string, actor Actor) (*LoginResult, error)
+	// Logout revokes the session of the refresh token.
+	Logout(refreshToken string) error
+	// GetActive lists the user's active sessions. The one with ID
+	// currentSessionID is marked current.
+	GetActive(userID, currentSessionID int64) ([]*Session, error)
+	// Revoke revokes one of the user's sessions.
+	Revoke(userID, sessionID int64, reason string) error
+	// RevokeAll revokes every session of the user.
+	RevokeAll(userID int64, reason string) (int64, error)
+	// CheckUser rejects managing the sessions of a user who is not in
+	// actor's tenant.
+	CheckUser(userID int64, actor Actor) error
+	// Prune deletes expired sessions.
+	Prune() (int64, error)
+	// CheckAccess rejects an access token whose session has ended or whose
+	// user has since changed role, status or password, or was deleted.
+	// Otherwise it returns the user's current permissions.
+	CheckAccess(userID, sessionID int64, tokenVersion int) (permissions []string, err error)
+}
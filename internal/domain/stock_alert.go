@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+)
+
+// Stock alert types. Low-stock and out-of-stock alerts go to the vendor;
+// back-in-stock alerts go to the buyers following the product.
+const (
+	StockAlertLowStock    = "low_stock"
+	StockAlertOutOfStock  = "out_of_stock"
+	StockAlertBackInStock = "back_in_stock"
+)
+
+type StockAlert struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"user_id"`
+	ProductID   int64     `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	AlertType   string    `json:"alert_type"`
+	Available   int       `json:"available"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// StockAlertTypes returns the alerts raised when a product's available stock
+// moves from before to after. Alerts fire only when a boundary is crossed,
+// so repeated movements below a threshold do not raise duplicates. A nil
+// threshold disables low-stock alerts.
+func StockAlertTypes(before, after int, threshold *int) []string {
+	var types []string
+	switch {
+	case before > 0 && after <= 0:
+		types = append(types, StockAlertOutOfStock)
+	case before <= 0 && after > 0:
+		types = append(types, StockAlertBackInStock)
+	}
+	if threshold != nil && after > 0 && before > *threshold && after <= *threshold {
+		types = append(types, StockAlertLowStock)
+	}
+	return types
+}
+
+// stockAlertNotifications maps each alert type to its notification event.
+var stockAlertNotifications = map[string]string{
+	StockAlertLowStock:    NotificationLowStock,
+	StockAlertOutOfStock:  NotificationOutOfStock,
+	StockAlertBackInStock: NotificationBackInStock,
+}
+
+// NewStockAlertNotificationJobs returns a notification job for each
+// recipient of an alert about product.
+func NewStockAlertNotificationJobs(alertType string, productID int64, productName string, available int, recipients []int64) ([]*Job, error) {
+	event, ok := stockAlertNotifications[alertType]
+	if !ok {
+		return nil, fmt.Errorf("unknown stock alert type %q", alertType)
+	}
+
+	data := map[string]interface{}{
+		"ProductID":   productID,
+		"ProductName": productName,
+		"Available":   available,
+	}
+	jobs := make([]*Job, len(recipients))
+	for i, userID := range recipients {
+		job, err := NewNotificationJob(userID, event, data)
+		if err != nil {
+			return nil, err
+		}
+		jobs[i] = job
+	}
+	return jobs, nil
+}
+
+type StockAlertRepository interface {
+	Subscribe(userID, productID int64) error
+	// Unsubscribe reports false when the user did not follow the product.
+	Unsubscribe(userID, productID int64) (bool, error)
+	GetByUserID(userID int64, afterID *int64, limit int) ([]StockAlert, error)
+	CountByUserID(userID int64) (int64, error)
+}
+
+type StockAlertUsecase interface {
+	// Subscribe alerts the user when a product of actor's tenant is back in
+	// stock.
+	Subscribe(userID, productID int64, actor Actor) error
+	Unsubscribe(userID, productID int64) error
+	GetAlerts(userID int64, page pagination.Params) (*pagination.Page[StockAlert], error)
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// DefaultTenantID is the tenant of everything created before tenants
+// existed, and of anonymous requests that name no tenant.
+const DefaultTenantID int64 = 1
+
+// Tenant is one procuring entity, such as a subsidiary, sharing the
+// deployment with others. Users, products, purchase orders and
+// organizations belong to one tenant, and repositories scoped to another
+// tenant neither see nor change them.
+type Tenant struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TenantRepository looks tenants up. Tenants are provisioned by the
+// operators of the deployment, not through the API.
+type TenantRepository interface {
+	// GetBySlug returns the tenant, or nil if there is none with slug.
+	GetBySlug(slug string) (*Tenant, error)
+}
+
+type TenantUsecase interface {
+	// GetBySlug returns the tenant an anonymous request names.
+	GetBySlug(slug string) (*Tenant, error)
+}
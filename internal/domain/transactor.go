@@ -0,0 +1,60 @@
+package domain
+
+// Repositories are bound to one database transaction.
+type Repositories struct {
+	Users           UserRepository
+	Products        ProductRepository
+	Carts           CartRepository
+	PurchaseOrders  PurchaseOrderRepository
+	Notifications   NotificationRepository
+	Jobs            JobRepository
+	Audit           AuditRepository
+	PasswordResets  PasswordResetRepository
+	MFA             MFARepository
+	Sessions        SessionRepository
+	Roles           RoleRepository
+	Organizations   OrganizationRepository
+	RFQs            RFQRepository
+	Approvals       ApprovalRepository
+	VendorDocuments VendorDocumentRepository
+}
+
+// ForTenant scopes the repositories that hold tenant data to tenantID, as
+// their ForTenant methods do.
+func (r Repositories) ForTenant(tenantID int64) Repositories {
+	r.Users = r.Users.ForTenant(tenantID)
+	r.Products = r.Products.ForTenant(tenantID)
+	r.PurchaseOrders = r.PurchaseOrders.ForTenant(tenantID)
+	r.Audit = r.Audit.ForTenant(tenantID)
+	r.Organizations = r.Organizations.ForTenant(tenantID)
+	r.RFQs = r.RFQs.ForTenant(tenantID)
+	r.Approvals = r.Approvals.ForTenant(tenantID)
+	r.VendorDocuments = r.VendorDocuments.ForTenant(tenantID)
+	return r
+}
+
+// SystemScope lets the repositories that hold tenant data span every
+// tenant, as their SystemScope methods do.
+func (r Repositories) SystemScope() Repositories {
+	r.Users = r.Users.SystemScope()
+	r.Products = r.Products.SystemScope()
+	r.PurchaseOrders = r.PurchaseOrders.SystemScope()
+	r.Audit = r.Audit.SystemScope()
+	r.Organizations = r.Organizations.SystemScope()
+	r.RFQs = r.RFQs.SystemScope()
+	r.Approvals = r.Approvals.SystemScope()
+	r.VendorDocuments = r.VendorDocuments.SystemScope()
+	return r
+}
+
+// Transactor runs fn in a transaction. The transaction commits when fn
+// returns nil and rolls back otherwise, so outbox jobs enqueued through
+// repos are only written together with the change that caused them.
+type Transactor interface {
+	// WithinTransaction scopes repos to tenantID.
+	WithinTransaction(tenantID int64, fn func(repos Repositories) error) error
+	// WithinSystemTransaction gives fn repos spanning every tenant, for
+	// the scheduler and for requests that identify a user by a credential
+	// rather than within a tenant.
+	WithinSystemTransaction(fn func(repos Repositories) error) error
+}
@@ -1,6 +1,11 @@
 package domain
 
-import "time"
+import (
+	"slices"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+)
 
 type User struct {
 	ID        int64     `json:"id"`
@@ -8,41 +13,118 @@ type User struct {
 	Username  string    `json:"username" validate:"required"`
 	Email     string    `json:"email" validate:"required"`
 	Password  string    `json:"password" validate:"required"`
-	Role      string    `json:"role"`
 	Status    string    `json:"status"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// EmailVerifiedAt is nil until the user follows the link in their
+	// verification email.
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	// PasswordChangedAt is when the password was last reset.
+	PasswordChangedAt *time.Time `json:"-"`
+	// TokenVersion changes with the user's role, status or password.
+	// Access tokens issued for another version are rejected.
+	TokenVersion int `json:"-"`
+	// FailedLogins counts the failed password logins since the last
+	// successful one.
+	FailedLogins int `json:"-"`
+	// LockedUntil is when the account may try to log in again after failed
+	// attempts.
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	// TenantID is the tenant the user belongs to, and acts in.
+	TenantID int64 `json:"-"`
+	// Roles are the names of the user's roles, as held in user_roles. They
+	// are given at registration and changed through RoleUsecase.SetUserRoles
+	// only, never with the rest of the user.
+	Roles []string `json:"-"`
+}
+
+// HasRole reports whether the user holds the named role.
+func (u *User) HasRole(name string) bool {
+	return slices.Contains(u.Roles, name)
 }
 
 type UserResponse struct {
-	ID        int64     `json:"id"`
-	Name      string    `json:"name"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Role      string    `json:"role"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              int64      `json:"id"`
+	Name            string     `json:"name"`
+	Username        string     `json:"username"`
+	Email           string     `json:"email"`
+	Roles           []string   `json:"roles"`
+	Status          string     `json:"status"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	LockedUntil     *time.Time `json:"locked_until,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// LoginResult is either a pair of tokens or, when the user needs a second
+// factor, a challenge to answer with POST /login/mfa. RecoveryCodes are
+// set when the login completed an MFA enrollment.
+type LoginResult struct {
+	AccessToken           string     `json:"access_token,omitempty"`
+	RefreshToken          string     `json:"refresh_token,omitempty"`
+	MFARequired           bool       `json:"mfa_required"`
+	MFAEnrollmentRequired bool       `json:"mfa_enrollment_required,omitempty"`
+	ChallengeToken        string     `json:"challenge_token,omitempty"`
+	ChallengeExpiresAt    *time.Time `json:"challenge_expires_at,omitempty"`
+	RecoveryCodes         []string   `json:"recovery_codes,omitempty"`
+	User                  *User      `json:"-"`
 }
 
 type UserRepository interface {
+	// ForTenant returns the repository scoped to a tenant: its queries
+	// only match that tenant's users, and it creates users in it.
+	// SystemScope returns the one spanning every tenant, for the paths
+	// that find a user by a credential rather than within a tenant. A
+	// repository that is neither matches nothing.
+	ForTenant(tenantID int64) UserRepository
+	SystemScope() UserRepository
 	Create(user *User) error
 	GetByID(id int64) (*User, error)
 	GetByEmail(email string) (*User, error)
-	GetAllByRole(role string) ([]*User, error)
+	// GetAllByRole and CountByRole skip users whose email is not verified
+	// when verifiedOnly is set.
+	GetAllByRole(role string, verifiedOnly bool, afterID *int64, limit int) ([]*User, error)
+	CountByRole(role string, verifiedOnly bool) (int64, error)
+	// GetIDsWithPermission returns the active users holding permission
+	// through one of their roles.
+	GetIDsWithPermission(permission string) ([]int64, error)
+	// Create, GetByID, GetByEmail and GetAllByRole leave roles to the role
+	// repository, but the users they return carry their role names.
+	// Update saves the user, not its roles. Changing the email clears
+	// EmailVerifiedAt.
 	Update(user *User) error
+	// UpdatePassword stores a new password hash and sets PasswordChangedAt.
+	UpdatePassword(id int64, passwordHash string) error
+	// MarkEmailVerified verifies email for the user, unless the user's
+	// address changed or is already verified; ok reports which happened.
+	MarkEmailVerified(id int64, email string) (ok bool, err error)
+	// ClaimVerificationEmail records that a verification email is being
+	// sent. ok is false when the email is verified or one was sent after
+	// sentBefore.
+	ClaimVerificationEmail(id int64, sentBefore time.Time) (ok bool, err error)
+	// IncrementFailedLogins counts a failed login and returns the number
+	// of failures since the last successful one.
+	IncrementFailedLogins(id int64) (int, error)
+	// Lock blocks logins until until, unless they are blocked for longer.
+	Lock(id int64, until time.Time) error
+	// ResetFailedLogins clears the failure count and any lock.
+	ResetFailedLogins(id int64) error
 	Delete(id int64) error
 }
 
 type UserUsecase interface {
-	Register(user *User) error
-	Login(email, password string) (string, string, error)
-	GetByID(id int64) (*User, error)
+	Register(user *User, actor Actor) error
+	Login(email, password string, actor Actor) (*LoginResult, error)
+	// GetByID returns a user if actor may see it.
+	GetByID(id int64, actor Actor) (*User, error)
 	GetByEmail(email string) (*User, error)
-	GetAllByRole(role string) ([]*User, error)
-	Update(user *User) error
-	Delete(id int64) error
-	RefreshToken(refreshToken string) (string, error)
-	ApproveVendor(id int64) error
-	RejectVendor(id int64) error
+	GetAllByRole(role string, verifiedOnly bool, page pagination.Params, actor Actor) (*pagination.Page[*User], error)
+	// Update changes a user as far as actor may. It never changes the
+	// password.
+	Update(user *User, actor Actor) error
+	Delete(id int64, actor Actor) error
+	ApproveVendor(id int64, actor Actor) error
+	RejectVendor(id int64, actor Actor) error
+	// Unlock lifts a login lockout.
+	Unlock(id int64, actor Actor) error
 }
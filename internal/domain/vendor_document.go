@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// VendorDocument is a document a vendor keeps on file, such as a
+// certificate or insurance, that is only valid until it expires.
+type VendorDocument struct {
+	ID        int64     `json:"id"`
+	VendorID  int64     `json:"vendor_id"`
+	Name      string    `json:"name"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// TenantID is the tenant of the vendor.
+	TenantID int64 `json:"-"`
+	// RemindedAt is when the vendor was reminded of the expiry.
+	RemindedAt *time.Time `json:"reminded_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// VendorDocumentRequest records a document. It must not have expired yet.
+type VendorDocumentRequest struct {
+	Name      string    `json:"name" validate:"required,max=255"`
+	ExpiresAt time.Time `json:"expires_at" validate:"required"`
+}
+
+type VendorDocumentRepository interface {
+	// ForTenant returns the repository scoped to a tenant's documents, and
+	// SystemScope the one spanning every tenant. A repository that is
+	// neither matches nothing.
+	ForTenant(tenantID int64) VendorDocumentRepository
+	SystemScope() VendorDocumentRepository
+	Create(document *VendorDocument) error
+	// ClaimExpiring marks up to limit documents expiring before
+	// expiresBefore reminded and returns them. Documents already reminded
+	// or locked by another transaction are skipped.
+	ClaimExpiring(expiresBefore time.Time, limit int) ([]VendorDocument, error)
+}
+
+type VendorDocumentUsecase interface {
+	// Create records a document of the actor, as its vendor.
+	Create(request VendorDocumentRequest, actor Actor) (*VendorDocument, error)
+	// RemindExpiring reminds vendors, in every tenant, of their documents
+	// expiring within the given time.
+	RemindExpiring(within time.Duration) (int, error)
+}
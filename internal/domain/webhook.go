@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+)
+
+// Webhook events.
+const (
+	EventVendorApproved       = "vendor.approved"
+	EventVendorRejected       = "vendor.rejected"
+	EventProductCreated       = "product.created"
+	EventProductUpdated       = "product.updated"
+	EventPurchaseOrderIssued  = "purchase_order.issued"
+	EventPurchaseOrderShipped = "purchase_order.shipped"
+)
+
+// WebhookEvents lists the events a subscription may ask for.
+var WebhookEvents = []string{
+	EventVendorApproved,
+	EventVendorRejected,
+	EventProductCreated,
+	EventProductUpdated,
+	EventPurchaseOrderIssued,
+	EventPurchaseOrderShipped,
+}
+
+// Webhook delivery statuses. Pending deliveries are retried with exponential
+// backoff until they succeed or run out of attempts.
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+type WebhookSubscription struct {
+	ID     int64    `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+	// Secret signs the payloads. It is only returned when the subscription
+	// is created.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedBy *int64    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WebhookSubscriptionRequest struct {
+	URL    string   `json:"url" validate:"required,url"`
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=vendor.approved vendor.rejected product.created product.updated purchase_order.issued purchase_order.shipped"`
+	Active *bool    `json:"active"`
+}
+
+// WebhookDelivery is one event sent, or to be sent, to one subscription.
+type WebhookDelivery struct {
+	ID             int64      `json:"id"`
+	SubscriptionID int64      `json:"subscription_id"`
+	EventType      string     `json:"event_type"`
+	Payload        []byte     `json:"-"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastStatusCode *int       `json:"last_status_code"`
+	LastError      string     `json:"last_error"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	RedeliveryOf   *int64     `json:"redelivery_of,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// WebhookAttempt is the outcome of one delivery attempt.
+type WebhookAttempt struct {
+	Status        string
+	NextAttemptAt time.Time
+	StatusCode    *int
+	Error         string
+	DeliveredAt   *time.Time
+}
+
+type WebhookRepository interface {
+	CreateSubscription(subscription *WebhookSubscription) error
+	GetSubscriptionByID(id int64) (*WebhookSubscription, error)
+	GetSubscriptions(afterID *int64, limit int) ([]WebhookSubscription, error)
+	CountSubscriptions() (int64, error)
+	// UpdateSubscription and DeleteSubscription report false when the
+	// subscription does not exist.
+	UpdateSubscription(subscription *WebhookSubscription) (bool, error)
+	DeleteSubscription(id int64) (bool, error)
+	// Enqueue creates a pending delivery of payload for every active
+	// subscription to event.
+	Enqueue(event string, payload []byte) (int64, error)
+	Redeliver(deliveryID int64) (*WebhookDelivery, error)
+	GetDeliveryByID(id int64) (*WebhookDelivery, error)
+	GetDeliveries(subscriptionID int64, afterID *int64, limit int) ([]WebhookDelivery, error)
+	CountDeliveries(subscriptionID int64) (int64, error)
+	// ClaimDue leases up to limit pending deliveries whose next attempt is
+	// due, so concurrent dispatchers do not send them twice.
+	ClaimDue(limit int, lease time.Duration) ([]WebhookDelivery, error)
+	RecordAttempt(id int64, attempt WebhookAttempt) error
+}
+
+type WebhookUsecase interface {
+	// Publish handles JobWebhookPublish jobs.
+	Publish(job WebhookEventJob) error
+	CreateSubscription(request WebhookSubscriptionRequest, createdBy int64) (*WebhookSubscription, error)
+	GetSubscription(id int64) (*WebhookSubscription, error)
+	GetSubscriptions(page pagination.Params) (*pagination.Page[WebhookSubscription], error)
+	UpdateSubscription(id int64, request WebhookSubscriptionRequest) (*WebhookSubscription, error)
+	DeleteSubscription(id int64) error
+	GetDeliveries(subscriptionID int64, page pagination.Params) (*pagination.Page[WebhookDelivery], error)
+	Redeliver(deliveryID int64) (*WebhookDelivery, error)
+	// DispatchDue sends the deliveries that are due and returns how many
+	// were attempted.
+	DispatchDue() (int, error)
+}
@@ -0,0 +1,46 @@
+package file
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+)
+
+type auditCheckpointExporter struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewAuditCheckpointExporter appends checkpoints to the file at path as
+// JSON lines. Point it at storage the database administrators cannot
+// rewrite, such as a mounted write-once volume.
+func NewAuditCheckpointExporter(path string) domain.AuditCheckpointExporter {
+	return &auditCheckpointExporter{path: path}
+}
+
+// Export implements domain.AuditCheckpointExporter.
+func (e *auditCheckpointExporter) Export(checkpoint domain.AuditCheckpoint) error {
+	line, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	f, err := os.OpenFile(e.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+)
+
+// pruneInterval is how often full buckets are forgotten.
+const pruneInterval = time.Minute
+
+type rateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]time.Time
+	lastPrune time.Time
+}
+
+// NewRateLimitStore keeps rate limit buckets in this process, so each
+// replica counts on its own.
+func NewRateLimitStore() domain.RateLimitStore {
+	return &rateLimitStore{buckets: make(map[string]time.Time)}
+}
+
+// Take implements domain.RateLimitStore.
+func (s *rateLimitStore) Take(key string, limit domain.RateLimit, now time.Time) (domain.RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastPrune) >= pruneInterval {
+		s.prune(now)
+	}
+
+	fullAt, result := limit.Take(s.buckets[key], now)
+	s.buckets[key] = fullAt
+	return result, nil
+}
+
+// Prune implements domain.RateLimitStore.
+func (s *rateLimitStore) Prune(now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.prune(now), nil
+}
+
+func (s *rateLimitStore) prune(now time.Time) int64 {
+	var count int64
+	for key, fullAt := range s.buckets {
+		if !fullAt.After(now) {
+			delete(s.buckets, key)
+			count++
+		}
+	}
+	s.lastPrune = now
+	return count
+}
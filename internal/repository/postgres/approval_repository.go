@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+)
+
+type approvalRepository struct {
+	q     *postgres.Queries
+	scope tenantScope
+}
+
+// ForTenant implements domain.ApprovalRepository.
+func (a *approvalRepository) ForTenant(tenantID int64) domain.ApprovalRepository {
+	return &approvalRepository{q: a.q, scope: tenantScope{tenantID: tenantID}}
+}
+
+// SystemScope implements domain.ApprovalRepository.
+func (a *approvalRepository) SystemScope() domain.ApprovalRepository {
+	return &approvalRepository{q: a.q, scope: tenantScope{system: true}}
+}
+
+// Create implements domain.ApprovalRepository.
+func (a *approvalRepository) Create(approval *domain.Approval) error {
+	ctx := context.Background()
+	created, err := a.q.CreateApproval(ctx, postgres.CreateApprovalParams{
+		TenantID:        createTenant(a.scope, approval.TenantID),
+		PurchaseOrderID: int32(approval.PurchaseOrderID),
+		ApproverID:      int32(approval.ApproverID),
+	})
+	if err != nil {
+		return err
+	}
+	*approval = toDomainApproval(created)
+	return nil
+}
+
+// GetByID implements domain.ApprovalRepository.
+func (a *approvalRepository) GetByID(id int64) (*domain.Approval, error) {
+	ctx := context.Background()
+	row, err := a.q.GetApprovalByID(ctx, postgres.GetApprovalByIDParams{
+		ID:       int32(id),
+		TenantID: tenantArg(a.scope),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	approval := toDomainApproval(row)
+	return &approval, nil
+}
+
+// Decide implements domain.ApprovalRepository.
+func (a *approvalRepository) Decide(id int64, status string) (*domain.Approval, error) {
+	ctx := context.Background()
+	row, err := a.q.DecideApproval(ctx, postgres.DecideApprovalParams{
+		Status:   status,
+		ID:       int32(id),
+		TenantID: tenantArg(a.scope),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	approval := toDomainApproval(row)
+	return &approval, nil
+}
+
+// EscalateIdle implements domain.ApprovalRepository.
+func (a *approvalRepository) EscalateIdle(idleBefore time.Time, limit int) ([]domain.Approval, error) {
+	ctx := context.Background()
+	rows, err := a.q.EscalateIdleApprovals(ctx, postgres.EscalateIdleApprovalsParams{
+		IdleBefore: pgtype.Timestamptz{Time: idleBefore, Valid: true},
+		TenantID:   tenantArg(a.scope),
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	approvals := make([]domain.Approval, len(rows))
+	for i, row := range rows {
+		approvals[i] = toDomainApproval(row)
+	}
+	return approvals, nil
+}
+
+func toDomainApproval(row postgres.Approval) domain.Approval {
+	approval := domain.Approval{
+		ID:              int64(row.ID),
+		PurchaseOrderID: int64(row.PurchaseOrderID),
+		ApproverID:      int64(row.ApproverID),
+		Status:          row.Status,
+		IdleSince:       row.IdleSince.Time,
+		TenantID:        int64(row.TenantID),
+		CreatedAt:       row.CreatedAt.Time,
+		UpdatedAt:       row.UpdatedAt.Time,
+	}
+	if row.EscalatedAt.Valid {
+		escalatedAt := row.EscalatedAt.Time
+		approval.EscalatedAt = &escalatedAt
+	}
+	return approval
+}
@@ -0,0 +1,222 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type auditRepository struct {
+	q     *postgres.Queries
+	scope tenantScope
+}
+
+func NewAuditRepository(db *pgxpool.Pool) domain.AuditRepository {
+	return &auditRepository{q: postgres.New(db)}
+}
+
+// ForTenant implements domain.AuditRepository.
+func (a *auditRepository) ForTenant(tenantID int64) domain.AuditRepository {
+	return &auditRepository{q: a.q, scope: tenantScope{tenantID: tenantID}}
+}
+
+// SystemScope implements domain.AuditRepository.
+func (a *auditRepository) SystemScope() domain.AuditRepository {
+	return &auditRepository{q: a.q, scope: tenantScope{system: true}}
+}
+
+// Record implements domain.AuditRepository.
+func (a *auditRepository) Record(event *domain.AuditEvent) error {
+	ctx := context.Background()
+	params := postgres.CreateAuditEventParams{
+		ActorRole:  event.ActorRole,
+		Ip:         event.IP,
+		RequestID:  event.RequestID,
+		Action:     event.Action,
+		EntityType: event.EntityType,
+		EntityID:   int32(event.EntityID),
+		Before:     event.Before,
+		After:      event.After,
+		Diff:       event.Diff,
+	}
+	if event.ActorID != nil {
+		params.ActorID = pgtype.Int4{Int32: int32(*event.ActorID), Valid: true}
+	}
+	if event.TenantID != nil {
+		params.TenantID = pgtype.Int4{Int32: int32(*event.TenantID), Valid: true}
+	}
+
+	created, err := a.q.CreateAuditEvent(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	*event = toDomainAuditEvent(created)
+	return nil
+}
+
+// GetEvents implements domain.AuditRepository. Events are returned newest
+// first.
+func (a *auditRepository) GetEvents(filter domain.AuditFilter, afterID *int64, limit int) ([]domain.AuditEvent, error) {
+	ctx := context.Background()
+	actorID, entityType, entityID, from, to := toAuditFilterParams(filter)
+	params := postgres.GetAuditEventsParams{
+		ActorID:     actorID,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		CreatedFrom: from,
+		CreatedTo:   to,
+		TenantID:    tenantArg(a.scope),
+		Limit:       int32(limit),
+	}
+	if afterID != nil {
+		params.CursorID = pgtype.Int8{Int64: *afterID, Valid: true}
+	}
+
+	events, err := a.q.GetAuditEvents(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	domainEvents := make([]domain.AuditEvent, len(events))
+	for i, event := range events {
+		domainEvents[i] = toDomainAuditEvent(event)
+	}
+
+	return domainEvents, nil
+}
+
+// CountEvents implements domain.AuditRepository.
+func (a *auditRepository) CountEvents(filter domain.AuditFilter) (int64, error) {
+	ctx := context.Background()
+	actorID, entityType, entityID, from, to := toAuditFilterParams(filter)
+	return a.q.CountAuditEvents(ctx, postgres.CountAuditEventsParams{
+		ActorID:     actorID,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		CreatedFrom: from,
+		CreatedTo:   to,
+		TenantID:    tenantArg(a.scope),
+	})
+}
+
+// GetChain implements domain.AuditRepository.
+func (a *auditRepository) GetChain(afterSeq int64, limit int) ([]domain.AuditChainLink, error) {
+	ctx := context.Background()
+	rows, err := a.q.GetAuditChain(ctx, postgres.GetAuditChainParams{
+		AfterSeq: afterSeq,
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]domain.AuditChainLink, len(rows))
+	for i, row := range rows {
+		links[i] = domain.AuditChainLink{
+			ID:           row.ID,
+			Seq:          row.Seq,
+			PrevHash:     row.PrevHash,
+			Hash:         row.Hash,
+			ComputedHash: row.ComputedHash,
+		}
+	}
+
+	return links, nil
+}
+
+// CreateCheckpoint implements domain.AuditRepository.
+func (a *auditRepository) CreateCheckpoint(checkpoint *domain.AuditCheckpoint) error {
+	ctx := context.Background()
+	created, err := a.q.CreateAuditCheckpoint(ctx, postgres.CreateAuditCheckpointParams{
+		Seq:       checkpoint.Seq,
+		EventID:   checkpoint.EventID,
+		Hash:      checkpoint.Hash,
+		KeyID:     checkpoint.KeyID,
+		Signature: checkpoint.Signature,
+		CreatedAt: pgtype.Timestamptz{Time: checkpoint.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+
+	*checkpoint = toDomainAuditCheckpoint(created)
+	return nil
+}
+
+// GetCheckpoints implements domain.AuditRepository.
+func (a *auditRepository) GetCheckpoints() ([]domain.AuditCheckpoint, error) {
+	ctx := context.Background()
+	checkpoints, err := a.q.GetAuditCheckpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	domainCheckpoints := make([]domain.AuditCheckpoint, len(checkpoints))
+	for i, checkpoint := range checkpoints {
+		domainCheckpoints[i] = toDomainAuditCheckpoint(checkpoint)
+	}
+
+	return domainCheckpoints, nil
+}
+
+func toAuditFilterParams(filter domain.AuditFilter) (actorID pgtype.Int4, entityType pgtype.Text, entityID pgtype.Int4, from, to pgtype.Timestamptz) {
+	if filter.ActorID != nil {
+		actorID = pgtype.Int4{Int32: int32(*filter.ActorID), Valid: true}
+	}
+	entityType = pgtype.Text{String: filter.EntityType, Valid: filter.EntityType != ""}
+	if filter.EntityID != nil {
+		entityID = pgtype.Int4{Int32: int32(*filter.EntityID), Valid: true}
+	}
+	if filter.From != nil {
+		from = pgtype.Timestamptz{Time: *filter.From, Valid: true}
+	}
+	if filter.To != nil {
+		to = pgtype.Timestamptz{Time: *filter.To, Valid: true}
+	}
+	return
+}
+
+func toDomainAuditEvent(event postgres.AuditEvent) domain.AuditEvent {
+	domainEvent := domain.AuditEvent{
+		ID:         event.ID,
+		ActorRole:  event.ActorRole,
+		IP:         event.Ip,
+		RequestID:  event.RequestID,
+		Action:     event.Action,
+		EntityType: event.EntityType,
+		EntityID:   int64(event.EntityID),
+		Before:     event.Before,
+		After:      event.After,
+		Diff:       event.Diff,
+		CreatedAt:  event.CreatedAt.Time,
+		Seq:        event.Seq,
+		PrevHash:   event.PrevHash,
+		Hash:       event.Hash,
+	}
+	if event.ActorID.Valid {
+		actorID := int64(event.ActorID.Int32)
+		domainEvent.ActorID = &actorID
+	}
+	if event.TenantID.Valid {
+		tenantID := int64(event.TenantID.Int32)
+		domainEvent.TenantID = &tenantID
+	}
+	return domainEvent
+}
+
+func toDomainAuditCheckpoint(checkpoint postgres.AuditCheckpoint) domain.AuditCheckpoint {
+	return domain.AuditCheckpoint{
+		ID:        checkpoint.ID,
+		Seq:       checkpoint.Seq,
+		EventID:   checkpoint.EventID,
+		Hash:      checkpoint.Hash,
+		KeyID:     checkpoint.KeyID,
+		Signature: checkpoint.Signature,
+		CreatedAt: checkpoint.CreatedAt.Time.UTC(),
+	}
+}
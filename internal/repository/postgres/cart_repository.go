@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type cartRepository struct {
+	db database
+	q  *postgres.Queries
+}
+
+func NewCartRepository(db *pgxpool.Pool) domain.CartRepository {
+	return &cartRepository{db: db, q: postgres.New(db)}
+}
+
+// GetItems implements domain.CartRepository.
+func (c *cartRepository) GetItems(userID int64) ([]domain.CartItem, error) {
+	ctx := context.Background()
+	rows, err := c.q.GetCartItems(ctx, int32(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.CartItem, len(rows))
+	for i, row := range rows {
+		items[i] = toDomainCartItem(postgres.LockCartItemsRow(row))
+	}
+
+	return items, nil
+}
+
+// AddItem implements domain.CartRepository. Adding a product that is already
+// in the cart increases its quantity.
+func (c *cartRepository) AddItem(userID, productID int64, quantity int) error {
+	ctx := context.Background()
+	_, err := c.q.AddCartItem(ctx, postgres.AddCartItemParams{
+		UserID:    int32(userID),
+		ProductID: int32(productID),
+		Quantity:  int32(quantity),
+	})
+
+	return err
+}
+
+// UpdateItemQuantity implements domain.CartRepository. It reports false when
+// the product is not in the cart.
+func (c *cartRepository) UpdateItemQuantity(userID, productID int64, quantity int) (bool, error) {
+	ctx := context.Background()
+	rows, err := c.q.UpdateCartItemQuantity(ctx, postgres.UpdateCartItemQuantityParams{
+		UserID:    int32(userID),
+		ProductID: int32(productID),
+		Quantity:  int32(quantity),
+	})
+
+	return rows > 0, err
+}
+
+// RemoveItem implements domain.CartRepository. It reports false when the
+// product is not in the cart.
+func (c *cartRepository) RemoveItem(userID, productID int64) (bool, error) {
+	ctx := context.Background()
+	rows, err := c.q.RemoveCartItem(ctx, postgres.RemoveCartItemParams{
+		UserID:    int32(userID),
+		ProductID: int32(productID),
+	})
+
+	return rows > 0, err
+}
+
+// Clear implements domain.CartRepository.
+func (c *cartRepository) Clear(userID int64) error {
+	ctx := context.Background()
+	return c.q.ClearCart(ctx, int32(userID))
+}
+
+// Checkout implements domain.CartRepository.
+func (c *cartRepository) Checkout(userID int64, plan domain.CheckoutPlanner) ([]domain.PurchaseOrder, error) {
+	ctx := context.Background()
+	tx, err := c.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := c.q.WithTx(tx)
+
+	// Lock the cart rows and their products so available stock cannot change
+	// between validation and reservation.
+	rows, err := qtx.LockCartItems(ctx, int32(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.CartItem, len(rows))
+	for i, row := range rows {
+		items[i] = toDomainCartItem(row)
+	}
+
+	orders, err := plan(items)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range orders {
+		order := &orders[i]
+		params := postgres.CreatePurchaseOrderParams{
+			TenantID:    int32(order.TenantID),
+			BuyerID:     int32(order.BuyerID),
+			VendorID:    int32(order.VendorID),
+			Status:      order.Status,
+			TotalAmount: order.TotalAmount,
+		}
+		if order.ExpiresAt != nil {
+			params.ExpiresAt = pgtype.Timestamptz{Time: *order.ExpiresAt, Valid: true}
+		}
+		if order.OrganizationID != nil {
+			params.OrganizationID = pgtype.Int4{Int32: int32(*order.OrganizationID), Valid: true}
+		}
+		if order.DepartmentID != nil {
+			params.DepartmentID = pgtype.Int4{Int32: int32(*order.DepartmentID), Valid: true}
+		}
+
+		created, err := qtx.CreatePurchaseOrder(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		order.ID = int64(created.ID)
+		order.CreatedAt = created.CreatedAt.Time
+		order.UpdatedAt = created.UpdatedAt.Time
+
+		for j := range order.Items {
+			item := &order.Items[j]
+			createdItem, err := qtx.CreatePurchaseOrderItem(ctx, postgres.CreatePurchaseOrderItemParams{
+				PurchaseOrderID: created.ID,
+				ProductID:       pgtype.Int4{Int32: int32(item.ProductID), Valid: true},
+				ProductName:     item.ProductName,
+				UnitPrice:       item.UnitPrice,
+				Quantity:        int32(item.Quantity),
+			})
+			if err != nil {
+				return nil, err
+			}
+			item.ID = int64(createdItem.ID)
+			item.PurchaseOrderID = order.ID
+
+			if err := reserveStock(ctx, qtx, order.TenantID, item.ProductID, order.ID, item.Quantity, &userID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := qtx.ClearCart(ctx, int32(userID)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func toDomainCartItem(row postgres.LockCartItemsRow) domain.CartItem {
+	return domain.CartItem{
+		ID:               int64(row.ID),
+		ProductID:        int64(row.ProductID),
+		ProductName:      row.ProductName,
+		VendorID:         int64(row.VendorID),
+		VendorName:       row.VendorName.String,
+		UnitPrice:        row.Price,
+		Quantity:         int(row.Quantity),
+		Subtotal:         int64(row.Price) * int64(row.Quantity),
+		AvailableStock:   int(row.Stock - row.ReservedStock),
+		MinOrderQuantity: int(row.MinOrderQuantity),
+		CreatedAt:        row.CreatedAt.Time,
+		UpdatedAt:        row.UpdatedAt.Time,
+	}
+}
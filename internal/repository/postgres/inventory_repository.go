@@ -0,0 +1,300 @@
+package postgres
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type inventoryRepository struct {
+	db    *pgxpool.Pool
+	q     *postgres.Queries
+	scope tenantScope
+}
+
+func NewInventoryRepository(db *pgxpool.Pool) domain.InventoryRepository {
+	return &inventoryRepository{db: db, q: postgres.New(db)}
+}
+
+// ForTenant implements domain.InventoryRepository.
+func (i *inventoryRepository) ForTenant(tenantID int64) domain.InventoryRepository {
+	return &inventoryRepository{db: i.db, q: i.q, scope: tenantScope{tenantID: tenantID}}
+}
+
+// GetBalance implements domain.InventoryRepository. The balance is summed
+// from the ledger rather than read from the product's stock columns.
+func (i *inventoryRepository) GetBalance(productID int64) (*domain.InventoryBalance, error) {
+	ctx := context.Background()
+	balance, err := i.q.GetInventoryBalance(ctx, postgres.GetInventoryBalanceParams{
+		ProductID: int32(productID),
+		TenantID:  tenantArg(i.scope),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.InventoryBalance{
+		ProductID: productID,
+		OnHand:    int(balance.OnHand),
+		Reserved:  int(balance.Reserved),
+		Available: int(balance.OnHand - balance.Reserved),
+	}, nil
+}
+
+// GetMovements implements domain.InventoryRepository.
+func (i *inventoryRepository) GetMovements(productID int64, afterID *int64, limit int) ([]domain.InventoryMovement, error) {
+	ctx := context.Background()
+	params := postgres.GetInventoryMovementsParams{
+		ProductID: int32(productID),
+		TenantID:  tenantArg(i.scope),
+		Limit:     int32(limit),
+	}
+	if afterID != nil {
+		params.CursorID = pgtype.Int4{Int32: int32(*afterID), Valid: true}
+	}
+
+	movements, err := i.q.GetInventoryMovements(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	domainMovements := make([]domain.InventoryMovement, len(movements))
+	for j, movement := range movements {
+		domainMovements[j] = toDomainInventoryMovement(movement)
+	}
+
+	return domainMovements, nil
+}
+
+// CountMovements implements domain.InventoryRepository.
+func (i *inventoryRepository) CountMovements(productID int64) (int64, error) {
+	ctx := context.Background()
+	return i.q.CountInventoryMovements(ctx, postgres.CountInventoryMovementsParams{
+		ProductID: int32(productID),
+		TenantID:  tenantArg(i.scope),
+	})
+}
+
+// Record implements domain.InventoryRepository.
+func (i *inventoryRepository) Record(movement *domain.InventoryMovement) error {
+	ctx := context.Background()
+	tx, err := i.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := recordMovement(ctx, i.q.WithTx(tx), tenantArg(i.scope), movement); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// recordMovement locks the product, appends movement to the ledger and
+// applies its deltas to products.stock and products.reserved_stock. The
+// product must be in tenantID, the tenant_id argument of the caller's
+// scope. q must be bound to a transaction so the lock is held until it
+// ends.
+func recordMovement(ctx context.Context, q *postgres.Queries, tenantID pgtype.Int4, movement *domain.InventoryMovement) error {
+	product, err := q.LockProduct(ctx, postgres.LockProductParams{
+		ID:       int32(movement.ProductID),
+		TenantID: tenantID,
+	})
+	if err != nil {
+		return err
+	}
+
+	onHand := int(product.Stock) + movement.OnHandDelta
+	reserved := int(product.ReservedStock) + movement.ReservedDelta
+	if onHand < 0 || reserved < 0 || reserved > onHand {
+		return errors.ErrInsufficientStock
+	}
+
+	params := postgres.CreateInventoryMovementParams{
+		ProductID:     int32(movement.ProductID),
+		MovementType:  movement.MovementType,
+		Quantity:      int32(movement.Quantity),
+		OnHandDelta:   int32(movement.OnHandDelta),
+		ReservedDelta: int32(movement.ReservedDelta),
+		Note:          movement.Note,
+	}
+	if movement.ReservationID != nil {
+		params.ReservationID = pgtype.Int4{Int32: int32(*movement.ReservationID), Valid: true}
+	}
+	if movement.CreatedBy != nil {
+		params.CreatedBy = pgtype.Int4{Int32: int32(*movement.CreatedBy), Valid: true}
+	}
+
+	created, err := q.CreateInventoryMovement(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	if err := q.ApplyInventoryDelta(ctx, postgres.ApplyInventoryDeltaParams{
+		ID:            int32(movement.ProductID),
+		OnHandDelta:   int32(movement.OnHandDelta),
+		ReservedDelta: int32(movement.ReservedDelta),
+	}); err != nil {
+		return err
+	}
+
+	before := int(product.Stock - product.ReservedStock)
+	if err := raiseStockAlerts(ctx, q, product, before, onHand-reserved); err != nil {
+		return err
+	}
+
+	*movement = toDomainInventoryMovement(created)
+	return nil
+}
+
+// raiseStockAlerts records the alerts for a change of available stock from
+// before to after, and enqueues their notifications, in the same
+// transaction as the movement that caused it. Low-stock and out-of-stock
+// alerts go to the vendor while they hold inventory:manage; back-in-stock
+// alerts go to the product's subscribers.
+func raiseStockAlerts(ctx context.Context, q *postgres.Queries, product postgres.Product, before, after int) error {
+	var threshold *int
+	if product.LowStockThreshold.Valid {
+		value := int(product.LowStockThreshold.Int32)
+		threshold = &value
+	}
+
+	for _, alertType := range domain.StockAlertTypes(before, after, threshold) {
+		var recipients []int64
+		if alertType == domain.StockAlertBackInStock {
+			subscribers, err := q.CreateSubscriberStockAlerts(ctx, postgres.CreateSubscriberStockAlertsParams{
+				ProductID: product.ID,
+				AlertType: alertType,
+				Available: int32(after),
+			})
+			if err != nil {
+				return err
+			}
+			for _, userID := range subscribers {
+				recipients = append(recipients, int64(userID))
+			}
+		} else {
+			err := q.CreateStockAlert(ctx, postgres.CreateStockAlertParams{
+				UserID:    product.VendorID,
+				ProductID: product.ID,
+				AlertType: alertType,
+				Available: int32(after),
+			})
+			if err != nil {
+				return err
+			}
+			permissions, err := q.GetUserPermissions(ctx, product.VendorID)
+			if err != nil {
+				return err
+			}
+			if slices.Contains(permissions, domain.PermissionInventoryManage) {
+				recipients = append(recipients, int64(product.VendorID))
+			}
+		}
+
+		jobs, err := domain.NewStockAlertNotificationJobs(alertType, int64(product.ID), product.Name, after, recipients)
+		if err != nil {
+			return err
+		}
+		if err := enqueueJobs(ctx, q, jobs...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reserveStock holds quantity of a product for a purchase order of
+// tenantID.
+func reserveStock(ctx context.Context, q *postgres.Queries, tenantID, productID, purchaseOrderID int64, quantity int, by *int64) error {
+	reservation, err := q.CreateStockReservation(ctx, postgres.CreateStockReservationParams{
+		ProductID:       int32(productID),
+		PurchaseOrderID: int32(purchaseOrderID),
+		Quantity:        int32(quantity),
+		Status:          domain.ReservationActive,
+	})
+	if err != nil {
+		return err
+	}
+
+	reservationID := int64(reservation.ID)
+	return recordMovement(ctx, q, pgtype.Int4{Int32: int32(tenantID), Valid: true}, &domain.InventoryMovement{
+		ProductID:     productID,
+		MovementType:  domain.MovementReservation,
+		Quantity:      quantity,
+		ReservedDelta: quantity,
+		ReservationID: &reservationID,
+		CreatedBy:     by,
+	})
+}
+
+// settleReservations closes the active reservations of a purchase order of
+// tenantID. Releasing returns the stock to the available pool; fulfilling
+// ships it.
+func settleReservations(ctx context.Context, q *postgres.Queries, tenantID, purchaseOrderID int64, status string, by *int64) error {
+	reservations, err := q.LockStockReservations(ctx, postgres.LockStockReservationsParams{
+		PurchaseOrderID: int32(purchaseOrderID),
+		Status:          domain.ReservationActive,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, reservation := range reservations {
+		reservationID := int64(reservation.ID)
+		movement := domain.InventoryMovement{
+			ProductID:     int64(reservation.ProductID),
+			MovementType:  domain.MovementRelease,
+			Quantity:      int(reservation.Quantity),
+			ReservedDelta: -int(reservation.Quantity),
+			ReservationID: &reservationID,
+			CreatedBy:     by,
+		}
+		if status == domain.ReservationFulfilled {
+			movement.MovementType = domain.MovementShipment
+			movement.OnHandDelta = -int(reservation.Quantity)
+		}
+
+		if err := recordMovement(ctx, q, pgtype.Int4{Int32: int32(tenantID), Valid: true}, &movement); err != nil {
+			return err
+		}
+
+		if err := q.UpdateStockReservationStatus(ctx, postgres.UpdateStockReservationStatusParams{
+			ID:     reservation.ID,
+			Status: status,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func toDomainInventoryMovement(movement postgres.InventoryMovement) domain.InventoryMovement {
+	domainMovement := domain.InventoryMovement{
+		ID:            int64(movement.ID),
+		ProductID:     int64(movement.ProductID),
+		MovementType:  movement.MovementType,
+		Quantity:      int(movement.Quantity),
+		OnHandDelta:   int(movement.OnHandDelta),
+		ReservedDelta: int(movement.ReservedDelta),
+		Note:          movement.Note,
+		CreatedAt:     movement.CreatedAt.Time,
+	}
+	if movement.ReservationID.Valid {
+		reservationID := int64(movement.ReservationID.Int32)
+		domainMovement.ReservationID = &reservationID
+	}
+	if movement.CreatedBy.Valid {
+		createdBy := int64(movement.CreatedBy.Int32)
+		domainMovement.CreatedBy = &createdBy
+	}
+	return domainMovement
+}
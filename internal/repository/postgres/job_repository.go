@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type jobRepository struct {
+	q *postgres.Queries
+}
+
+func NewJobRepository(db *pgxpool.Pool) domain.JobRepository {
+	return &jobRepository{q: postgres.New(db)}
+}
+
+// Enqueue implements domain.JobRepository. Jobs without RunAt are due now.
+func (j *jobRepository) Enqueue(jobs ...*domain.Job) error {
+	return enqueueJobs(context.Background(), j.q, jobs...)
+}
+
+// enqueueJobs inserts jobs with q, so repositories can enqueue in the
+// transaction of the change the jobs report.
+func enqueueJobs(ctx context.Context, q *postgres.Queries, jobs ...*domain.Job) error {
+	for _, job := range jobs {
+		maxAttempts := job.MaxAttempts
+		if maxAttempts <= 0 {
+			maxAttempts = domain.DefaultJobMaxAttempts
+		}
+		runAt := job.RunAt
+		if runAt.IsZero() {
+			runAt = time.Now()
+		}
+
+		err := q.EnqueueJob(ctx, postgres.EnqueueJobParams{
+			JobType:     job.Type,
+			Payload:     job.Payload,
+			Status:      domain.JobStatusPending,
+			MaxAttempts: int32(maxAttempts),
+			RunAt:       pgtype.Timestamptz{Time: runAt, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Claim implements domain.JobRepository.
+func (j *jobRepository) Claim(lease time.Duration) (*domain.Job, error) {
+	ctx := context.Background()
+	job, err := j.q.ClaimJob(ctx, postgres.ClaimJobParams{
+		RunningStatus: domain.JobStatusRunning,
+		LeaseSeconds:  int32(lease.Seconds()),
+		PendingStatus: domain.JobStatusPending,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	domainJob := toDomainJob(job)
+	return &domainJob, nil
+}
+
+// Complete implements domain.JobRepository.
+func (j *jobRepository) Complete(id int64) error {
+	ctx := context.Background()
+	return j.q.CompleteJob(ctx, postgres.CompleteJobParams{
+		ID:     int32(id),
+		Status: domain.JobStatusSucceeded,
+	})
+}
+
+// Fail implements domain.JobRepository.
+func (j *jobRepository) Fail(id int64, status string, runAt time.Time, lastError string) error {
+	ctx := context.Background()
+	return j.q.FailJob(ctx, postgres.FailJobParams{
+		ID:        int32(id),
+		Status:    status,
+		RunAt:     pgtype.Timestamptz{Time: runAt, Valid: true},
+		LastError: lastError,
+	})
+}
+
+// GetJobs implements domain.JobRepository. Jobs are returned newest first;
+// an empty status matches every job.
+func (j *jobRepository) GetJobs(status string, afterID *int64, limit int) ([]domain.Job, error) {
+	ctx := context.Background()
+	params := postgres.GetJobsParams{
+		Status: pgtype.Text{String: status, Valid: status != ""},
+		Limit:  int32(limit),
+	}
+	if afterID != nil {
+		params.CursorID = pgtype.Int4{Int32: int32(*afterID), Valid: true}
+	}
+
+	jobs, err := j.q.GetJobs(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	domainJobs := make([]domain.Job, len(jobs))
+	for i, job := range jobs {
+		domainJobs[i] = toDomainJob(job)
+	}
+
+	return domainJobs, nil
+}
+
+// CountJobs implements domain.JobRepository.
+func (j *jobRepository) CountJobs(status string) (int64, error) {
+	ctx := context.Background()
+	return j.q.CountJobs(ctx, pgtype.Text{String: status, Valid: status != ""})
+}
+
+// Requeue implements domain.JobRepository.
+func (j *jobRepository) Requeue(id int64) (bool, error) {
+	ctx := context.Background()
+	rows, err := j.q.RequeueJob(ctx, postgres.RequeueJobParams{
+		PendingStatus: domain.JobStatusPending,
+		ID:            int32(id),
+		DeadStatus:    domain.JobStatusDead,
+	})
+
+	return rows > 0, err
+}
+
+// DeleteSucceeded implements domain.JobRepository.
+func (j *jobRepository) DeleteSucceeded(before time.Time) (int64, error) {
+	ctx := context.Background()
+	return j.q.DeleteFinishedJobs(ctx, postgres.DeleteFinishedJobsParams{
+		Status:      domain.JobStatusSucceeded,
+		CompletedAt: pgtype.Timestamptz{Time: before, Valid: true},
+	})
+}
+
+func toDomainJob(job postgres.Job) domain.Job {
+	domainJob := domain.Job{
+		ID:          int64(job.ID),
+		Type:        job.JobType,
+		Payload:     job.Payload,
+		Status:      job.Status,
+		Attempts:    int(job.Attempts),
+		MaxAttempts: int(job.MaxAttempts),
+		RunAt:       job.RunAt.Time,
+		LastError:   job.LastError,
+		CreatedAt:   job.CreatedAt.Time,
+		UpdatedAt:   job.UpdatedAt.Time,
+	}
+	if job.CompletedAt.Valid {
+		completedAt := job.CompletedAt.Time
+		domainJob.CompletedAt = &completedAt
+	}
+	return domainJob
+}
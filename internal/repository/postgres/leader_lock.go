@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// leaderLock implements domain.LeaderLock with session-level Postgres
+// advisory locks. Advisory locks belong to the connection that took them,
+// so they are held on one dedicated connection outside the pool; if that
+// connection drops, the server releases them and another replica can take
+// over.
+type leaderLock struct {
+	config *pgx.ConnConfig
+
+	mu   sync.Mutex
+	conn *pgx.Conn
+	q    *postgres.Queries
+	held map[string]bool
+}
+
+func NewLeaderLock(db *pgxpool.Pool) domain.LeaderLock {
+	return &leaderLock{config: db.Config().ConnConfig.Copy()}
+}
+
+// TryAcquire implements domain.LeaderLock.
+func (l *leaderLock) TryAcquire(name string) (bool, error) {
+	ctx := context.Background()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.connect(ctx); err != nil {
+		return false, err
+	}
+	if l.held[name] {
+		return true, nil
+	}
+
+	acquired, err := l.q.TryAdvisoryLock(ctx, lockKey(name))
+	if err != nil {
+		return false, err
+	}
+	if acquired {
+		l.held[name] = true
+	}
+	return acquired, nil
+}
+
+// Release implements domain.LeaderLock.
+func (l *leaderLock) Release(name string) error {
+	ctx := context.Background()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil || !l.held[name] {
+		return nil
+	}
+
+	delete(l.held, name)
+	_, err := l.q.AdvisoryUnlock(ctx, lockKey(name))
+	return err
+}
+
+// Close implements domain.LeaderLock. Closing the session releases its
+// locks.
+func (l *leaderLock) Close() error {
+	ctx := context.Background()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+
+	err := l.conn.Close(ctx)
+	l.conn, l.q, l.held = nil, nil, nil
+	return err
+}
+
+// connect makes sure the lock session is alive, opening a new one when it
+// is not. Locks held by a lost session are forgotten because the server
+// has already released them.
+func (l *leaderLock) connect(ctx context.Context) error {
+	if l.conn != nil {
+		if err := l.conn.Ping(ctx); err == nil {
+			return nil
+		}
+		l.conn.Close(ctx)
+		l.conn, l.q, l.held = nil, nil, nil
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, l.config)
+	if err != nil {
+		return err
+	}
+
+	l.conn, l.q, l.held = conn, postgres.New(conn), make(map[string]bool)
+	return nil
+}
+
+// lockKey maps a lock name to an advisory lock key.
+func lockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("scheduler:" + name))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type loginAttemptRepository struct {
+	q *postgres.Queries
+}
+
+func NewLoginAttemptRepository(db *pgxpool.Pool) domain.LoginAttemptRepository {
+	return &loginAttemptRepository{q: postgres.New(db)}
+}
+
+// Record implements domain.LoginAttemptRepository.
+func (l *loginAttemptRepository) Record(attempt *domain.LoginAttempt) error {
+	ctx := context.Background()
+	params := postgres.CreateLoginAttemptParams{
+		Email:     attempt.Email,
+		Ip:        attempt.IP,
+		UserAgent: attempt.UserAgent,
+		Success:   attempt.Success,
+		Reason:    attempt.Reason,
+	}
+	if attempt.UserID != nil {
+		params.UserID = pgtype.Int4{Int32: int32(*attempt.UserID), Valid: true}
+	}
+	return l.q.CreateLoginAttempt(ctx, params)
+}
+
+// CountRecentFailuresByIP implements domain.LoginAttemptRepository.
+func (l *loginAttemptRepository) CountRecentFailuresByIP(ip string, since time.Time) (int64, time.Time, error) {
+	ctx := context.Background()
+	row, err := l.q.CountRecentFailedLoginsByIP(ctx, postgres.CountRecentFailedLoginsByIPParams{
+		Ip:        ip,
+		CreatedAt: pgtype.Timestamptz{Time: since, Valid: true},
+	})
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return row.Count, row.Oldest.Time, nil
+}
+
+// GetAll implements domain.LoginAttemptRepository. Attempts are returned
+// newest first.
+func (l *loginAttemptRepository) GetAll(filter domain.LoginAttemptFilter, afterID *int64, limit int) ([]domain.LoginAttempt, error) {
+	ctx := context.Background()
+	userID, email, ip, success, from, to := toLoginAttemptFilterParams(filter)
+	params := postgres.GetLoginAttemptsParams{
+		UserID:      userID,
+		Email:       email,
+		Ip:          ip,
+		Success:     success,
+		CreatedFrom: from,
+		CreatedTo:   to,
+		Limit:       int32(limit),
+	}
+	if afterID != nil {
+		params.CursorID = pgtype.Int8{Int64: *afterID, Valid: true}
+	}
+
+	attempts, err := l.q.GetLoginAttempts(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	domainAttempts := make([]domain.LoginAttempt, len(attempts))
+	for i, attempt := range attempts {
+		domainAttempts[i] = toDomainLoginAttempt(attempt)
+	}
+
+	return domainAttempts, nil
+}
+
+// Count implements domain.LoginAttemptRepository.
+func (l *loginAttemptRepository) Count(filter domain.LoginAttemptFilter) (int64, error) {
+	ctx := context.Background()
+	userID, email, ip, success, from, to := toLoginAttemptFilterParams(filter)
+	return l.q.CountLoginAttempts(ctx, postgres.CountLoginAttemptsParams{
+		UserID:      userID,
+		Email:       email,
+		Ip:          ip,
+		Success:     success,
+		CreatedFrom: from,
+		CreatedTo:   to,
+	})
+}
+
+// DeleteBefore implements domain.LoginAttemptRepository.
+func (l *loginAttemptRepository) DeleteBefore(before time.Time) (int64, error) {
+	ctx := context.Background()
+	return l.q.DeleteLoginAttemptsBefore(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+}
+
+func toLoginAttemptFilterParams(filter domain.LoginAttemptFilter) (userID pgtype.Int4, email, ip pgtype.Text, success pgtype.Bool, from, to pgtype.Timestamptz) {
+	if filter.UserID != nil {
+		userID = pgtype.Int4{Int32: int32(*filter.UserID), Valid: true}
+	}
+	email = pgtype.Text{String: filter.Email, Valid: filter.Email != ""}
+	ip = pgtype.Text{String: filter.IP, Valid: filter.IP != ""}
+	if filter.Success != nil {
+		success = pgtype.Bool{Bool: *filter.Success, Valid: true}
+	}
+	if filter.From != nil {
+		from = pgtype.Timestamptz{Time: *filter.From, Valid: true}
+	}
+	if filter.To != nil {
+		to = pgtype.Timestamptz{Time: *filter.To, Valid: true}
+	}
+	return
+}
+
+func toDomainLoginAttempt(attempt postgres.LoginAttempt) domain.LoginAttempt {
+	domainAttempt := domain.LoginAttempt{
+		ID:        attempt.ID,
+		Email:     attempt.Email,
+		IP:        attempt.Ip,
+		UserAgent: attempt.UserAgent,
+		Success:   attempt.Success,
+		Reason:    attempt.Reason,
+		CreatedAt: attempt.CreatedAt.Time,
+	}
+	if attempt.UserID.Valid {
+		userID := int64(attempt.UserID.Int32)
+		domainAttempt.UserID = &userID
+	}
+	return domainAttempt
+}
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type mfaRepository struct {
+	q *postgres.Queries
+}
+
+func NewMFARepository(db *pgxpool.Pool) domain.MFARepository {
+	return &mfaRepository{q: postgres.New(db)}
+}
+
+// Get implements domain.MFARepository.
+func (m *mfaRepository) Get(userID int64) (*domain.MFA, error) {
+	ctx := context.Background()
+	row, err := m.q.GetUserMFA(ctx, int32(userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mfa := &domain.MFA{
+		UserID:   int64(row.UserID),
+		Secret:   row.Secret,
+		LastStep: row.LastStep,
+	}
+	if row.ConfirmedAt.Valid {
+		mfa.ConfirmedAt = &row.ConfirmedAt.Time
+	}
+	return mfa, nil
+}
+
+// SavePending implements domain.MFARepository.
+func (m *mfaRepository) SavePending(userID int64, secret string) (bool, error) {
+	ctx := context.Background()
+	n, err := m.q.UpsertPendingUserMFA(ctx, postgres.UpsertPendingUserMFAParams{
+		UserID: int32(userID),
+		Secret: secret,
+	})
+	return n > 0, err
+}
+
+// Confirm implements domain.MFARepository.
+func (m *mfaRepository) Confirm(userID int64) error {
+	ctx := context.Background()
+	return m.q.ConfirmUserMFA(ctx, int32(userID))
+}
+
+// UseStep implements domain.MFARepository.
+func (m *mfaRepository) UseStep(userID int64, step int64) (bool, error) {
+	ctx := context.Background()
+	n, err := m.q.UseMFAStep(ctx, postgres.UseMFAStepParams{
+		UserID:   int32(userID),
+		LastStep: step,
+	})
+	return n > 0, err
+}
+
+// Delete implements domain.MFARepository.
+func (m *mfaRepository) Delete(userID int64) error {
+	ctx := context.Background()
+	if err := m.q.DeleteRecoveryCodes(ctx, int32(userID)); err != nil {
+		return err
+	}
+	return m.q.DeleteUserMFA(ctx, int32(userID))
+}
+
+// ReplaceRecoveryCodes implements domain.MFARepository.
+func (m *mfaRepository) ReplaceRecoveryCodes(userID int64, codeHashes []string) error {
+	ctx := context.Background()
+	if err := m.q.DeleteRecoveryCodes(ctx, int32(userID)); err != nil {
+		return err
+	}
+	for _, codeHash := range codeHashes {
+		err := m.q.CreateRecoveryCode(ctx, postgres.CreateRecoveryCodeParams{
+			UserID:   int32(userID),
+			CodeHash: codeHash,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UseRecoveryCode implements domain.MFARepository.
+func (m *mfaRepository) UseRecoveryCode(userID int64, codeHash string) (bool, error) {
+	ctx := context.Background()
+	n, err := m.q.UseRecoveryCode(ctx, postgres.UseRecoveryCodeParams{
+		UserID:   int32(userID),
+		CodeHash: codeHash,
+	})
+	return n > 0, err
+}
+
+// CountRecoveryCodes implements domain.MFARepository.
+func (m *mfaRepository) CountRecoveryCodes(userID int64) (int64, error) {
+	ctx := context.Background()
+	return m.q.CountUnusedRecoveryCodes(ctx, int32(userID))
+}
+
+// CreateChallenge implements domain.MFARepository.
+func (m *mfaRepository) CreateChallenge(userID int64, tokenHash string, expiresAt time.Time) error {
+	ctx := context.Background()
+	return m.q.CreateMFAChallenge(ctx, postgres.CreateMFAChallengeParams{
+		UserID:    int32(userID),
+		TokenHash: tokenHash,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+}
+
+// GetChallenge implements domain.MFARepository.
+func (m *mfaRepository) GetChallenge(tokenHash string) (*domain.MFAChallenge, error) {
+	ctx := context.Background()
+	row, err := m.q.GetMFAChallenge(ctx, postgres.GetMFAChallengeParams{
+		TokenHash:   tokenHash,
+		MaxAttempts: domain.MFAChallengeMaxAttempts,
+	})
+	return toDomainMFAChallenge(row, err)
+}
+
+// CountChallengeAttempt implements domain.MFARepository. The attempt is
+// counted in the same statement that checks the limit, so parallel
+// guesses cannot get past it.
+func (m *mfaRepository) CountChallengeAttempt(tokenHash string) (*domain.MFAChallenge, error) {
+	ctx := context.Background()
+	row, err := m.q.CountMFAChallengeAttempt(ctx, postgres.CountMFAChallengeAttemptParams{
+		TokenHash:   tokenHash,
+		MaxAttempts: domain.MFAChallengeMaxAttempts,
+	})
+	return toDomainMFAChallenge(row, err)
+}
+
+// UseChallenge implements domain.MFARepository.
+func (m *mfaRepository) UseChallenge(id int64) (bool, error) {
+	ctx := context.Background()
+	n, err := m.q.UseMFAChallenge(ctx, int32(id))
+	return n > 0, err
+}
+
+// DeleteExpiredChallenges implements domain.MFARepository.
+func (m *mfaRepository) DeleteExpiredChallenges(before time.Time) (int64, error) {
+	ctx := context.Background()
+	return m.q.DeleteExpiredMFAChallenges(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+}
+
+func toDomainMFAChallenge(row postgres.MfaChallenge, err error) (*domain.MFAChallenge, error) {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.MFAChallenge{
+		ID:        int64(row.ID),
+		UserID:    int64(row.UserID),
+		Attempts:  int(row.Attempts),
+		ExpiresAt: row.ExpiresAt.Time,
+	}, nil
+}
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type notificationRepository struct {
+	q *postgres.Queries
+}
+
+func NewNotificationRepository(db *pgxpool.Pool) domain.NotificationRepository {
+	return &notificationRepository{q: postgres.New(db)}
+}
+
+// Create implements domain.NotificationRepository.
+func (n *notificationRepository) Create(notification *domain.Notification) error {
+	ctx := context.Background()
+	created, err := n.q.CreateNotification(ctx, postgres.CreateNotificationParams{
+		UserID:    int32(notification.UserID),
+		EventType: notification.EventType,
+		Subject:   notification.Subject,
+		Body:      notification.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	*notification = toDomainNotification(created)
+	return nil
+}
+
+// GetByUserID implements domain.NotificationRepository. Notifications are
+// returned newest first.
+func (n *notificationRepository) GetByUserID(userID int64, unreadOnly bool, afterID *int64, limit int) ([]domain.Notification, error) {
+	ctx := context.Background()
+	params := postgres.GetNotificationsByUserIDParams{
+		UserID:     int32(userID),
+		UnreadOnly: unreadOnly,
+		Limit:      int32(limit),
+	}
+	if afterID != nil {
+		params.CursorID = pgtype.Int4{Int32: int32(*afterID), Valid: true}
+	}
+
+	notifications, err := n.q.GetNotificationsByUserID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	domainNotifications := make([]domain.Notification, len(notifications))
+	for i, notification := range notifications {
+		domainNotifications[i] = toDomainNotification(notification)
+	}
+
+	return domainNotifications, nil
+}
+
+// CountByUserID implements domain.NotificationRepository.
+func (n *notificationRepository) CountByUserID(userID int64, unreadOnly bool) (int64, error) {
+	ctx := context.Background()
+	return n.q.CountNotificationsByUserID(ctx, postgres.CountNotificationsByUserIDParams{
+		UserID:     int32(userID),
+		UnreadOnly: unreadOnly,
+	})
+}
+
+// SetRead implements domain.NotificationRepository.
+func (n *notificationRepository) SetRead(id, userID int64, read bool) (bool, error) {
+	ctx := context.Background()
+	rows, err := n.q.SetNotificationRead(ctx, postgres.SetNotificationReadParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+		Read:   read,
+	})
+
+	return rows > 0, err
+}
+
+// MarkAllRead implements domain.NotificationRepository.
+func (n *notificationRepository) MarkAllRead(userID int64) (int64, error) {
+	ctx := context.Background()
+	return n.q.MarkAllNotificationsRead(ctx, int32(userID))
+}
+
+// GetPreferences implements domain.NotificationRepository. Users who never
+// saved preferences get every channel enabled.
+func (n *notificationRepository) GetPreferences(userID int64) (*domain.NotificationPreferences, error) {
+	ctx := context.Background()
+	preferences, err := n.q.GetNotificationPreferences(ctx, int32(userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &domain.NotificationPreferences{Email: true, InApp: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.NotificationPreferences{
+		Email: preferences.EmailEnabled,
+		InApp: preferences.InAppEnabled,
+	}, nil
+}
+
+// UpdatePreferences implements domain.NotificationRepository.
+func (n *notificationRepository) UpdatePreferences(userID int64, preferences domain.NotificationPreferences) error {
+	ctx := context.Background()
+	_, err := n.q.UpsertNotificationPreferences(ctx, postgres.UpsertNotificationPreferencesParams{
+		UserID:       int32(userID),
+		EmailEnabled: preferences.Email,
+		InAppEnabled: preferences.InApp,
+	})
+
+	return err
+}
+
+func toDomainNotification(notification postgres.Notification) domain.Notification {
+	domainNotification := domain.Notification{
+		ID:        int64(notification.ID),
+		UserID:    int64(notification.UserID),
+		EventType: notification.EventType,
+		Subject:   notification.Subject,
+		Body:      notification.Body,
+		CreatedAt: notification.CreatedAt.Time,
+	}
+	if notification.ReadAt.Valid {
+		readAt := notification.ReadAt.Time
+		domainNotification.ReadAt = &readAt
+	}
+	return domainNotification
+}
@@ -0,0 +1,356 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type organizationRepository struct {
+	q     *postgres.Queries
+	scope tenantScope
+}
+
+func NewOrganizationRepository(db *pgxpool.Pool) domain.OrganizationRepository {
+	return &organizationRepository{q: postgres.New(db)}
+}
+
+// ForTenant implements domain.OrganizationRepository.
+func (o *organizationRepository) ForTenant(tenantID int64) domain.OrganizationRepository {
+	return &organizationRepository{q: o.q, scope: tenantScope{tenantID: tenantID}}
+}
+
+// SystemScope implements domain.OrganizationRepository.
+func (o *organizationRepository) SystemScope() domain.OrganizationRepository {
+	return &organizationRepository{q: o.q, scope: tenantScope{system: true}}
+}
+
+// GetAll implements domain.OrganizationRepository.
+func (o *organizationRepository) GetAll(afterID *int64, limit int) ([]domain.Organization, error) {
+	ctx := context.Background()
+	params := postgres.GetOrganizationsParams{
+		TenantID: tenantArg(o.scope),
+		Limit:    int32(limit),
+	}
+	if afterID != nil {
+		params.CursorID = pgtype.Int4{Int32: int32(*afterID), Valid: true}
+	}
+
+	rows, err := o.q.GetOrganizations(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	organizations := make([]domain.Organization, len(rows))
+	for i, row := range rows {
+		organizations[i] = toDomainOrganization(row)
+	}
+	return organizations, nil
+}
+
+// Count implements domain.OrganizationRepository.
+func (o *organizationRepository) Count() (int64, error) {
+	ctx := context.Background()
+	return o.q.CountOrganizations(ctx, tenantArg(o.scope))
+}
+
+// GetByID implements domain.OrganizationRepository.
+func (o *organizationRepository) GetByID(id int64) (*domain.Organization, error) {
+	ctx := context.Background()
+	row, err := o.q.GetOrganizationByID(ctx, postgres.GetOrganizationByIDParams{
+		ID:       int32(id),
+		TenantID: tenantArg(o.scope),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	organization := toDomainOrganization(row)
+	return &organization, nil
+}
+
+// GetByName implements domain.OrganizationRepository.
+func (o *organizationRepository) GetByName(name string) (*domain.Organization, error) {
+	ctx := context.Background()
+	row, err := o.q.GetOrganizationByName(ctx, postgres.GetOrganizationByNameParams{
+		Name:     name,
+		TenantID: tenantArg(o.scope),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	organization := toDomainOrganization(row)
+	return &organization, nil
+}
+
+// Create implements domain.OrganizationRepository.
+func (o *organizationRepository) Create(organization *domain.Organization) error {
+	ctx := context.Background()
+	created, err := o.q.CreateOrganization(ctx, postgres.CreateOrganizationParams{
+		TenantID: createTenant(o.scope, organization.TenantID),
+		Name:     organization.Name,
+	})
+	if err != nil {
+		return err
+	}
+	*organization = toDomainOrganization(created)
+	return nil
+}
+
+// Update implements domain.OrganizationRepository.
+func (o *organizationRepository) Update(organization *domain.Organization) error {
+	ctx := context.Background()
+	updated, err := o.q.UpdateOrganization(ctx, postgres.UpdateOrganizationParams{
+		ID:       int32(organization.ID),
+		Name:     organization.Name,
+		TenantID: tenantArg(o.scope),
+	})
+	if err != nil {
+		return err
+	}
+	*organization = toDomainOrganization(updated)
+	return nil
+}
+
+// Delete implements domain.OrganizationRepository. Its departments and
+// memberships go with it; purchase orders keep no reference.
+func (o *organizationRepository) Delete(id int64) error {
+	ctx := context.Background()
+	return o.q.DeleteOrganization(ctx, postgres.DeleteOrganizationParams{
+		ID:       int32(id),
+		TenantID: tenantArg(o.scope),
+	})
+}
+
+// GetDepartments implements domain.OrganizationRepository.
+func (o *organizationRepository) GetDepartments(organizationID int64) ([]domain.Department, error) {
+	ctx := context.Background()
+	rows, err := o.q.GetDepartments(ctx, postgres.GetDepartmentsParams{
+		OrganizationID: int32(organizationID),
+		TenantID:       tenantArg(o.scope),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	departments := make([]domain.Department, len(rows))
+	for i, row := range rows {
+		departments[i] = toDomainDepartment(row)
+	}
+	return departments, nil
+}
+
+// GetDepartment implements domain.OrganizationRepository.
+func (o *organizationRepository) GetDepartment(id int64) (*domain.Department, error) {
+	ctx := context.Background()
+	row, err := o.q.GetDepartmentByID(ctx, postgres.GetDepartmentByIDParams{
+		ID:       int32(id),
+		TenantID: tenantArg(o.scope),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	department := toDomainDepartment(row)
+	return &department, nil
+}
+
+// GetDepartmentByName implements domain.OrganizationRepository.
+func (o *organizationRepository) GetDepartmentByName(organizationID int64, name string) (*domain.Department, error) {
+	ctx := context.Background()
+	row, err := o.q.GetDepartmentByName(ctx, postgres.GetDepartmentByNameParams{
+		OrganizationID: int32(organizationID),
+		Name:           name,
+		TenantID:       tenantArg(o.scope),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	department := toDomainDepartment(row)
+	return &department, nil
+}
+
+// CreateDepartment implements domain.OrganizationRepository. The
+// organization has to be in the repository's tenant.
+func (o *organizationRepository) CreateDepartment(department *domain.Department) error {
+	ctx := context.Background()
+	created, err := o.q.CreateDepartment(ctx, postgres.CreateDepartmentParams{
+		OrganizationID: int32(department.OrganizationID),
+		Name:           department.Name,
+		Location:       department.Location,
+		CostCenter:     department.CostCenter,
+		TenantID:       tenantArg(o.scope),
+	})
+	if err != nil {
+		return err
+	}
+	*department = toDomainDepartment(created)
+	return nil
+}
+
+// UpdateDepartment implements domain.OrganizationRepository.
+func (o *organizationRepository) UpdateDepartment(department *domain.Department) error {
+	ctx := context.Background()
+	updated, err := o.q.UpdateDepartment(ctx, postgres.UpdateDepartmentParams{
+		ID:         int32(department.ID),
+		Name:       department.Name,
+		Location:   department.Location,
+		CostCenter: department.CostCenter,
+		TenantID:   tenantArg(o.scope),
+	})
+	if err != nil {
+		return err
+	}
+	*department = toDomainDepartment(updated)
+	return nil
+}
+
+// DeleteDepartment implements domain.OrganizationRepository. Its members
+// stay in the organization without a department.
+func (o *organizationRepository) DeleteDepartment(id int64) error {
+	ctx := context.Background()
+	return o.q.DeleteDepartment(ctx, postgres.DeleteDepartmentParams{
+		ID:       int32(id),
+		TenantID: tenantArg(o.scope),
+	})
+}
+
+// GetMembers implements domain.OrganizationRepository.
+func (o *organizationRepository) GetMembers(organizationID int64, afterID *int64, limit int) ([]domain.OrganizationMember, error) {
+	ctx := context.Background()
+	params := postgres.GetOrganizationMembersParams{
+		OrganizationID: int32(organizationID),
+		TenantID:       tenantArg(o.scope),
+		Limit:          int32(limit),
+	}
+	if afterID != nil {
+		params.CursorID = pgtype.Int4{Int32: int32(*afterID), Valid: true}
+	}
+
+	rows, err := o.q.GetOrganizationMembers(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]domain.OrganizationMember, len(rows))
+	for i, row := range rows {
+		members[i] = toDomainOrganizationMember(row)
+	}
+	return members, nil
+}
+
+// CountMembers implements domain.OrganizationRepository.
+func (o *organizationRepository) CountMembers(organizationID int64) (int64, error) {
+	ctx := context.Background()
+	return o.q.CountOrganizationMembers(ctx, postgres.CountOrganizationMembersParams{
+		OrganizationID: int32(organizationID),
+		TenantID:       tenantArg(o.scope),
+	})
+}
+
+// GetMember implements domain.OrganizationRepository.
+func (o *organizationRepository) GetMember(userID int64) (*domain.OrganizationMember, error) {
+	ctx := context.Background()
+	row, err := o.q.GetOrganizationMember(ctx, postgres.GetOrganizationMemberParams{
+		UserID:   int32(userID),
+		TenantID: tenantArg(o.scope),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	member := toDomainOrganizationMember(postgres.GetOrganizationMembersRow(row))
+	return &member, nil
+}
+
+// SaveMember implements domain.OrganizationRepository. A member of another
+// organization is left as is, and so is a user of another tenant than the
+// organization's.
+func (o *organizationRepository) SaveMember(member *domain.OrganizationMember) error {
+	ctx := context.Background()
+	params := postgres.UpsertOrganizationMemberParams{
+		UserID:         int32(member.UserID),
+		OrganizationID: int32(member.OrganizationID),
+		Role:           member.Role,
+		TenantID:       tenantArg(o.scope),
+	}
+	if member.DepartmentID != nil {
+		params.DepartmentID = pgtype.Int4{Int32: int32(*member.DepartmentID), Valid: true}
+	}
+	return o.q.UpsertOrganizationMember(ctx, params)
+}
+
+// DeleteMember implements domain.OrganizationRepository.
+func (o *organizationRepository) DeleteMember(userID int64) error {
+	ctx := context.Background()
+	return o.q.DeleteOrganizationMember(ctx, postgres.DeleteOrganizationMemberParams{
+		UserID:   int32(userID),
+		TenantID: tenantArg(o.scope),
+	})
+}
+
+// CountAdmins implements domain.OrganizationRepository.
+func (o *organizationRepository) CountAdmins(organizationID int64) (int64, error) {
+	ctx := context.Background()
+	return o.q.CountOrganizationAdmins(ctx, postgres.CountOrganizationAdminsParams{
+		OrganizationID: int32(organizationID),
+		TenantID:       tenantArg(o.scope),
+	})
+}
+
+func toDomainOrganization(row postgres.Organization) domain.Organization {
+	return domain.Organization{
+		ID:        int64(row.ID),
+		Name:      row.Name,
+		TenantID:  int64(row.TenantID),
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
+
+func toDomainDepartment(row postgres.Department) domain.Department {
+	return domain.Department{
+		ID:             int64(row.ID),
+		OrganizationID: int64(row.OrganizationID),
+		Name:           row.Name,
+		Location:       row.Location,
+		CostCenter:     row.CostCenter,
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+	}
+}
+
+func toDomainOrganizationMember(row postgres.GetOrganizationMembersRow) domain.OrganizationMember {
+	member := domain.OrganizationMember{
+		UserID:         int64(row.UserID),
+		OrganizationID: int64(row.OrganizationID),
+		Role:           row.Role,
+		Name:           row.Name,
+		Email:          row.Email,
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+	}
+	if row.DepartmentID.Valid {
+		departmentID := int64(row.DepartmentID.Int32)
+		member.DepartmentID = &departmentID
+	}
+	return member
+}
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type passwordResetRepository struct {
+	q *postgres.Queries
+}
+
+func NewPasswordResetRepository(db *pgxpool.Pool) domain.PasswordResetRepository {
+	return &passwordResetRepository{q: postgres.New(db)}
+}
+
+// Create implements domain.PasswordResetRepository.
+func (p *passwordResetRepository) Create(userID int64, tokenHash string, expiresAt time.Time) error {
+	ctx := context.Background()
+	return p.q.CreatePasswordResetToken(ctx, postgres.CreatePasswordResetTokenParams{
+		UserID:    int32(userID),
+		TokenHash: tokenHash,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+}
+
+// Consume implements domain.PasswordResetRepository. The token is checked
+// and marked used in one statement, so it cannot be used twice.
+func (p *passwordResetRepository) Consume(tokenHash string) (int64, bool, error) {
+	ctx := context.Background()
+	userID, err := p.q.ConsumePasswordResetToken(ctx, tokenHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return int64(userID), true, nil
+}
+
+// InvalidateAll implements domain.PasswordResetRepository.
+func (p *passwordResetRepository) InvalidateAll(userID int64) error {
+	ctx := context.Background()
+	return p.q.InvalidatePasswordResetTokens(ctx, int32(userID))
+}
+
+// DeleteExpired implements domain.PasswordResetRepository.
+func (p *passwordResetRepository) DeleteExpired(before time.Time) (int64, error) {
+	ctx := context.Background()
+	return p.q.DeleteExpiredPasswordResetTokens(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+}
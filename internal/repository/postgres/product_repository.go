@@ -6,64 +6,238 @@ import (
 	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
 	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type productRepository struct {
-	q *postgres.Queries
+	db    database
+	q     *postgres.Queries
+	scope tenantScope
 }
 
 func NewProductRepository(db *pgxpool.Pool) domain.ProductRepository {
-	return &productRepository{q: postgres.New(db)}
+	return &productRepository{db: db, q: postgres.New(db)}
 }
 
-// CreateProduct implements domain.ProductRepository.
+// ForTenant implements domain.ProductRepository.
+func (p *productRepository) ForTenant(tenantID int64) domain.ProductRepository {
+	return &productRepository{db: p.db, q: p.q, scope: tenantScope{tenantID: tenantID}}
+}
+
+// SystemScope implements domain.ProductRepository.
+func (p *productRepository) SystemScope() domain.ProductRepository {
+	return &productRepository{db: p.db, q: p.q, scope: tenantScope{system: true}}
+}
+
+// CreateProduct implements domain.ProductRepository. The initial stock is
+// recorded as the opening receipt in the inventory ledger.
 func (p *productRepository) Create(product *domain.Product) error {
 	ctx := context.Background()
-	_, err := p.q.CreateProduct(ctx, postgres.CreateProductParams{
-		VendorID: int32(product.VendorID),
-		Name:     product.Name,
-		Price:    product.Price,
-		Stock:    int32(product.Stock),
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := p.q.WithTx(tx)
+	created, err := qtx.CreateProduct(ctx, postgres.CreateProductParams{
+		TenantID:          createTenant(p.scope, product.TenantID),
+		VendorID:          int32(product.VendorID),
+		Name:              product.Name,
+		Sku:               pgtype.Text{String: product.SKU, Valid: product.SKU != ""},
+		Description:       product.Description,
+		Category:          product.Category,
+		Price:             product.Price,
+		Stock:             int32(product.Stock),
+		LeadTimeDays:      int32(product.LeadTimeDays),
+		MinOrderQuantity:  int32(product.MinOrderQuantity),
+		LowStockThreshold: toNullableInt4(product.LowStockThreshold),
 	})
+	if err != nil {
+		return err
+	}
+	product.ID = int64(created.ID)
+	product.TenantID = int64(created.TenantID)
+	product.CreatedAt = created.CreatedAt.Time
+	product.UpdatedAt = created.UpdatedAt.Time
 
-	return err
+	if created.Stock > 0 {
+		if _, err := qtx.CreateInventoryMovement(ctx, postgres.CreateInventoryMovementParams{
+			ProductID:    created.ID,
+			MovementType: domain.MovementReceipt,
+			Quantity:     created.Stock,
+			OnHandDelta:  created.Stock,
+			Note:         "opening balance",
+			CreatedBy:    pgtype.Int4{Int32: created.VendorID, Valid: true},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
 }
 
-func (p *productRepository) GetAll(name string, limit int, offset int) ([]domain.ProductWithVendor, error) {
+// GetAll lists up to limit products matching filter that come after cursor
+// in filter.Sort order. Results are ranked by relevance when filter.Sort asks
+// for it and a search query is given.
+func (p *productRepository) GetAll(filter domain.ProductFilter, cursor *domain.ProductCursor, limit int) ([]domain.ProductWithVendor, error) {
 	ctx := context.Background()
-	products, err := p.q.GetProductsWithVendor(ctx, postgres.GetProductsWithVendorParams{
-		Column1: name,
-		Limit:   int32(limit),
-		Offset:  int32(offset),
-	})
+	args := productFilterArgs(filter, p.scope)
+	params := postgres.ListProductsParams{
+		Query:           args.Query,
+		MinPrice:        args.MinPrice,
+		MaxPrice:        args.MaxPrice,
+		VendorID:        args.VendorID,
+		Category:        args.Category,
+		InStock:         args.InStock,
+		MaxLeadTimeDays: args.MaxLeadTimeDays,
+		TenantID:        args.TenantID,
+		Sort:            filter.Sort,
+		Limit:           int32(limit),
+	}
+	if cursor != nil {
+		params.CursorID = pgtype.Int4{Int32: int32(cursor.ID), Valid: true}
+		params.CursorPrice = pgtype.Int4{Int32: cursor.Price, Valid: true}
+		params.CursorName = pgtype.Text{String: cursor.Name, Valid: true}
+		params.CursorRank = pgtype.Float4{Float32: cursor.Rank, Valid: true}
+	}
 
+	products, err := p.q.ListProducts(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
-	var domainProducts []domain.ProductWithVendor
-	for _, product := range products {
-		domainProducts = append(domainProducts, domain.ProductWithVendor{
-			ID:          int64(product.ID),
-			VendorID:    int64(product.VendorID),
-			ProductName: product.ProductName,
-			Price:       product.Price,
-			Stock:       int(product.Stock),
-			VendorName:  product.VendorName.String,
-		})
+	domainProducts := make([]domain.ProductWithVendor, len(products))
+	for i, product := range products {
+		domainProducts[i] = domain.ProductWithVendor{
+			ID:               int64(product.ID),
+			VendorID:         int64(product.VendorID),
+			ProductName:      product.ProductName,
+			SKU:              product.Sku.String,
+			Description:      product.Description,
+			Category:         product.Category,
+			Price:            product.Price,
+			Stock:            int(product.Stock),
+			ReservedStock:    int(product.ReservedStock),
+			LeadTimeDays:     int(product.LeadTimeDays),
+			MinOrderQuantity: int(product.MinOrderQuantity),
+			CreatedAt:        product.CreatedAt.Time,
+			UpdatedAt:        product.UpdatedAt.Time,
+			VendorName:       product.VendorName.String,
+			Rank:             product.Rank,
+			Snippet:          product.Snippet,
+		}
 	}
 
 	return domainProducts, nil
 }
 
-func (p *productRepository) GetProductsByVendorID(vendorID int64, limit int, offset int) ([]domain.Product, error) {
+// Count implements domain.ProductRepository.
+func (p *productRepository) Count(filter domain.ProductFilter) (int64, error) {
 	ctx := context.Background()
-	products, err := p.q.GetProductsByVendorID(ctx, postgres.GetProductsByVendorIDParams{
+	args := productFilterArgs(filter, p.scope)
+	return p.q.CountProducts(ctx, postgres.CountProductsParams{
+		Query:           args.Query,
+		MinPrice:        args.MinPrice,
+		MaxPrice:        args.MaxPrice,
+		VendorID:        args.VendorID,
+		Category:        args.Category,
+		InStock:         args.InStock,
+		MaxLeadTimeDays: args.MaxLeadTimeDays,
+		TenantID:        args.TenantID,
+	})
+}
+
+// GetFacets implements domain.ProductRepository. Each facet is counted with
+// its own filter removed so the sidebar keeps showing the alternatives.
+func (p *productRepository) GetFacets(filter domain.ProductFilter) (*domain.ProductFacets, error) {
+	ctx := context.Background()
+	args := productFilterArgs(filter, p.scope)
+
+	categories, err := p.q.GetProductCategoryFacets(ctx, postgres.GetProductCategoryFacetsParams{
+		Query:           args.Query,
+		MinPrice:        args.MinPrice,
+		MaxPrice:        args.MaxPrice,
+		VendorID:        args.VendorID,
+		InStock:         args.InStock,
+		MaxLeadTimeDays: args.MaxLeadTimeDays,
+		TenantID:        args.TenantID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vendors, err := p.q.GetProductVendorFacets(ctx, postgres.GetProductVendorFacetsParams{
+		Query:           args.Query,
+		MinPrice:        args.MinPrice,
+		MaxPrice:        args.MaxPrice,
+		Category:        args.Category,
+		InStock:         args.InStock,
+		MaxLeadTimeDays: args.MaxLeadTimeDays,
+		TenantID:        args.TenantID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := p.q.GetProductPriceFacets(ctx, postgres.GetProductPriceFacetsParams{
+		Bounds:          domain.PriceBucketBounds,
+		Query:           args.Query,
+		VendorID:        args.VendorID,
+		Category:        args.Category,
+		InStock:         args.InStock,
+		MaxLeadTimeDays: args.MaxLeadTimeDays,
+		TenantID:        args.TenantID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	facets := &domain.ProductFacets{
+		Categories:   make([]domain.FacetCount, len(categories)),
+		Vendors:      make([]domain.VendorFacetCount, len(vendors)),
+		PriceBuckets: make([]domain.PriceBucketCount, len(prices)),
+	}
+	for i, category := range categories {
+		facets.Categories[i] = domain.FacetCount{Value: category.Category, Count: category.Count}
+	}
+	for i, vendor := range vendors {
+		facets.Vendors[i] = domain.VendorFacetCount{
+			VendorID:   int64(vendor.VendorID),
+			VendorName: vendor.VendorName.String,
+			Count:      vendor.Count,
+		}
+	}
+	for i, price := range prices {
+		// width_bucket returns 0 below the first bound and len(bounds) at or
+		// above the last one.
+		bucket := domain.PriceBucketCount{Count: price.Count}
+		if price.Bucket > 0 {
+			bucket.Min = domain.PriceBucketBounds[price.Bucket-1]
+		}
+		if int(price.Bucket) < len(domain.PriceBucketBounds) {
+			max := domain.PriceBucketBounds[price.Bucket]
+			bucket.Max = &max
+		}
+		facets.PriceBuckets[i] = bucket
+	}
+
+	return facets, nil
+}
+
+func (p *productRepository) GetProductsByVendorID(vendorID int64, afterID *int64, limit int) ([]domain.Product, error) {
+	ctx := context.Background()
+	params := postgres.GetProductsByVendorIDParams{
 		VendorID: int32(vendorID),
+		TenantID: tenantArg(p.scope),
 		Limit:    int32(limit),
-		Offset:   int32(offset),
-	})
+	}
+	if afterID != nil {
+		params.CursorID = pgtype.Int4{Int32: int32(*afterID), Valid: true}
+	}
+
+	products, err := p.q.GetProductsByVendorID(ctx, params)
 
 	if err != nil {
 		return []domain.Product{}, err
@@ -75,22 +249,28 @@ func (p *productRepository) GetProductsByVendorID(vendorID int64, limit int, off
 
 	domainProducts := make([]domain.Product, len(products))
 	for i, product := range products {
-		domainProducts[i] = domain.Product{
-			ID:       int64(product.ID),
-			VendorID: int64(product.VendorID),
-			Name:     product.Name,
-			Price:    product.Price,
-			Stock:    int(product.Stock),
-		}
+		domainProducts[i] = *toDomainProduct(product)
 	}
 
 	return domainProducts, nil
 }
 
+// CountByVendorID implements domain.ProductRepository.
+func (p *productRepository) CountByVendorID(vendorID int64) (int64, error) {
+	ctx := context.Background()
+	return p.q.CountProductsByVendorID(ctx, postgres.CountProductsByVendorIDParams{
+		VendorID: int32(vendorID),
+		TenantID: tenantArg(p.scope),
+	})
+}
+
 // DeleteProduct implements domain.ProductRepository.
 func (p *productRepository) Delete(id int64) error {
 	ctx := context.Background()
-	err := p.q.DeleteProduct(ctx, int32(id))
+	err := p.q.DeleteProduct(ctx, postgres.DeleteProductParams{
+		ID:       int32(id),
+		TenantID: tenantArg(p.scope),
+	})
 
 	return err
 }
@@ -98,26 +278,94 @@ func (p *productRepository) Delete(id int64) error {
 // GetProductByID implements domain.ProductRepository.
 func (p *productRepository) GetByID(id int64) (*domain.Product, error) {
 	ctx := context.Background()
-	product, err := p.q.GetProductByID(ctx, int32(id))
+	product, err := p.q.GetProductByID(ctx, postgres.GetProductByIDParams{
+		ID:       int32(id),
+		TenantID: tenantArg(p.scope),
+	})
 
-	return &domain.Product{
-		ID:       int64(product.ID),
-		VendorID: int64(product.VendorID),
-		Name:     product.Name,
-		Price:    product.Price,
-		Stock:    int(product.Stock),
-	}, err
+	return toDomainProduct(product), err
 }
 
 // UpdateProduct implements domain.ProductRepository.
 func (p *productRepository) Update(product *domain.Product) error {
 	ctx := context.Background()
 	err := p.q.UpdateProduct(ctx, postgres.UpdateProductParams{
-		ID:    int32(product.ID),
-		Name:  product.Name,
-		Price: product.Price,
-		Stock: int32(product.Stock),
+		ID:                int32(product.ID),
+		Name:              product.Name,
+		Sku:               pgtype.Text{String: product.SKU, Valid: product.SKU != ""},
+		Description:       product.Description,
+		Category:          product.Category,
+		Price:             product.Price,
+		LeadTimeDays:      int32(product.LeadTimeDays),
+		MinOrderQuantity:  int32(product.MinOrderQuantity),
+		LowStockThreshold: toNullableInt4(product.LowStockThreshold),
+		TenantID:          tenantArg(p.scope),
 	})
 
 	return err
 }
+
+func toDomainProduct(product postgres.Product) *domain.Product {
+	domainProduct := &domain.Product{
+		ID:               int64(product.ID),
+		VendorID:         int64(product.VendorID),
+		Name:             product.Name,
+		SKU:              product.Sku.String,
+		Description:      product.Description,
+		Category:         product.Category,
+		Price:            product.Price,
+		Stock:            int(product.Stock),
+		ReservedStock:    int(product.ReservedStock),
+		LeadTimeDays:     int(product.LeadTimeDays),
+		MinOrderQuantity: int(product.MinOrderQuantity),
+		TenantID:         int64(product.TenantID),
+		CreatedAt:        product.CreatedAt.Time,
+		UpdatedAt:        product.UpdatedAt.Time,
+	}
+	if product.LowStockThreshold.Valid {
+		threshold := int(product.LowStockThreshold.Int32)
+		domainProduct.LowStockThreshold = &threshold
+	}
+	return domainProduct
+}
+
+func toNullableInt4(value *int) pgtype.Int4 {
+	if value == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: int32(*value), Valid: true}
+}
+
+// filterArgs holds the nullable query arguments shared by the catalog queries.
+type filterArgs struct {
+	TenantID        pgtype.Int4
+	Query           pgtype.Text
+	MinPrice        pgtype.Int4
+	MaxPrice        pgtype.Int4
+	VendorID        pgtype.Int4
+	Category        pgtype.Text
+	InStock         bool
+	MaxLeadTimeDays pgtype.Int4
+}
+
+func productFilterArgs(filter domain.ProductFilter, scope tenantScope) filterArgs {
+	args := filterArgs{
+		TenantID: tenantArg(scope),
+		Query:    pgtype.Text{String: filter.Query, Valid: filter.Query != ""},
+		Category: pgtype.Text{String: filter.Category, Valid: filter.Category != ""},
+		InStock:  filter.InStock,
+	}
+	if filter.MinPrice != nil {
+		args.MinPrice = pgtype.Int4{Int32: *filter.MinPrice, Valid: true}
+	}
+	if filter.MaxPrice != nil {
+		args.MaxPrice = pgtype.Int4{Int32: *filter.MaxPrice, Valid: true}
+	}
+	if filter.VendorID != nil {
+		args.VendorID = pgtype.Int4{Int32: int32(*filter.VendorID), Valid: true}
+	}
+	if filter.MaxLeadTimeDays != nil {
+		args.MaxLeadTimeDays = pgtype.Int4{Int32: int32(*filter.MaxLeadTimeDays), Valid: true}
+	}
+	return args
+}
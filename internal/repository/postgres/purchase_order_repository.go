@@ -0,0 +1,298 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type purchaseOrderRepository struct {
+	db    database
+	q     *postgres.Queries
+	scope tenantScope
+}
+
+func NewPurchaseOrderRepository(db *pgxpool.Pool) domain.PurchaseOrderRepository {
+	return &purchaseOrderRepository{db: db, q: postgres.New(db)}
+}
+
+// ForTenant implements domain.PurchaseOrderRepository.
+func (p *purchaseOrderRepository) ForTenant(tenantID int64) domain.PurchaseOrderRepository {
+	return &purchaseOrderRepository{db: p.db, q: p.q, scope: tenantScope{tenantID: tenantID}}
+}
+
+// SystemScope implements domain.PurchaseOrderRepository.
+func (p *purchaseOrderRepository) SystemScope() domain.PurchaseOrderRepository {
+	return &purchaseOrderRepository{db: p.db, q: p.q, scope: tenantScope{system: true}}
+}
+
+// GetByID implements domain.PurchaseOrderRepository. The order is returned
+// with its items.
+func (p *purchaseOrderRepository) GetByID(id int64) (*domain.PurchaseOrder, error) {
+	ctx := context.Background()
+	order, err := p.q.GetPurchaseOrderByID(ctx, postgres.GetPurchaseOrderByIDParams{
+		ID:       int32(id),
+		TenantID: tenantArg(p.scope),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := p.q.GetPurchaseOrderItems(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	domainOrder := toDomainPurchaseOrder(order)
+	domainOrder.Items = make([]domain.PurchaseOrderItem, len(items))
+	for i, item := range items {
+		domainOrder.Items[i] = domain.PurchaseOrderItem{
+			ID:              int64(item.ID),
+			PurchaseOrderID: int64(item.PurchaseOrderID),
+			ProductID:       int64(item.ProductID.Int32),
+			ProductName:     item.ProductName,
+			UnitPrice:       item.UnitPrice,
+			Quantity:        int(item.Quantity),
+			Subtotal:        int64(item.UnitPrice) * int64(item.Quantity),
+		}
+	}
+
+	return &domainOrder, nil
+}
+
+// GetByBuyerID implements domain.PurchaseOrderRepository.
+func (p *purchaseOrderRepository) GetByBuyerID(buyerID int64, afterID *int64, limit int) ([]domain.PurchaseOrder, error) {
+	ctx := context.Background()
+	params := postgres.GetPurchaseOrdersByBuyerIDParams{
+		BuyerID:  int32(buyerID),
+		TenantID: tenantArg(p.scope),
+		Limit:    int32(limit),
+	}
+	if afterID != nil {
+		params.CursorID = pgtype.Int4{Int32: int32(*afterID), Valid: true}
+	}
+
+	orders, err := p.q.GetPurchaseOrdersByBuyerID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	domainOrders := make([]domain.PurchaseOrder, len(orders))
+	for i, order := range orders {
+		domainOrders[i] = toDomainPurchaseOrder(order)
+	}
+
+	return domainOrders, nil
+}
+
+// CountByBuyerID implements domain.PurchaseOrderRepository.
+func (p *purchaseOrderRepository) CountByBuyerID(buyerID int64) (int64, error) {
+	ctx := context.Background()
+	return p.q.CountPurchaseOrdersByBuyerID(ctx, postgres.CountPurchaseOrdersByBuyerIDParams{
+		BuyerID:  int32(buyerID),
+		TenantID: tenantArg(p.scope),
+	})
+}
+
+// GetByVendorID implements domain.PurchaseOrderRepository.
+func (p *purchaseOrderRepository) GetByVendorID(vendorID int64, afterID *int64, limit int) ([]domain.PurchaseOrder, error) {
+	ctx := context.Background()
+	params := postgres.GetPurchaseOrdersByVendorIDParams{
+		VendorID: int32(vendorID),
+		TenantID: tenantArg(p.scope),
+		Limit:    int32(limit),
+	}
+	if afterID != nil {
+		params.CursorID = pgtype.Int4{Int32: int32(*afterID), Valid: true}
+	}
+
+	orders, err := p.q.GetPurchaseOrdersByVendorID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	domainOrders := make([]domain.PurchaseOrder, len(orders))
+	for i, order := range orders {
+		domainOrders[i] = toDomainPurchaseOrder(order)
+	}
+
+	return domainOrders, nil
+}
+
+// CountByVendorID implements domain.PurchaseOrderRepository.
+func (p *purchaseOrderRepository) CountByVendorID(vendorID int64) (int64, error) {
+	ctx := context.Background()
+	return p.q.CountPurchaseOrdersByVendorID(ctx, postgres.CountPurchaseOrdersByVendorIDParams{
+		VendorID: int32(vendorID),
+		TenantID: tenantArg(p.scope),
+	})
+}
+
+// GetByOrganizationID implements domain.PurchaseOrderRepository.
+func (p *purchaseOrderRepository) GetByOrganizationID(organizationID int64, departmentID *int64, afterID *int64, limit int) ([]domain.PurchaseOrder, error) {
+	ctx := context.Background()
+	params := postgres.GetPurchaseOrdersByOrganizationIDParams{
+		OrganizationID: pgtype.Int4{Int32: int32(organizationID), Valid: true},
+		TenantID:       tenantArg(p.scope),
+		Limit:          int32(limit),
+	}
+	if departmentID != nil {
+		params.DepartmentID = pgtype.Int4{Int32: int32(*departmentID), Valid: true}
+	}
+	if afterID != nil {
+		params.CursorID = pgtype.Int4{Int32: int32(*afterID), Valid: true}
+	}
+
+	orders, err := p.q.GetPurchaseOrdersByOrganizationID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	domainOrders := make([]domain.PurchaseOrder, len(orders))
+	for i, order := range orders {
+		domainOrders[i] = toDomainPurchaseOrder(order)
+	}
+
+	return domainOrders, nil
+}
+
+// CountByOrganizationID implements domain.PurchaseOrderRepository.
+func (p *purchaseOrderRepository) CountByOrganizationID(organizationID int64, departmentID *int64) (int64, error) {
+	ctx := context.Background()
+	params := postgres.CountPurchaseOrdersByOrganizationIDParams{
+		OrganizationID: pgtype.Int4{Int32: int32(organizationID), Valid: true},
+		TenantID:       tenantArg(p.scope),
+	}
+	if departmentID != nil {
+		params.DepartmentID = pgtype.Int4{Int32: int32(*departmentID), Valid: true}
+	}
+	return p.q.CountPurchaseOrdersByOrganizationID(ctx, params)
+}
+
+// Transition implements domain.PurchaseOrderRepository.
+func (p *purchaseOrderRepository) Transition(id int64, to string, by *int64) (*domain.PurchaseOrder, error) {
+	ctx := context.Background()
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := p.q.WithTx(tx)
+	order, err := qtx.LockPurchaseOrder(ctx, postgres.LockPurchaseOrderParams{
+		ID:       int32(id),
+		TenantID: tenantArg(p.scope),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.CanTransitionPurchaseOrder(order.Status, to) {
+		return nil, errors.ErrInvalidTransition
+	}
+	// the sweeper may not have run yet, so check the deadline here as well
+	if to == domain.PurchaseOrderStatusIssued && order.ExpiresAt.Valid && order.ExpiresAt.Time.Before(time.Now()) {
+		return nil, errors.ErrPurchaseOrderExpired
+	}
+
+	if err := transitionPurchaseOrder(ctx, qtx, order, to, by); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return p.GetByID(id)
+}
+
+// ExpirePending implements domain.PurchaseOrderRepository. Orders locked by
+// another transaction are skipped so concurrent sweepers do not block each
+// other or a buyer confirming an order.
+func (p *purchaseOrderRepository) ExpirePending(limit int) ([]domain.PurchaseOrder, error) {
+	ctx := context.Background()
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := p.q.WithTx(tx)
+	orders, err := qtx.LockExpiredPurchaseOrders(ctx, postgres.LockExpiredPurchaseOrdersParams{
+		Status:   domain.PurchaseOrderStatusPending,
+		TenantID: tenantArg(p.scope),
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	expired := make([]domain.PurchaseOrder, len(orders))
+	for i, order := range orders {
+		if err := transitionPurchaseOrder(ctx, qtx, order, domain.PurchaseOrderStatusExpired, nil); err != nil {
+			return nil, err
+		}
+		expired[i] = toDomainPurchaseOrder(order)
+		expired[i].Status = domain.PurchaseOrderStatusExpired
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return expired, nil
+}
+
+// transitionPurchaseOrder moves a locked order to status to and settles its
+// stock reservations accordingly. Only pending orders have a deadline, so the
+// expiry is cleared on every transition.
+func transitionPurchaseOrder(ctx context.Context, q *postgres.Queries, order postgres.PurchaseOrder, to string, by *int64) error {
+	switch to {
+	case domain.PurchaseOrderStatusCancelled, domain.PurchaseOrderStatusExpired:
+		if err := settleReservations(ctx, q, int64(order.TenantID), int64(order.ID), domain.ReservationReleased, by); err != nil {
+			return err
+		}
+	case domain.PurchaseOrderStatusShipped:
+		if err := settleReservations(ctx, q, int64(order.TenantID), int64(order.ID), domain.ReservationFulfilled, by); err != nil {
+			return err
+		}
+	}
+
+	return q.UpdatePurchaseOrderStatus(ctx, postgres.UpdatePurchaseOrderStatusParams{
+		ID:       order.ID,
+		Status:   to,
+		TenantID: pgtype.Int4{Int32: order.TenantID, Valid: true},
+	})
+}
+
+func toDomainPurchaseOrder(order postgres.PurchaseOrder) domain.PurchaseOrder {
+	domainOrder := domain.PurchaseOrder{
+		ID:          int64(order.ID),
+		BuyerID:     int64(order.BuyerID),
+		VendorID:    int64(order.VendorID),
+		Status:      order.Status,
+		TotalAmount: order.TotalAmount,
+		TenantID:    int64(order.TenantID),
+		CreatedAt:   order.CreatedAt.Time,
+		UpdatedAt:   order.UpdatedAt.Time,
+	}
+	if order.ExpiresAt.Valid {
+		expiresAt := order.ExpiresAt.Time
+		domainOrder.ExpiresAt = &expiresAt
+	}
+	if order.OrganizationID.Valid {
+		organizationID := int64(order.OrganizationID.Int32)
+		domainOrder.OrganizationID = &organizationID
+	}
+	if order.DepartmentID.Valid {
+		departmentID := int64(order.DepartmentID.Int32)
+		domainOrder.DepartmentID = &departmentID
+	}
+	return domainOrder
+}
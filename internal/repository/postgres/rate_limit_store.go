@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type rateLimitStore struct {
+	q *postgres.Queries
+}
+
+// NewRateLimitStore keeps rate limit buckets in Postgres, so the replicas
+// share them. A token is taken in a single statement.
+func NewRateLimitStore(db *pgxpool.Pool) domain.RateLimitStore {
+	return &rateLimitStore{q: postgres.New(db)}
+}
+
+// Take implements domain.RateLimitStore.
+func (s *rateLimitStore) Take(key string, limit domain.RateLimit, now time.Time) (domain.RateLimitResult, error) {
+	ctx := context.Background()
+	fullAt, err := s.q.TakeRateLimitToken(ctx, postgres.TakeRateLimitTokenParams{
+		Key:      key,
+		Now:      pgtype.Timestamptz{Time: now, Valid: true},
+		Interval: pgtype.Interval{Microseconds: limit.Interval().Microseconds(), Valid: true},
+		Period:   pgtype.Interval{Microseconds: limit.Period.Microseconds(), Valid: true},
+	})
+	if err == nil {
+		return limit.Result(fullAt.Time, now, true), nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return domain.RateLimitResult{}, err
+	}
+
+	// The bucket is empty, so it was left as it was.
+	fullAt, err = s.q.GetRateLimitBucket(ctx, key)
+	if err != nil {
+		return domain.RateLimitResult{}, err
+	}
+	return limit.Result(fullAt.Time, now, false), nil
+}
+
+// Prune implements domain.RateLimitStore.
+func (s *rateLimitStore) Prune(now time.Time) (int64, error) {
+	ctx := context.Background()
+	return s.q.DeleteFullRateLimitBuckets(ctx, pgtype.Timestamptz{Time: now, Valid: true})
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+)
+
+type rfqRepository struct {
+	q     *postgres.Queries
+	scope tenantScope
+}
+
+// ForTenant implements domain.RFQRepository.
+func (r *rfqRepository) ForTenant(tenantID int64) domain.RFQRepository {
+	return &rfqRepository{q: r.q, scope: tenantScope{tenantID: tenantID}}
+}
+
+// SystemScope implements domain.RFQRepository.
+func (r *rfqRepository) SystemScope() domain.RFQRepository {
+	return &rfqRepository{q: r.q, scope: tenantScope{system: true}}
+}
+
+// Create implements domain.RFQRepository.
+func (r *rfqRepository) Create(rfq *domain.RFQ) error {
+	ctx := context.Background()
+	created, err := r.q.CreateRFQ(ctx, postgres.CreateRFQParams{
+		TenantID: createTenant(r.scope, rfq.TenantID),
+		BuyerID:  int32(rfq.BuyerID),
+		Title:    rfq.Title,
+		Deadline: pgtype.Timestamptz{Time: rfq.Deadline, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	*rfq = toDomainRFQ(created)
+	return nil
+}
+
+// CloseDue implements domain.RFQRepository.
+func (r *rfqRepository) CloseDue(limit int) ([]domain.RFQ, error) {
+	ctx := context.Background()
+	rows, err := r.q.CloseDueRFQs(ctx, postgres.CloseDueRFQsParams{
+		TenantID: tenantArg(r.scope),
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rfqs := make([]domain.RFQ, len(rows))
+	for i, row := range rows {
+		rfqs[i] = toDomainRFQ(row)
+	}
+	return rfqs, nil
+}
+
+func toDomainRFQ(row postgres.Rfq) domain.RFQ {
+	rfq := domain.RFQ{
+		ID:        int64(row.ID),
+		BuyerID:   int64(row.BuyerID),
+		Title:     row.Title,
+		Status:    row.Status,
+		Deadline:  row.Deadline.Time,
+		TenantID:  int64(row.TenantID),
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+	if row.ClosedAt.Valid {
+		closedAt := row.ClosedAt.Time
+		rfq.ClosedAt = &closedAt
+	}
+	return rfq
+}
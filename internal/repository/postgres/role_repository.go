@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type roleRepository struct {
+	q *postgres.Queries
+}
+
+func NewRoleRepository(db *pgxpool.Pool) domain.RoleRepository {
+	return &roleRepository{q: postgres.New(db)}
+}
+
+// GetAll implements domain.RoleRepository.
+func (r *roleRepository) GetAll() ([]domain.Role, error) {
+	ctx := context.Background()
+	rows, err := r.q.GetRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]domain.Role, len(rows))
+	for i, row := range rows {
+		roles[i] = toDomainRole(row)
+	}
+	return roles, nil
+}
+
+// GetByID implements domain.RoleRepository.
+func (r *roleRepository) GetByID(id int64) (*domain.Role, error) {
+	ctx := context.Background()
+	row, err := r.q.GetRoleByID(ctx, int32(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	role := toDomainRole(postgres.GetRolesRow(row))
+	return &role, nil
+}
+
+// GetByName implements domain.RoleRepository.
+func (r *roleRepository) GetByName(name string) (*domain.Role, error) {
+	ctx := context.Background()
+	row, err := r.q.GetRoleByName(ctx, name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	role := toDomainRole(postgres.GetRolesRow(row))
+	return &role, nil
+}
+
+// Create implements domain.RoleRepository. Permissions are set separately.
+func (r *roleRepository) Create(role *domain.Role) error {
+	ctx := context.Background()
+	created, err := r.q.CreateRole(ctx, postgres.CreateRoleParams{
+		Name:        role.Name,
+		Description: role.Description,
+	})
+	if err != nil {
+		return err
+	}
+
+	role.ID = int64(created.ID)
+	role.BuiltIn = created.BuiltIn
+	role.CreatedAt = created.CreatedAt.Time
+	role.UpdatedAt = created.UpdatedAt.Time
+	return nil
+}
+
+// Update implements domain.RoleRepository. Permissions are set separately.
+func (r *roleRepository) Update(role *domain.Role) error {
+	ctx := context.Background()
+	updated, err := r.q.UpdateRole(ctx, postgres.UpdateRoleParams{
+		ID:          int32(role.ID),
+		Name:        role.Name,
+		Description: role.Description,
+	})
+	if err != nil {
+		return err
+	}
+
+	role.UpdatedAt = updated.UpdatedAt.Time
+	return nil
+}
+
+// Delete implements domain.RoleRepository.
+func (r *roleRepository) Delete(id int64) error {
+	ctx := context.Background()
+	return r.q.DeleteRole(ctx, int32(id))
+}
+
+// SetPermissions implements domain.RoleRepository.
+func (r *roleRepository) SetPermissions(roleID int64, permissions []string) error {
+	ctx := context.Background()
+	if err := r.q.DeleteRolePermissions(ctx, int32(roleID)); err != nil {
+		return err
+	}
+	return r.q.AddRolePermissions(ctx, postgres.AddRolePermissionsParams{
+		RoleID:      int32(roleID),
+		Permissions: permissions,
+	})
+}
+
+// GetByUserID implements domain.RoleRepository.
+func (r *roleRepository) GetByUserID(userID int64) ([]domain.Role, error) {
+	ctx := context.Background()
+	rows, err := r.q.GetRolesByUserID(ctx, int32(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]domain.Role, len(rows))
+	for i, row := range rows {
+		roles[i] = toDomainRole(postgres.GetRolesRow(row))
+	}
+	return roles, nil
+}
+
+// GetUserPermissions implements domain.RoleRepository.
+func (r *roleRepository) GetUserPermissions(userID int64) ([]string, error) {
+	ctx := context.Background()
+	return r.q.GetUserPermissions(ctx, int32(userID))
+}
+
+// SetUserRoles implements domain.RoleRepository.
+func (r *roleRepository) SetUserRoles(userID int64, roleIDs []int64) error {
+	ctx := context.Background()
+	if err := r.q.DeleteUserRoles(ctx, int32(userID)); err != nil {
+		return err
+	}
+
+	ids := make([]int32, len(roleIDs))
+	for i, id := range roleIDs {
+		ids[i] = int32(id)
+	}
+	return r.q.AddUserRoles(ctx, postgres.AddUserRolesParams{
+		UserID:  int32(userID),
+		RoleIds: ids,
+	})
+}
+
+// AddUserRoleByName implements domain.RoleRepository.
+func (r *roleRepository) AddUserRoleByName(userID int64, name string) error {
+	ctx := context.Background()
+	return r.q.AddUserRoleByName(ctx, postgres.AddUserRoleByNameParams{
+		UserID: int32(userID),
+		Name:   name,
+	})
+}
+
+// CountUsers implements domain.RoleRepository.
+func (r *roleRepository) CountUsers(roleID int64) (int64, error) {
+	ctx := context.Background()
+	return r.q.CountRoleUsers(ctx, int32(roleID))
+}
+
+func toDomainRole(row postgres.GetRolesRow) domain.Role {
+	return domain.Role{
+		ID:          int64(row.ID),
+		Name:        row.Name,
+		Description: row.Description,
+		Permissions: row.Permissions,
+		BuiltIn:     row.BuiltIn,
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}
+}
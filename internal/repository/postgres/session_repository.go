@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type sessionRepository struct {
+	q *postgres.Queries
+}
+
+func NewSessionRepository(db *pgxpool.Pool) domain.SessionRepository {
+	return &sessionRepository{q: postgres.New(db)}
+}
+
+// Create implements domain.SessionRepository.
+func (s *sessionRepository) Create(session *domain.Session) error {
+	ctx := context.Background()
+	row, err := s.q.CreateSession(ctx, postgres.CreateSessionParams{
+		UserID:    int32(session.UserID),
+		UserAgent: session.UserAgent,
+		Ip:        session.IP,
+		ExpiresAt: pgtype.Timestamptz{Time: session.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	*session = *toDomainSession(row)
+	return nil
+}
+
+// AddRefreshToken implements domain.SessionRepository.
+func (s *sessionRepository) AddRefreshToken(sessionID int64, tokenHash string) error {
+	ctx := context.Background()
+	return s.q.CreateRefreshToken(ctx, postgres.CreateRefreshTokenParams{
+		SessionID: int32(sessionID),
+		TokenHash: tokenHash,
+	})
+}
+
+// GetRefreshToken implements domain.SessionRepository.
+func (s *sessionRepository) GetRefreshToken(tokenHash string) (*domain.RefreshToken, error) {
+	ctx := context.Background()
+	row, err := s.q.GetRefreshTokenForUpdate(ctx, tokenHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token := &domain.RefreshToken{
+		ID:               int64(row.ID),
+		SessionID:        int64(row.SessionID),
+		UserID:           int64(row.UserID),
+		SessionExpiresAt: row.ExpiresAt.Time,
+	}
+	if row.UsedAt.Valid {
+		token.UsedAt = &row.UsedAt.Time
+	}
+	if row.RevokedAt.Valid {
+		token.SessionRevokedAt = &row.RevokedAt.Time
+	}
+	return token, nil
+}
+
+// UseRefreshToken implements domain.SessionRepository.
+func (s *sessionRepository) UseRefreshToken(id int64) (bool, error) {
+	ctx := context.Background()
+	n, err := s.q.UseRefreshToken(ctx, int32(id))
+	return n > 0, err
+}
+
+// Touch implements domain.SessionRepository.
+func (s *sessionRepository) Touch(id int64, ip, userAgent string, expiresAt time.Time) error {
+	ctx := context.Background()
+	return s.q.TouchSession(ctx, postgres.TouchSessionParams{
+		ID:        int32(id),
+		Ip:        ip,
+		UserAgent: userAgent,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+}
+
+// Revoke implements domain.SessionRepository.
+func (s *sessionRepository) Revoke(id int64, reason string) (bool, error) {
+	ctx := context.Background()
+	n, err := s.q.RevokeSession(ctx, postgres.RevokeSessionParams{
+		ID:            int32(id),
+		RevokedReason: reason,
+	})
+	return n > 0, err
+}
+
+// RevokeAll implements domain.SessionRepository.
+func (s *sessionRepository) RevokeAll(userID int64, reason string) (int64, error) {
+	ctx := context.Background()
+	return s.q.RevokeUserSessions(ctx, postgres.RevokeUserSessionsParams{
+		UserID:        int32(userID),
+		RevokedReason: reason,
+	})
+}
+
+// GetActive implements domain.SessionRepository.
+func (s *sessionRepository) GetActive(userID int64) ([]*domain.Session, error) {
+	ctx := context.Background()
+	rows, err := s.q.GetActiveUserSessions(ctx, int32(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*domain.Session, len(rows))
+	for i, row := range rows {
+		sessions[i] = toDomainSession(row)
+	}
+	return sessions, nil
+}
+
+// RevokeForUser implements domain.SessionRepository.
+func (s *sessionRepository) RevokeForUser(id, userID int64, reason string) (bool, error) {
+	ctx := context.Background()
+	n, err := s.q.RevokeUserSession(ctx, postgres.RevokeUserSessionParams{
+		ID:            int32(id),
+		UserID:        int32(userID),
+		RevokedReason: reason,
+	})
+	return n > 0, err
+}
+
+// DeleteExpired implements domain.SessionRepository.
+func (s *sessionRepository) DeleteExpired(before time.Time) (int64, error) {
+	ctx := context.Background()
+	return s.q.DeleteExpiredSessions(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+}
+
+// GetAccessState implements domain.SessionRepository.
+func (s *sessionRepository) GetAccessState(userID, sessionID int64) (*domain.AccessState, error) {
+	ctx := context.Background()
+	row, err := s.q.GetAccessState(ctx, postgres.GetAccessStateParams{
+		UserID:    int32(userID),
+		SessionID: int32(sessionID),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.AccessState{
+		TokenVersion:  int(row.TokenVersion),
+		Status:        row.Status,
+		SessionActive: row.SessionActive,
+		Permissions:   row.Permissions,
+	}, nil
+}
+
+func toDomainSession(row postgres.Session) *domain.Session {
+	session := &domain.Session{
+		ID:            int64(row.ID),
+		UserID:        int64(row.UserID),
+		UserAgent:     row.UserAgent,
+		IP:            row.Ip,
+		LastUsedAt:    row.LastUsedAt.Time,
+		ExpiresAt:     row.ExpiresAt.Time,
+		RevokedReason: row.RevokedReason,
+		CreatedAt:     row.CreatedAt.Time,
+	}
+	if row.RevokedAt.Valid {
+		session.RevokedAt = &row.RevokedAt.Time
+	}
+	return session
+}
@@ -8,24 +8,358 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type Product struct {
+type Approval struct {
+	ID              int32
+	TenantID        int32
+	PurchaseOrderID int32
+	ApproverID      int32
+	Status          string
+	IdleSince       pgtype.Timestamptz
+	EscalatedAt     pgtype.Timestamptz
+	CreatedAt       pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+}
+
+type AuditCheckpoint struct {
+	ID        int64
+	Seq       int64
+	EventID   int64
+	Hash      string
+	KeyID     string
+	Signature string
+	CreatedAt pgtype.Timestamptz
+}
+
+type AuditEvent struct {
+	ID         int64
+	ActorID    pgtype.Int4
+	ActorRole  string
+	Ip         string
+	RequestID  string
+	Action     string
+	EntityType string
+	EntityID   int32
+	Before     []byte
+	After      []byte
+	Diff       []byte
+	CreatedAt  pgtype.Timestamptz
+	Seq        int64
+	PrevHash   string
+	Hash       string
+	TenantID   pgtype.Int4
+}
+
+type CartItem struct {
 	ID        int32
-	VendorID  int32
-	Name      string
-	Price     int32
-	Stock     int32
+	UserID    int32
+	ProductID int32
+	Quantity  int32
 	CreatedAt pgtype.Timestamptz
 	UpdatedAt pgtype.Timestamptz
 }
 
-type User struct {
+type Department struct {
+	ID             int32
+	OrganizationID int32
+	Name           string
+	Location       string
+	CostCenter     string
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+}
+
+type InventoryMovement struct {
+	ID            int32
+	ProductID     int32
+	MovementType  string
+	Quantity      int32
+	OnHandDelta   int32
+	ReservedDelta int32
+	ReservationID pgtype.Int4
+	Note          string
+	CreatedBy     pgtype.Int4
+	CreatedAt     pgtype.Timestamptz
+}
+
+type Job struct {
+	ID          int32
+	JobType     string
+	Payload     []byte
+	Status      string
+	Attempts    int32
+	MaxAttempts int32
+	RunAt       pgtype.Timestamptz
+	LockedUntil pgtype.Timestamptz
+	LastError   string
+	CompletedAt pgtype.Timestamptz
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+}
+
+type LoginAttempt struct {
+	ID        int64
+	UserID    pgtype.Int4
+	Email     string
+	Ip        string
+	UserAgent string
+	Success   bool
+	Reason    string
+	CreatedAt pgtype.Timestamptz
+}
+
+type MfaChallenge struct {
+	ID        int32
+	UserID    int32
+	TokenHash string
+	Attempts  int32
+	ExpiresAt pgtype.Timestamptz
+	UsedAt    pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+}
+
+type MfaRecoveryCode struct {
+	ID        int32
+	UserID    int32
+	CodeHash  string
+	UsedAt    pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+}
+
+type Notification struct {
+	ID        int32
+	UserID    int32
+	EventType string
+	Subject   string
+	Body      string
+	ReadAt    pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+}
+
+type NotificationPreference struct {
+	UserID       int32
+	EmailEnabled bool
+	InAppEnabled bool
+	UpdatedAt    pgtype.Timestamptz
+}
+
+type Organization struct {
 	ID        int32
 	Name      string
-	Username  string
-	Email     string
-	Password  string
-	Role      string
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+	TenantID  int32
+}
+
+type OrganizationMember struct {
+	UserID         int32
+	OrganizationID int32
+	DepartmentID   pgtype.Int4
+	Role           string
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+}
+
+type PasswordResetToken struct {
+	ID        int32
+	UserID    int32
+	TokenHash string
+	ExpiresAt pgtype.Timestamptz
+	UsedAt    pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+}
+
+type Product struct {
+	ID                int32
+	VendorID          int32
+	Name              string
+	Price             int32
+	Stock             int32
+	CreatedAt         pgtype.Timestamptz
+	UpdatedAt         pgtype.Timestamptz
+	Sku               pgtype.Text
+	Description       string
+	Category          string
+	LeadTimeDays      int32
+	MinOrderQuantity  int32
+	ReservedStock     int32
+	LowStockThreshold pgtype.Int4
+	TenantID          int32
+}
+
+type ProductSubscription struct {
+	UserID    int32
+	ProductID int32
+	CreatedAt pgtype.Timestamptz
+}
+
+type PurchaseOrder struct {
+	ID             int32
+	BuyerID        int32
+	VendorID       int32
+	Status         string
+	TotalAmount    int64
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+	ExpiresAt      pgtype.Timestamptz
+	OrganizationID pgtype.Int4
+	DepartmentID   pgtype.Int4
+	TenantID       int32
+}
+
+type PurchaseOrderItem struct {
+	ID              int32
+	PurchaseOrderID int32
+	ProductID       pgtype.Int4
+	ProductName     string
+	UnitPrice       int32
+	Quantity        int32
+	CreatedAt       pgtype.Timestamptz
+}
+
+type RateLimitBucket struct {
+	Key    string
+	FullAt pgtype.Timestamptz
+}
+
+type RefreshToken struct {
+	ID        int32
+	SessionID int32
+	TokenHash string
+	UsedAt    pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+}
+
+type Rfq struct {
+	ID        int32
+	TenantID  int32
+	BuyerID   int32
+	Title     string
 	Status    string
+	Deadline  pgtype.Timestamptz
+	ClosedAt  pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+}
+
+type Role struct {
+	ID          int32
+	Name        string
+	Description string
+	BuiltIn     bool
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+}
+
+type RolePermission struct {
+	RoleID     int32
+	Permission string
+}
+
+type Session struct {
+	ID            int32
+	UserID        int32
+	UserAgent     string
+	Ip            string
+	LastUsedAt    pgtype.Timestamptz
+	ExpiresAt     pgtype.Timestamptz
+	RevokedAt     pgtype.Timestamptz
+	RevokedReason string
+	CreatedAt     pgtype.Timestamptz
+}
+
+type StockAlert struct {
+	ID        int32
+	UserID    int32
+	ProductID int32
+	AlertType string
+	Available int32
+	CreatedAt pgtype.Timestamptz
+}
+
+type StockReservation struct {
+	ID              int32
+	ProductID       int32
+	PurchaseOrderID int32
+	Quantity        int32
+	Status          string
+	CreatedAt       pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+}
+
+type Tenant struct {
+	ID        int32
+	Name      string
+	Slug      string
+	CreatedAt pgtype.Timestamptz
+}
+
+type User struct {
+	ID                 int32
+	Name               string
+	Username           string
+	Email              string
+	Password           string
+	Status             string
+	CreatedAt          pgtype.Timestamptz
+	UpdatedAt          pgtype.Timestamptz
+	PasswordChangedAt  pgtype.Timestamptz
+	EmailVerifiedAt    pgtype.Timestamptz
+	VerificationSentAt pgtype.Timestamptz
+	TokenVersion       int32
+	FailedLogins       int32
+	LockedUntil        pgtype.Timestamptz
+	TenantID           int32
+}
+
+type UserMfa struct {
+	UserID      int32
+	Secret      string
+	ConfirmedAt pgtype.Timestamptz
+	LastStep    int64
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+}
+
+type UserRole struct {
+	UserID    int32
+	RoleID    int32
+	CreatedAt pgtype.Timestamptz
+}
+
+type VendorDocument struct {
+	ID         int32
+	TenantID   int32
+	VendorID   int32
+	Name       string
+	ExpiresAt  pgtype.Timestamptz
+	RemindedAt pgtype.Timestamptz
+	CreatedAt  pgtype.Timestamptz
+	UpdatedAt  pgtype.Timestamptz
+}
+
+type WebhookDelivery struct {
+	ID             int32
+	SubscriptionID int32
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int32
+	NextAttemptAt  pgtype.Timestamptz
+	LastStatusCode pgtype.Int4
+	LastError      string
+	DeliveredAt    pgtype.Timestamptz
+	RedeliveryOf   pgtype.Int4
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+}
+
+type WebhookSubscription struct {
+	ID        int32
+	Url       string
+	Secret    string
+	Events    []string
+	Active    bool
+	CreatedBy pgtype.Int4
 	CreatedAt pgtype.Timestamptz
 	UpdatedAt pgtype.Timestamptz
 }
@@ -11,25 +11,99 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countProducts = `-- name: CountProducts :one
+SELECT count(*)
+FROM products p
+LEFT JOIN users u ON p.vendor_id = u.id
+WHERE
+    ($1::text IS NULL OR product_search_matches(p.name, p.sku, p.description, u.name, $1::text))
+    AND ($2::int IS NULL OR p.price >= $2::int)
+    AND ($3::int IS NULL OR p.price <= $3::int)
+    AND ($4::int IS NULL OR p.vendor_id = $4::int)
+    AND ($5::text IS NULL OR p.category = $5::text)
+    AND (NOT $6::boolean OR p.stock > p.reserved_stock)
+    AND ($7::int IS NULL OR p.lead_time_days <= $7::int)
+    AND ($8::int IS NULL OR p.tenant_id = $8::int)
+`
+
+type CountProductsParams struct {
+	Query           pgtype.Text
+	MinPrice        pgtype.Int4
+	MaxPrice        pgtype.Int4
+	VendorID        pgtype.Int4
+	Category        pgtype.Text
+	InStock         bool
+	MaxLeadTimeDays pgtype.Int4
+	TenantID        pgtype.Int4
+}
+
+func (q *Queries) CountProducts(ctx context.Context, arg CountProductsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countProducts,
+		arg.Query,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.VendorID,
+		arg.Category,
+		arg.InStock,
+		arg.MaxLeadTimeDays,
+		arg.TenantID,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countProductsByVendorID = `-- name: CountProductsByVendorID :one
+SELECT count(*) FROM products
+WHERE vendor_id = $1
+    AND ($2::int IS NULL OR tenant_id = $2::int)
+`
+
+type CountProductsByVendorIDParams struct {
+	VendorID int32
+	TenantID pgtype.Int4
+}
+
+func (q *Queries) CountProductsByVendorID(ctx context.Context, arg CountProductsByVendorIDParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countProductsByVendorID, arg.VendorID, arg.TenantID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createProduct = `-- name: CreateProduct :one
-INSERT INTO products (vendor_id, name, price, stock)
-VALUES ($1, $2, $3, $4)
-RETURNING id, vendor_id, name, price, stock, created_at, updated_at
+INSERT INTO products (tenant_id, vendor_id, name, sku, description, category, price, stock, lead_time_days, min_order_quantity, low_stock_threshold)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, vendor_id, name, price, stock, created_at, updated_at, sku, description, category, lead_time_days, min_order_quantity, reserved_stock, low_stock_threshold, tenant_id
 `
 
 type CreateProductParams struct {
-	VendorID int32
-	Name     string
-	Price    int32
-	Stock    int32
+	TenantID          int32
+	VendorID          int32
+	Name              string
+	Sku               pgtype.Text
+	Description       string
+	Category          string
+	Price             int32
+	Stock             int32
+	LeadTimeDays      int32
+	MinOrderQuantity  int32
+	LowStockThreshold pgtype.Int4
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error) {
 	row := q.db.QueryRow(ctx, createProduct,
+		arg.TenantID,
 		arg.VendorID,
 		arg.Name,
+		arg.Sku,
+		arg.Description,
+		arg.Category,
 		arg.Price,
 		arg.Stock,
+		arg.LeadTimeDays,
+		arg.MinOrderQuantity,
+		arg.LowStockThreshold,
 	)
 	var i Product
 	err := row.Scan(
@@ -40,6 +114,14 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.Stock,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Sku,
+		&i.Description,
+		&i.Category,
+		&i.LeadTimeDays,
+		&i.MinOrderQuantity,
+		&i.ReservedStock,
+		&i.LowStockThreshold,
+		&i.TenantID,
 	)
 	return i, err
 }
@@ -47,20 +129,33 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 const deleteProduct = `-- name: DeleteProduct :exec
 DELETE FROM products
 WHERE id = $1
+    AND ($2::int IS NULL OR tenant_id = $2::int)
 `
 
-func (q *Queries) DeleteProduct(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteProduct, id)
+type DeleteProductParams struct {
+	ID       int32
+	TenantID pgtype.Int4
+}
+
+func (q *Queries) DeleteProduct(ctx context.Context, arg DeleteProductParams) error {
+	_, err := q.db.Exec(ctx, deleteProduct, arg.ID, arg.TenantID)
 	return err
 }
 
 const getProductByID = `-- name: GetProductByID :one
-SELECT id, vendor_id, name, price, stock, created_at, updated_at FROM products
-WHERE id = $1 LIMIT 1
+SELECT id, vendor_id, name, price, stock, created_at, updated_at, sku, description, category, lead_time_days, min_order_quantity, reserved_stock, low_stock_threshold, tenant_id FROM products
+WHERE id = $1
+    AND ($2::int IS NULL OR tenant_id = $2::int)
+LIMIT 1
 `
 
-func (q *Queries) GetProductByID(ctx context.Context, id int32) (Product, error) {
-	row := q.db.QueryRow(ctx, getProductByID, id)
+type GetProductByIDParams struct {
+	ID       int32
+	TenantID pgtype.Int4
+}
+
+func (q *Queries) GetProductByID(ctx context.Context, arg GetProductByIDParams) (Product, error) {
+	row := q.db.QueryRow(ctx, getProductByID, arg.ID, arg.TenantID)
 	var i Product
 	err := row.Scan(
 		&i.ID,
@@ -70,57 +165,207 @@ func (q *Queries) GetProductByID(ctx context.Context, id int32) (Product, error)
 		&i.Stock,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Sku,
+		&i.Description,
+		&i.Category,
+		&i.LeadTimeDays,
+		&i.MinOrderQuantity,
+		&i.ReservedStock,
+		&i.LowStockThreshold,
+		&i.TenantID,
 	)
 	return i, err
 }
 
-const getProducts = `-- name: GetProducts :many
-SELECT
-    p.id,
-    p.vendor_id,
-    p.name AS product_name,
-    p.price,
-    p.stock,
-    u.id AS user_id,
-    u.name AS vendor_name
+const getProductCategoryFacets = `-- name: GetProductCategoryFacets :many
+SELECT p.category, count(*) AS count
 FROM products p
 LEFT JOIN users u ON p.vendor_id = u.id
-ORDER BY p.id DESC
-LIMIT $1 OFFSET $2
+WHERE
+    ($1::text IS NULL OR product_search_matches(p.name, p.sku, p.description, u.name, $1::text))
+    AND ($2::int IS NULL OR p.price >= $2::int)
+    AND ($3::int IS NULL OR p.price <= $3::int)
+    AND ($4::int IS NULL OR p.vendor_id = $4::int)
+    AND ($5::text IS NULL OR p.category = $5::text)
+    AND (NOT $6::boolean OR p.stock > p.reserved_stock)
+    AND ($7::int IS NULL OR p.lead_time_days <= $7::int)
+    AND ($8::int IS NULL OR p.tenant_id = $8::int)
+    AND p.category <> ''
+GROUP BY p.category
+ORDER BY count DESC, p.category
 `
 
-type GetProductsParams struct {
-	Limit  int32
-	Offset int32
+type GetProductCategoryFacetsParams struct {
+	Query           pgtype.Text
+	MinPrice        pgtype.Int4
+	MaxPrice        pgtype.Int4
+	VendorID        pgtype.Int4
+	Category        pgtype.Text
+	InStock         bool
+	MaxLeadTimeDays pgtype.Int4
+	TenantID        pgtype.Int4
 }
 
-type GetProductsRow struct {
-	ID          int32
-	VendorID    int32
-	ProductName string
-	Price       int32
-	Stock       int32
-	UserID      pgtype.Int4
-	VendorName  pgtype.Text
+type GetProductCategoryFacetsRow struct {
+	Category string
+	Count    int64
 }
 
-func (q *Queries) GetProducts(ctx context.Context, arg GetProductsParams) ([]GetProductsRow, error) {
-	rows, err := q.db.Query(ctx, getProducts, arg.Limit, arg.Offset)
+func (q *Queries) GetProductCategoryFacets(ctx context.Context, arg GetProductCategoryFacetsParams) ([]GetProductCategoryFacetsRow, error) {
+	rows, err := q.db.Query(ctx, getProductCategoryFacets,
+		arg.Query,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.VendorID,
+		arg.Category,
+		arg.InStock,
+		arg.MaxLeadTimeDays,
+		arg.TenantID,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetProductsRow{}
+	items := []GetProductCategoryFacetsRow{}
 	for rows.Next() {
-		var i GetProductsRow
+		var i GetProductCategoryFacetsRow
+		if err := rows.Scan(
+			&i.Category,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductPriceFacets = `-- name: GetProductPriceFacets :many
+SELECT width_bucket(p.price, $1::int[])::int AS bucket, count(*) AS count
+FROM products p
+LEFT JOIN users u ON p.vendor_id = u.id
+WHERE
+    ($2::text IS NULL OR product_search_matches(p.name, p.sku, p.description, u.name, $2::text))
+    AND ($3::int IS NULL OR p.price >= $3::int)
+    AND ($4::int IS NULL OR p.price <= $4::int)
+    AND ($5::int IS NULL OR p.vendor_id = $5::int)
+    AND ($6::text IS NULL OR p.category = $6::text)
+    AND (NOT $7::boolean OR p.stock > p.reserved_stock)
+    AND ($8::int IS NULL OR p.lead_time_days <= $8::int)
+    AND ($9::int IS NULL OR p.tenant_id = $9::int)
+GROUP BY bucket
+ORDER BY bucket
+`
+
+type GetProductPriceFacetsParams struct {
+	Bounds          []int32
+	Query           pgtype.Text
+	MinPrice        pgtype.Int4
+	MaxPrice        pgtype.Int4
+	VendorID        pgtype.Int4
+	Category        pgtype.Text
+	InStock         bool
+	MaxLeadTimeDays pgtype.Int4
+	TenantID        pgtype.Int4
+}
+
+type GetProductPriceFacetsRow struct {
+	Bucket int32
+	Count  int64
+}
+
+func (q *Queries) GetProductPriceFacets(ctx context.Context, arg GetProductPriceFacetsParams) ([]GetProductPriceFacetsRow, error) {
+	rows, err := q.db.Query(ctx, getProductPriceFacets,
+		arg.Bounds,
+		arg.Query,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.VendorID,
+		arg.Category,
+		arg.InStock,
+		arg.MaxLeadTimeDays,
+		arg.TenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProductPriceFacetsRow{}
+	for rows.Next() {
+		var i GetProductPriceFacetsRow
+		if err := rows.Scan(
+			&i.Bucket,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductVendorFacets = `-- name: GetProductVendorFacets :many
+SELECT p.vendor_id, u.name AS vendor_name, count(*) AS count
+FROM products p
+LEFT JOIN users u ON p.vendor_id = u.id
+WHERE
+    ($1::text IS NULL OR product_search_matches(p.name, p.sku, p.description, u.name, $1::text))
+    AND ($2::int IS NULL OR p.price >= $2::int)
+    AND ($3::int IS NULL OR p.price <= $3::int)
+    AND ($4::int IS NULL OR p.vendor_id = $4::int)
+    AND ($5::text IS NULL OR p.category = $5::text)
+    AND (NOT $6::boolean OR p.stock > p.reserved_stock)
+    AND ($7::int IS NULL OR p.lead_time_days <= $7::int)
+    AND ($8::int IS NULL OR p.tenant_id = $8::int)
+GROUP BY p.vendor_id, u.name
+ORDER BY count DESC, p.vendor_id
+`
+
+type GetProductVendorFacetsParams struct {
+	Query           pgtype.Text
+	MinPrice        pgtype.Int4
+	MaxPrice        pgtype.Int4
+	VendorID        pgtype.Int4
+	Category        pgtype.Text
+	InStock         bool
+	MaxLeadTimeDays pgtype.Int4
+	TenantID        pgtype.Int4
+}
+
+type GetProductVendorFacetsRow struct {
+	VendorID   int32
+	VendorName pgtype.Text
+	Count      int64
+}
+
+func (q *Queries) GetProductVendorFacets(ctx context.Context, arg GetProductVendorFacetsParams) ([]GetProductVendorFacetsRow, error) {
+	rows, err := q.db.Query(ctx, getProductVendorFacets,
+		arg.Query,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.VendorID,
+		arg.Category,
+		arg.InStock,
+		arg.MaxLeadTimeDays,
+		arg.TenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProductVendorFacetsRow{}
+	for rows.Next() {
+		var i GetProductVendorFacetsRow
 		if err := rows.Scan(
-			&i.ID,
 			&i.VendorID,
-			&i.ProductName,
-			&i.Price,
-			&i.Stock,
-			&i.UserID,
 			&i.VendorName,
+			&i.Count,
 		); err != nil {
 			return nil, err
 		}
@@ -133,20 +378,28 @@ func (q *Queries) GetProducts(ctx context.Context, arg GetProductsParams) ([]Get
 }
 
 const getProductsByVendorID = `-- name: GetProductsByVendorID :many
-SELECT id, vendor_id, name, price, stock, created_at, updated_at FROM products
+SELECT id, vendor_id, name, price, stock, created_at, updated_at, sku, description, category, lead_time_days, min_order_quantity, reserved_stock, low_stock_threshold, tenant_id FROM products
 WHERE vendor_id = $1
+    AND ($2::int IS NULL OR tenant_id = $2::int)
+    AND ($3::int IS NULL OR id < $3::int)
 ORDER BY id DESC
-LIMIT $2 OFFSET $3
+LIMIT $4
 `
 
 type GetProductsByVendorIDParams struct {
 	VendorID int32
+	TenantID pgtype.Int4
+	CursorID pgtype.Int4
 	Limit    int32
-	Offset   int32
 }
 
 func (q *Queries) GetProductsByVendorID(ctx context.Context, arg GetProductsByVendorIDParams) ([]Product, error) {
-	rows, err := q.db.Query(ctx, getProductsByVendorID, arg.VendorID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, getProductsByVendorID,
+		arg.VendorID,
+		arg.TenantID,
+		arg.CursorID,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -162,6 +415,14 @@ func (q *Queries) GetProductsByVendorID(ctx context.Context, arg GetProductsByVe
 			&i.Stock,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Sku,
+			&i.Description,
+			&i.Category,
+			&i.LeadTimeDays,
+			&i.MinOrderQuantity,
+			&i.ReservedStock,
+			&i.LowStockThreshold,
+			&i.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -173,56 +434,140 @@ func (q *Queries) GetProductsByVendorID(ctx context.Context, arg GetProductsByVe
 	return items, nil
 }
 
-const getProductsWithVendor = `-- name: GetProductsWithVendor :many
-SELECT
-    p.id,
-    p.vendor_id,
-    p.name AS product_name,
-    p.price,
-    p.stock,
-    u.id AS user_id,
-    u.name AS vendor_name
-FROM products p
-LEFT JOIN users u ON p.vendor_id = u.id
+const listProducts = `-- name: ListProducts :many
+WITH catalog AS (
+    SELECT
+        p.id,
+        p.vendor_id,
+        p.name AS product_name,
+        p.sku,
+        p.description,
+        p.category,
+        p.price,
+        p.stock,
+        p.reserved_stock,
+        p.lead_time_days,
+        p.min_order_quantity,
+        p.created_at,
+        p.updated_at,
+        u.name AS vendor_name,
+        coalesce(product_search_rank(p.name, p.sku, p.description, u.name, $1::text), 0)::real AS rank,
+        coalesce(ts_headline(
+            'english',
+            concat_ws(' ', p.name, p.sku, p.description),
+            websearch_to_tsquery('english', $1::text),
+            'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10'
+        ), '')::text AS snippet
+    FROM products p
+    LEFT JOIN users u ON p.vendor_id = u.id
+    WHERE
+        ($1::text IS NULL OR product_search_matches(p.name, p.sku, p.description, u.name, $1::text))
+        AND ($2::int IS NULL OR p.price >= $2::int)
+        AND ($3::int IS NULL OR p.price <= $3::int)
+        AND ($4::int IS NULL OR p.vendor_id = $4::int)
+        AND ($5::text IS NULL OR p.category = $5::text)
+        AND (NOT $6::boolean OR p.stock > p.reserved_stock)
+        AND ($7::int IS NULL OR p.lead_time_days <= $7::int)
+        AND ($8::int IS NULL OR p.tenant_id = $8::int)
+)
+SELECT id, vendor_id, product_name, sku, description, category, price, stock, reserved_stock, lead_time_days, min_order_quantity, created_at, updated_at, vendor_name, rank, snippet
+FROM catalog
 WHERE
-    ($1::text IS NULL OR p.name ILIKE '%' || $1::text || '%')
-ORDER BY p.id DESC
-LIMIT $2 OFFSET $3
+    $9::int IS NULL
+    OR CASE $10::text
+        WHEN 'price_asc' THEN (price, id) > ($11::int, $9::int)
+        WHEN 'price_desc' THEN (price, id) < ($11::int, $9::int)
+        WHEN 'name' THEN (product_name, id) > ($12::text, $9::int)
+        WHEN 'relevance' THEN (rank, id) < ($13::real, $9::int)
+        ELSE id < $9::int
+    END
+ORDER BY
+    CASE WHEN $10::text = 'price_asc' THEN price END ASC,
+    CASE WHEN $10::text = 'price_desc' THEN price END DESC,
+    CASE WHEN $10::text = 'name' THEN product_name END ASC,
+    CASE WHEN $10::text = 'relevance' THEN rank END DESC,
+    CASE WHEN $10::text IN ('price_asc', 'name') THEN id END ASC,
+    id DESC
+LIMIT $14
 `
 
-type GetProductsWithVendorParams struct {
-	Column1 string
-	Limit   int32
-	Offset  int32
+type ListProductsParams struct {
+	Query           pgtype.Text
+	MinPrice        pgtype.Int4
+	MaxPrice        pgtype.Int4
+	VendorID        pgtype.Int4
+	Category        pgtype.Text
+	InStock         bool
+	MaxLeadTimeDays pgtype.Int4
+	TenantID        pgtype.Int4
+	CursorID        pgtype.Int4
+	Sort            string
+	CursorPrice     pgtype.Int4
+	CursorName      pgtype.Text
+	CursorRank      pgtype.Float4
+	Limit           int32
 }
 
-type GetProductsWithVendorRow struct {
-	ID          int32
-	VendorID    int32
-	ProductName string
-	Price       int32
-	Stock       int32
-	UserID      pgtype.Int4
-	VendorName  pgtype.Text
+type ListProductsRow struct {
+	ID               int32
+	VendorID         int32
+	ProductName      string
+	Sku              pgtype.Text
+	Description      string
+	Category         string
+	Price            int32
+	Stock            int32
+	ReservedStock    int32
+	LeadTimeDays     int32
+	MinOrderQuantity int32
+	CreatedAt        pgtype.Timestamptz
+	UpdatedAt        pgtype.Timestamptz
+	VendorName       pgtype.Text
+	Rank             float32
+	Snippet          string
 }
 
-func (q *Queries) GetProductsWithVendor(ctx context.Context, arg GetProductsWithVendorParams) ([]GetProductsWithVendorRow, error) {
-	rows, err := q.db.Query(ctx, getProductsWithVendor, arg.Column1, arg.Limit, arg.Offset)
+func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]ListProductsRow, error) {
+	rows, err := q.db.Query(ctx, listProducts,
+		arg.Query,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.VendorID,
+		arg.Category,
+		arg.InStock,
+		arg.MaxLeadTimeDays,
+		arg.TenantID,
+		arg.CursorID,
+		arg.Sort,
+		arg.CursorPrice,
+		arg.CursorName,
+		arg.CursorRank,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetProductsWithVendorRow{}
+	items := []ListProductsRow{}
 	for rows.Next() {
-		var i GetProductsWithVendorRow
+		var i ListProductsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.VendorID,
 			&i.ProductName,
+			&i.Sku,
+			&i.Description,
+			&i.Category,
 			&i.Price,
 			&i.Stock,
-			&i.UserID,
+			&i.ReservedStock,
+			&i.LeadTimeDays,
+			&i.MinOrderQuantity,
+			&i.CreatedAt,
+			&i.UpdatedAt,
 			&i.VendorName,
+			&i.Rank,
+			&i.Snippet,
 		); err != nil {
 			return nil, err
 		}
@@ -236,23 +581,36 @@ func (q *Queries) GetProductsWithVendor(ctx context.Context, arg GetProductsWith
 
 const updateProduct = `-- name: UpdateProduct :exec
 UPDATE products
-SET name = $2, price = $3, stock = $4
-WHERE id = $1
+SET name = $1, sku = $2, description = $3, category = $4, price = $5, lead_time_days = $6, min_order_quantity = $7, low_stock_threshold = $8, updated_at = CURRENT_TIMESTAMP
+WHERE id = $9
+    AND ($10::int IS NULL OR tenant_id = $10::int)
 `
 
 type UpdateProductParams struct {
-	ID    int32
-	Name  string
-	Price int32
-	Stock int32
+	Name              string
+	Sku               pgtype.Text
+	Description       string
+	Category          string
+	Price             int32
+	LeadTimeDays      int32
+	MinOrderQuantity  int32
+	LowStockThreshold pgtype.Int4
+	ID                int32
+	TenantID          pgtype.Int4
 }
 
 func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) error {
 	_, err := q.db.Exec(ctx, updateProduct,
-		arg.ID,
 		arg.Name,
+		arg.Sku,
+		arg.Description,
+		arg.Category,
 		arg.Price,
-		arg.Stock,
+		arg.LeadTimeDays,
+		arg.MinOrderQuantity,
+		arg.LowStockThreshold,
+		arg.ID,
+		arg.TenantID,
 	)
 	return err
 }
@@ -6,22 +6,186 @@ package postgres
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	AddCartItem(ctx context.Context, arg AddCartItemParams) (CartItem, error)
+	AddRolePermissions(ctx context.Context, arg AddRolePermissionsParams) error
+	AddUserRoleByName(ctx context.Context, arg AddUserRoleByNameParams) error
+	AddUserRoles(ctx context.Context, arg AddUserRolesParams) error
+	AdvisoryUnlock(ctx context.Context, key int64) (bool, error)
+	ApplyInventoryDelta(ctx context.Context, arg ApplyInventoryDeltaParams) error
+	ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ClaimExpiringVendorDocuments(ctx context.Context, arg ClaimExpiringVendorDocumentsParams) ([]VendorDocument, error)
+	ClaimJob(ctx context.Context, arg ClaimJobParams) (Job, error)
+	ClaimVerificationEmail(ctx context.Context, arg ClaimVerificationEmailParams) (int64, error)
+	ClearCart(ctx context.Context, userID int32) error
+	CloseDueRFQs(ctx context.Context, arg CloseDueRFQsParams) ([]Rfq, error)
+	CompleteJob(ctx context.Context, arg CompleteJobParams) error
+	ConfirmUserMFA(ctx context.Context, userID int32) error
+	ConsumePasswordResetToken(ctx context.Context, tokenHash string) (int32, error)
+	CountAuditEvents(ctx context.Context, arg CountAuditEventsParams) (int64, error)
+	CountByRole(ctx context.Context, arg CountByRoleParams) (int64, error)
+	CountInventoryMovements(ctx context.Context, arg CountInventoryMovementsParams) (int64, error)
+	CountJobs(ctx context.Context, status pgtype.Text) (int64, error)
+	CountLoginAttempts(ctx context.Context, arg CountLoginAttemptsParams) (int64, error)
+	CountMFAChallengeAttempt(ctx context.Context, arg CountMFAChallengeAttemptParams) (MfaChallenge, error)
+	CountNotificationsByUserID(ctx context.Context, arg CountNotificationsByUserIDParams) (int64, error)
+	CountOrganizationAdmins(ctx context.Context, arg CountOrganizationAdminsParams) (int64, error)
+	CountOrganizationMembers(ctx context.Context, arg CountOrganizationMembersParams) (int64, error)
+	CountOrganizations(ctx context.Context, tenantID pgtype.Int4) (int64, error)
+	CountProducts(ctx context.Context, arg CountProductsParams) (int64, error)
+	CountProductsByVendorID(ctx context.Context, arg CountProductsByVendorIDParams) (int64, error)
+	CountPurchaseOrdersByBuyerID(ctx context.Context, arg CountPurchaseOrdersByBuyerIDParams) (int64, error)
+	CountPurchaseOrdersByOrganizationID(ctx context.Context, arg CountPurchaseOrdersByOrganizationIDParams) (int64, error)
+	CountPurchaseOrdersByVendorID(ctx context.Context, arg CountPurchaseOrdersByVendorIDParams) (int64, error)
+	CountRecentFailedLoginsByIP(ctx context.Context, arg CountRecentFailedLoginsByIPParams) (CountRecentFailedLoginsByIPRow, error)
+	CountRoleUsers(ctx context.Context, roleID int32) (int64, error)
+	CountStockAlertsByUserID(ctx context.Context, userID int32) (int64, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID int32) (int64, error)
+	CountWebhookDeliveriesBySubscriptionID(ctx context.Context, subscriptionID int32) (int64, error)
+	CountWebhookSubscriptions(ctx context.Context) (int64, error)
+	CreateApproval(ctx context.Context, arg CreateApprovalParams) (Approval, error)
+	CreateAuditCheckpoint(ctx context.Context, arg CreateAuditCheckpointParams) (AuditCheckpoint, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error)
+	CreateDepartment(ctx context.Context, arg CreateDepartmentParams) (Department, error)
+	CreateInventoryMovement(ctx context.Context, arg CreateInventoryMovementParams) (InventoryMovement, error)
+	CreateLoginAttempt(ctx context.Context, arg CreateLoginAttemptParams) error
+	CreateMFAChallenge(ctx context.Context, arg CreateMFAChallengeParams) error
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
+	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (Organization, error)
+	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) error
 	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
+	CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrderParams) (PurchaseOrder, error)
+	CreatePurchaseOrderItem(ctx context.Context, arg CreatePurchaseOrderItemParams) (PurchaseOrderItem, error)
+	CreateRFQ(ctx context.Context, arg CreateRFQParams) (Rfq, error)
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error
+	CreateRole(ctx context.Context, arg CreateRoleParams) (Role, error)
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateStockAlert(ctx context.Context, arg CreateStockAlertParams) error
+	CreateStockReservation(ctx context.Context, arg CreateStockReservationParams) (StockReservation, error)
+	CreateSubscriberStockAlerts(ctx context.Context, arg CreateSubscriberStockAlertsParams) ([]int32, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
-	DeleteProduct(ctx context.Context, id int32) error
-	DeleteUser(ctx context.Context, id int32) error
-	GetAllByRole(ctx context.Context, role string) ([]User, error)
-	GetProductByID(ctx context.Context, id int32) (Product, error)
-	GetProducts(ctx context.Context, arg GetProductsParams) ([]GetProductsRow, error)
+	CreateVendorDocument(ctx context.Context, arg CreateVendorDocumentParams) (VendorDocument, error)
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
+	DecideApproval(ctx context.Context, arg DecideApprovalParams) (Approval, error)
+	DeleteDepartment(ctx context.Context, arg DeleteDepartmentParams) error
+	DeleteExpiredMFAChallenges(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error)
+	DeleteExpiredPasswordResetTokens(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error)
+	DeleteExpiredSessions(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error)
+	DeleteFinishedJobs(ctx context.Context, arg DeleteFinishedJobsParams) (int64, error)
+	DeleteFullRateLimitBuckets(ctx context.Context, fullAt pgtype.Timestamptz) (int64, error)
+	DeleteLoginAttemptsBefore(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
+	DeleteOrganization(ctx context.Context, arg DeleteOrganizationParams) error
+	DeleteOrganizationMember(ctx context.Context, arg DeleteOrganizationMemberParams) error
+	DeleteProduct(ctx context.Context, arg DeleteProductParams) error
+	DeleteRecoveryCodes(ctx context.Context, userID int32) error
+	DeleteRole(ctx context.Context, id int32) error
+	DeleteRolePermissions(ctx context.Context, roleID int32) error
+	DeleteUser(ctx context.Context, arg DeleteUserParams) error
+	DeleteUserMFA(ctx context.Context, userID int32) error
+	DeleteUserRoles(ctx context.Context, userID int32) error
+	DeleteWebhookSubscription(ctx context.Context, id int32) (int64, error)
+	EnqueueJob(ctx context.Context, arg EnqueueJobParams) error
+	EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int64, error)
+	EscalateIdleApprovals(ctx context.Context, arg EscalateIdleApprovalsParams) ([]Approval, error)
+	FailJob(ctx context.Context, arg FailJobParams) error
+	GetAccessState(ctx context.Context, arg GetAccessStateParams) (GetAccessStateRow, error)
+	GetActiveUserSessions(ctx context.Context, userID int32) ([]Session, error)
+	GetAllByRole(ctx context.Context, arg GetAllByRoleParams) ([]User, error)
+	GetApprovalByID(ctx context.Context, arg GetApprovalByIDParams) (Approval, error)
+	GetAuditChain(ctx context.Context, arg GetAuditChainParams) ([]GetAuditChainRow, error)
+	GetAuditCheckpoints(ctx context.Context) ([]AuditCheckpoint, error)
+	GetAuditEvents(ctx context.Context, arg GetAuditEventsParams) ([]AuditEvent, error)
+	GetCartItems(ctx context.Context, userID int32) ([]GetCartItemsRow, error)
+	GetDepartmentByID(ctx context.Context, arg GetDepartmentByIDParams) (Department, error)
+	GetDepartmentByName(ctx context.Context, arg GetDepartmentByNameParams) (Department, error)
+	GetDepartments(ctx context.Context, arg GetDepartmentsParams) ([]Department, error)
+	GetInventoryBalance(ctx context.Context, arg GetInventoryBalanceParams) (GetInventoryBalanceRow, error)
+	GetInventoryMovements(ctx context.Context, arg GetInventoryMovementsParams) ([]InventoryMovement, error)
+	GetJobs(ctx context.Context, arg GetJobsParams) ([]Job, error)
+	GetLoginAttempts(ctx context.Context, arg GetLoginAttemptsParams) ([]LoginAttempt, error)
+	GetMFAChallenge(ctx context.Context, arg GetMFAChallengeParams) (MfaChallenge, error)
+	GetNotificationPreferences(ctx context.Context, userID int32) (NotificationPreference, error)
+	GetNotificationsByUserID(ctx context.Context, arg GetNotificationsByUserIDParams) ([]Notification, error)
+	GetOrganizationByID(ctx context.Context, arg GetOrganizationByIDParams) (Organization, error)
+	GetOrganizationByName(ctx context.Context, arg GetOrganizationByNameParams) (Organization, error)
+	GetOrganizationMember(ctx context.Context, arg GetOrganizationMemberParams) (GetOrganizationMemberRow, error)
+	GetOrganizationMembers(ctx context.Context, arg GetOrganizationMembersParams) ([]GetOrganizationMembersRow, error)
+	GetOrganizations(ctx context.Context, arg GetOrganizationsParams) ([]Organization, error)
+	GetProductByID(ctx context.Context, arg GetProductByIDParams) (Product, error)
+	GetProductCategoryFacets(ctx context.Context, arg GetProductCategoryFacetsParams) ([]GetProductCategoryFacetsRow, error)
+	GetProductPriceFacets(ctx context.Context, arg GetProductPriceFacetsParams) ([]GetProductPriceFacetsRow, error)
+	GetProductVendorFacets(ctx context.Context, arg GetProductVendorFacetsParams) ([]GetProductVendorFacetsRow, error)
 	GetProductsByVendorID(ctx context.Context, arg GetProductsByVendorIDParams) ([]Product, error)
-	GetProductsWithVendor(ctx context.Context, arg GetProductsWithVendorParams) ([]GetProductsWithVendorRow, error)
-	GetUserByEmail(ctx context.Context, email string) (User, error)
-	GetUserByID(ctx context.Context, id int32) (User, error)
+	GetPurchaseOrderByID(ctx context.Context, arg GetPurchaseOrderByIDParams) (PurchaseOrder, error)
+	GetPurchaseOrderItems(ctx context.Context, purchaseOrderID int32) ([]PurchaseOrderItem, error)
+	GetPurchaseOrdersByBuyerID(ctx context.Context, arg GetPurchaseOrdersByBuyerIDParams) ([]PurchaseOrder, error)
+	GetPurchaseOrdersByOrganizationID(ctx context.Context, arg GetPurchaseOrdersByOrganizationIDParams) ([]PurchaseOrder, error)
+	GetPurchaseOrdersByVendorID(ctx context.Context, arg GetPurchaseOrdersByVendorIDParams) ([]PurchaseOrder, error)
+	GetRateLimitBucket(ctx context.Context, key string) (pgtype.Timestamptz, error)
+	GetRefreshTokenForUpdate(ctx context.Context, tokenHash string) (GetRefreshTokenForUpdateRow, error)
+	GetRoleByID(ctx context.Context, id int32) (GetRoleByIDRow, error)
+	GetRoleByName(ctx context.Context, name string) (GetRoleByNameRow, error)
+	GetRoles(ctx context.Context) ([]GetRolesRow, error)
+	GetRolesByUserID(ctx context.Context, userID int32) ([]GetRolesByUserIDRow, error)
+	GetStockAlertsByUserID(ctx context.Context, arg GetStockAlertsByUserIDParams) ([]GetStockAlertsByUserIDRow, error)
+	GetTenantBySlug(ctx context.Context, slug string) (Tenant, error)
+	GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error)
+	GetUserByID(ctx context.Context, arg GetUserByIDParams) (User, error)
+	GetUserIDsWithPermission(ctx context.Context, arg GetUserIDsWithPermissionParams) ([]int32, error)
+	GetUserMFA(ctx context.Context, userID int32) (UserMfa, error)
+	GetUserPermissions(ctx context.Context, userID int32) ([]string, error)
+	GetUserRoleNames(ctx context.Context, userIds []int32) ([]GetUserRoleNamesRow, error)
+	GetWebhookDeliveriesBySubscriptionID(ctx context.Context, arg GetWebhookDeliveriesBySubscriptionIDParams) ([]WebhookDelivery, error)
+	GetWebhookDeliveryByID(ctx context.Context, id int32) (WebhookDelivery, error)
+	GetWebhookSubscriptionByID(ctx context.Context, id int32) (WebhookSubscription, error)
+	GetWebhookSubscriptions(ctx context.Context, arg GetWebhookSubscriptionsParams) ([]WebhookSubscription, error)
+	IncrementFailedLogins(ctx context.Context, arg IncrementFailedLoginsParams) (int32, error)
+	InvalidatePasswordResetTokens(ctx context.Context, userID int32) error
+	ListProducts(ctx context.Context, arg ListProductsParams) ([]ListProductsRow, error)
+	LockCartItems(ctx context.Context, userID int32) ([]LockCartItemsRow, error)
+	LockExpiredPurchaseOrders(ctx context.Context, arg LockExpiredPurchaseOrdersParams) ([]PurchaseOrder, error)
+	LockProduct(ctx context.Context, arg LockProductParams) (Product, error)
+	LockPurchaseOrder(ctx context.Context, arg LockPurchaseOrderParams) (PurchaseOrder, error)
+	LockStockReservations(ctx context.Context, arg LockStockReservationsParams) ([]StockReservation, error)
+	LockUser(ctx context.Context, arg LockUserParams) error
+	MarkAllNotificationsRead(ctx context.Context, userID int32) (int64, error)
+	MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) (int64, error)
+	RecordWebhookAttempt(ctx context.Context, arg RecordWebhookAttemptParams) error
+	RedeliverWebhookDelivery(ctx context.Context, arg RedeliverWebhookDeliveryParams) (WebhookDelivery, error)
+	RemoveCartItem(ctx context.Context, arg RemoveCartItemParams) (int64, error)
+	RequeueJob(ctx context.Context, arg RequeueJobParams) (int64, error)
+	ResetFailedLogins(ctx context.Context, arg ResetFailedLoginsParams) error
+	RevokeSession(ctx context.Context, arg RevokeSessionParams) (int64, error)
+	RevokeUserSession(ctx context.Context, arg RevokeUserSessionParams) (int64, error)
+	RevokeUserSessions(ctx context.Context, arg RevokeUserSessionsParams) (int64, error)
+	SetNotificationRead(ctx context.Context, arg SetNotificationReadParams) (int64, error)
+	SubscribeToProduct(ctx context.Context, arg SubscribeToProductParams) error
+	TakeRateLimitToken(ctx context.Context, arg TakeRateLimitTokenParams) (pgtype.Timestamptz, error)
+	TouchSession(ctx context.Context, arg TouchSessionParams) error
+	TryAdvisoryLock(ctx context.Context, key int64) (bool, error)
+	UnsubscribeFromProduct(ctx context.Context, arg UnsubscribeFromProductParams) (int64, error)
+	UpdateCartItemQuantity(ctx context.Context, arg UpdateCartItemQuantityParams) (int64, error)
+	UpdateDepartment(ctx context.Context, arg UpdateDepartmentParams) (Department, error)
+	UpdateOrganization(ctx context.Context, arg UpdateOrganizationParams) (Organization, error)
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) error
+	UpdatePurchaseOrderStatus(ctx context.Context, arg UpdatePurchaseOrderStatusParams) error
+	UpdateRole(ctx context.Context, arg UpdateRoleParams) (Role, error)
+	UpdateStockReservationStatus(ctx context.Context, arg UpdateStockReservationStatusParams) error
 	UpdateUser(ctx context.Context, arg UpdateUserParams) error
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (int64, error)
+	UpsertNotificationPreferences(ctx context.Context, arg UpsertNotificationPreferencesParams) (NotificationPreference, error)
+	UpsertOrganizationMember(ctx context.Context, arg UpsertOrganizationMemberParams) error
+	UpsertPendingUserMFA(ctx context.Context, arg UpsertPendingUserMFAParams) (int64, error)
+	UseMFAChallenge(ctx context.Context, id int32) (int64, error)
+	UseMFAStep(ctx context.Context, arg UseMFAStepParams) (int64, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)
+	UseRefreshToken(ctx context.Context, id int32) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
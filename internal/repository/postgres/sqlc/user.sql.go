@@ -7,30 +7,79 @@ package postgres
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const claimVerificationEmail = `-- name: ClaimVerificationEmail :execrows
+UPDATE users
+SET verification_sent_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND email_verified_at IS NULL
+    AND (verification_sent_at IS NULL OR verification_sent_at < $2)
+    AND ($3::int IS NULL OR tenant_id = $3::int)
+`
+
+type ClaimVerificationEmailParams struct {
+	ID         int32
+	SentBefore pgtype.Timestamptz
+	TenantID   pgtype.Int4
+}
+
+func (q *Queries) ClaimVerificationEmail(ctx context.Context, arg ClaimVerificationEmailParams) (int64, error) {
+	result, err := q.db.Exec(ctx, claimVerificationEmail, arg.ID, arg.SentBefore, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const countByRole = `-- name: CountByRole :one
+SELECT count(*) FROM users
+WHERE EXISTS (
+        SELECT 1 FROM user_roles ur
+        JOIN roles r ON r.id = ur.role_id
+        WHERE ur.user_id = users.id AND r.name = $1
+    )
+    AND (NOT $2::bool OR email_verified_at IS NOT NULL)
+    AND ($3::int IS NULL OR tenant_id = $3::int)
+`
+
+type CountByRoleParams struct {
+	Role         string
+	VerifiedOnly bool
+	TenantID     pgtype.Int4
+}
+
+func (q *Queries) CountByRole(ctx context.Context, arg CountByRoleParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countByRole, arg.Role, arg.VerifiedOnly, arg.TenantID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
-INSERT INTO users (name, username, email, password, role, status)
+INSERT INTO users (tenant_id, name, username, email, password, status)
 VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, name, username, email, password, role, status, created_at, updated_at
+RETURNING id, name, username, email, password, status, created_at, updated_at, password_changed_at, email_verified_at, verification_sent_at, token_version, failed_logins, locked_until, tenant_id
 `
 
 type CreateUserParams struct {
+	TenantID int32
 	Name     string
 	Username string
 	Email    string
 	Password string
-	Role     string
 	Status   string
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
 	row := q.db.QueryRow(ctx, createUser,
+		arg.TenantID,
 		arg.Name,
 		arg.Username,
 		arg.Email,
 		arg.Password,
-		arg.Role,
 		arg.Status,
 	)
 	var i User
@@ -40,10 +89,16 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Username,
 		&i.Email,
 		&i.Password,
-		&i.Role,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PasswordChangedAt,
+		&i.EmailVerifiedAt,
+		&i.VerificationSentAt,
+		&i.TokenVersion,
+		&i.FailedLogins,
+		&i.LockedUntil,
+		&i.TenantID,
 	)
 	return i, err
 }
@@ -51,20 +106,49 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 const deleteUser = `-- name: DeleteUser :exec
 DELETE FROM users
 WHERE id = $1
+    AND ($2::int IS NULL OR tenant_id = $2::int)
 `
 
-func (q *Queries) DeleteUser(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteUser, id)
+type DeleteUserParams struct {
+	ID       int32
+	TenantID pgtype.Int4
+}
+
+func (q *Queries) DeleteUser(ctx context.Context, arg DeleteUserParams) error {
+	_, err := q.db.Exec(ctx, deleteUser, arg.ID, arg.TenantID)
 	return err
 }
 
 const getAllByRole = `-- name: GetAllByRole :many
-SELECT id, name, username, email, password, role, status, created_at, updated_at FROM users
-WHERE role = $1
+SELECT id, name, username, email, password, status, created_at, updated_at, password_changed_at, email_verified_at, verification_sent_at, token_version, failed_logins, locked_until, tenant_id FROM users
+WHERE EXISTS (
+        SELECT 1 FROM user_roles ur
+        JOIN roles r ON r.id = ur.role_id
+        WHERE ur.user_id = users.id AND r.name = $1
+    )
+    AND (NOT $2::bool OR email_verified_at IS NOT NULL)
+    AND ($3::int IS NULL OR tenant_id = $3::int)
+    AND ($4::int IS NULL OR id < $4::int)
+ORDER BY id DESC
+LIMIT $5
 `
 
-func (q *Queries) GetAllByRole(ctx context.Context, role string) ([]User, error) {
-	rows, err := q.db.Query(ctx, getAllByRole, role)
+type GetAllByRoleParams struct {
+	Role         string
+	VerifiedOnly bool
+	TenantID     pgtype.Int4
+	CursorID     pgtype.Int4
+	Limit        int32
+}
+
+func (q *Queries) GetAllByRole(ctx context.Context, arg GetAllByRoleParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, getAllByRole,
+		arg.Role,
+		arg.VerifiedOnly,
+		arg.TenantID,
+		arg.CursorID,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -78,10 +162,16 @@ func (q *Queries) GetAllByRole(ctx context.Context, role string) ([]User, error)
 			&i.Username,
 			&i.Email,
 			&i.Password,
-			&i.Role,
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PasswordChangedAt,
+			&i.EmailVerifiedAt,
+			&i.VerificationSentAt,
+			&i.TokenVersion,
+			&i.FailedLogins,
+			&i.LockedUntil,
+			&i.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -94,12 +184,19 @@ func (q *Queries) GetAllByRole(ctx context.Context, role string) ([]User, error)
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, name, username, email, password, role, status, created_at, updated_at FROM users
-WHERE email = $1 LIMIT 1
+SELECT id, name, username, email, password, status, created_at, updated_at, password_changed_at, email_verified_at, verification_sent_at, token_version, failed_logins, locked_until, tenant_id FROM users
+WHERE email = $1
+    AND ($2::int IS NULL OR tenant_id = $2::int)
+LIMIT 1
 `
 
-func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
-	row := q.db.QueryRow(ctx, getUserByEmail, email)
+type GetUserByEmailParams struct {
+	Email    string
+	TenantID pgtype.Int4
+}
+
+func (q *Queries) GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, arg.Email, arg.TenantID)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -107,21 +204,34 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Username,
 		&i.Email,
 		&i.Password,
-		&i.Role,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PasswordChangedAt,
+		&i.EmailVerifiedAt,
+		&i.VerificationSentAt,
+		&i.TokenVersion,
+		&i.FailedLogins,
+		&i.LockedUntil,
+		&i.TenantID,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, name, username, email, password, role, status, created_at, updated_at FROM users
-WHERE id = $1 LIMIT 1
+SELECT id, name, username, email, password, status, created_at, updated_at, password_changed_at, email_verified_at, verification_sent_at, token_version, failed_logins, locked_until, tenant_id FROM users
+WHERE id = $1
+    AND ($2::int IS NULL OR tenant_id = $2::int)
+LIMIT 1
 `
 
-func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
-	row := q.db.QueryRow(ctx, getUserByID, id)
+type GetUserByIDParams struct {
+	ID       int32
+	TenantID pgtype.Int4
+}
+
+func (q *Queries) GetUserByID(ctx context.Context, arg GetUserByIDParams) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByID, arg.ID, arg.TenantID)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -129,39 +239,214 @@ func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
 		&i.Username,
 		&i.Email,
 		&i.Password,
-		&i.Role,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PasswordChangedAt,
+		&i.EmailVerifiedAt,
+		&i.VerificationSentAt,
+		&i.TokenVersion,
+		&i.FailedLogins,
+		&i.LockedUntil,
+		&i.TenantID,
 	)
 	return i, err
 }
 
-const updateUser = `-- name: UpdateUser :exec
+const getUserIDsWithPermission = `-- name: GetUserIDsWithPermission :many
+SELECT DISTINCT u.id
+FROM users u
+JOIN user_roles ur ON ur.user_id = u.id
+JOIN role_permissions rp ON rp.role_id = ur.role_id
+WHERE rp.permission = $1
+    AND u.status = 'active'
+    AND ($2::int IS NULL OR u.tenant_id = $2::int)
+ORDER BY u.id
+`
+
+type GetUserIDsWithPermissionParams struct {
+	Permission string
+	TenantID   pgtype.Int4
+}
+
+func (q *Queries) GetUserIDsWithPermission(ctx context.Context, arg GetUserIDsWithPermissionParams) ([]int32, error) {
+	rows, err := q.db.Query(ctx, getUserIDsWithPermission, arg.Permission, arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserRoleNames = `-- name: GetUserRoleNames :many
+SELECT ur.user_id, r.name
+FROM user_roles ur
+JOIN roles r ON r.id = ur.role_id
+WHERE ur.user_id = ANY($1::int[])
+ORDER BY ur.user_id, r.name
+`
+
+type GetUserRoleNamesRow struct {
+	UserID int32
+	Name   string
+}
+
+func (q *Queries) GetUserRoleNames(ctx context.Context, userIds []int32) ([]GetUserRoleNamesRow, error) {
+	rows, err := q.db.Query(ctx, getUserRoleNames, userIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUserRoleNamesRow{}
+	for rows.Next() {
+		var i GetUserRoleNamesRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const incrementFailedLogins = `-- name: IncrementFailedLogins :one
 UPDATE users
-SET name = $2, username = $3, email = $4, password = $5, role = $6, status = $7, updated_at = CURRENT_TIMESTAMP
+SET failed_logins = failed_logins + 1
 WHERE id = $1
+    AND ($2::int IS NULL OR tenant_id = $2::int)
+RETURNING failed_logins
 `
 
-type UpdateUserParams struct {
+type IncrementFailedLoginsParams struct {
+	ID       int32
+	TenantID pgtype.Int4
+}
+
+func (q *Queries) IncrementFailedLogins(ctx context.Context, arg IncrementFailedLoginsParams) (int32, error) {
+	row := q.db.QueryRow(ctx, incrementFailedLogins, arg.ID, arg.TenantID)
+	var failed_logins int32
+	err := row.Scan(&failed_logins)
+	return failed_logins, err
+}
+
+const lockUser = `-- name: LockUser :exec
+UPDATE users
+SET locked_until = GREATEST(locked_until, $1)
+WHERE id = $2
+    AND ($3::int IS NULL OR tenant_id = $3::int)
+`
+
+type LockUserParams struct {
+	LockedUntil pgtype.Timestamptz
+	ID          int32
+	TenantID    pgtype.Int4
+}
+
+func (q *Queries) LockUser(ctx context.Context, arg LockUserParams) error {
+	_, err := q.db.Exec(ctx, lockUser, arg.LockedUntil, arg.ID, arg.TenantID)
+	return err
+}
+
+const markUserEmailVerified = `-- name: MarkUserEmailVerified :execrows
+UPDATE users
+SET email_verified_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND email = $2 AND email_verified_at IS NULL
+    AND ($3::int IS NULL OR tenant_id = $3::int)
+`
+
+type MarkUserEmailVerifiedParams struct {
 	ID       int32
+	Email    string
+	TenantID pgtype.Int4
+}
+
+func (q *Queries) MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markUserEmailVerified, arg.ID, arg.Email, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const resetFailedLogins = `-- name: ResetFailedLogins :exec
+UPDATE users
+SET failed_logins = 0, locked_until = NULL
+WHERE id = $1
+    AND ($2::int IS NULL OR tenant_id = $2::int)
+`
+
+type ResetFailedLoginsParams struct {
+	ID       int32
+	TenantID pgtype.Int4
+}
+
+func (q *Queries) ResetFailedLogins(ctx context.Context, arg ResetFailedLoginsParams) error {
+	_, err := q.db.Exec(ctx, resetFailedLogins, arg.ID, arg.TenantID)
+	return err
+}
+
+const updateUser = `-- name: UpdateUser :exec
+UPDATE users
+SET name = $1, username = $2, email = $3, password = $4, status = $5,
+    email_verified_at = CASE WHEN email = $3 THEN email_verified_at END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $6
+    AND ($7::int IS NULL OR tenant_id = $7::int)
+`
+
+type UpdateUserParams struct {
 	Name     string
 	Username string
 	Email    string
 	Password string
-	Role     string
 	Status   string
+	ID       int32
+	TenantID pgtype.Int4
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
 	_, err := q.db.Exec(ctx, updateUser,
-		arg.ID,
 		arg.Name,
 		arg.Username,
 		arg.Email,
 		arg.Password,
-		arg.Role,
 		arg.Status,
+		arg.ID,
+		arg.TenantID,
 	)
 	return err
 }
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET password = $1, password_changed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = $2
+    AND ($3::int IS NULL OR tenant_id = $3::int)
+`
+
+type UpdateUserPasswordParams struct {
+	Password string
+	ID       int32
+	TenantID pgtype.Int4
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.Exec(ctx, updateUserPassword, arg.Password, arg.ID, arg.TenantID)
+	return err
+}
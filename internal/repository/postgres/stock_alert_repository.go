@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type stockAlertRepository struct {
+	q *postgres.Queries
+}
+
+func NewStockAlertRepository(db *pgxpool.Pool) domain.StockAlertRepository {
+	return &stockAlertRepository{q: postgres.New(db)}
+}
+
+// Subscribe implements domain.StockAlertRepository. Following a product
+// twice is a no-op.
+func (s *stockAlertRepository) Subscribe(userID, productID int64) error {
+	ctx := context.Background()
+	return s.q.SubscribeToProduct(ctx, postgres.SubscribeToProductParams{
+		UserID:    int32(userID),
+		ProductID: int32(productID),
+	})
+}
+
+// Unsubscribe implements domain.StockAlertRepository.
+func (s *stockAlertRepository) Unsubscribe(userID, productID int64) (bool, error) {
+	ctx := context.Background()
+	rows, err := s.q.UnsubscribeFromProduct(ctx, postgres.UnsubscribeFromProductParams{
+		UserID:    int32(userID),
+		ProductID: int32(productID),
+	})
+
+	return rows > 0, err
+}
+
+// GetByUserID implements domain.StockAlertRepository. Alerts are returned
+// newest first.
+func (s *stockAlertRepository) GetByUserID(userID int64, afterID *int64, limit int) ([]domain.StockAlert, error) {
+	ctx := context.Background()
+	params := postgres.GetStockAlertsByUserIDParams{
+		UserID: int32(userID),
+		Limit:  int32(limit),
+	}
+	if afterID != nil {
+		params.CursorID = pgtype.Int4{Int32: int32(*afterID), Valid: true}
+	}
+
+	rows, err := s.q.GetStockAlertsByUserID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]domain.StockAlert, len(rows))
+	for i, row := range rows {
+		alerts[i] = domain.StockAlert{
+			ID:          int64(row.ID),
+			UserID:      int64(row.UserID),
+			ProductID:   int64(row.ProductID),
+			ProductName: row.ProductName,
+			AlertType:   row.AlertType,
+			Available:   int(row.Available),
+			CreatedAt:   row.CreatedAt.Time,
+		}
+	}
+
+	return alerts, nil
+}
+
+// CountByUserID implements domain.StockAlertRepository.
+func (s *stockAlertRepository) CountByUserID(userID int64) (int64, error) {
+	ctx := context.Background()
+	return s.q.CountStockAlertsByUserID(ctx, int32(userID))
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type tenantRepository struct {
+	q *postgres.Queries
+}
+
+func NewTenantRepository(db *pgxpool.Pool) domain.TenantRepository {
+	return &tenantRepository{q: postgres.New(db)}
+}
+
+// GetBySlug implements domain.TenantRepository.
+func (t *tenantRepository) GetBySlug(slug string) (*domain.Tenant, error) {
+	ctx := context.Background()
+	row, err := t.q.GetTenantBySlug(ctx, slug)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Tenant{
+		ID:        int64(row.ID),
+		Name:      row.Name,
+		Slug:      row.Slug,
+		CreatedAt: row.CreatedAt.Time,
+	}, nil
+}
+
+// tenantScope is the tenants a repository's queries match. A repository
+// starts out matching none: it finds nothing and creates nothing until it
+// is scoped to a tenant with ForTenant, or to every tenant with
+// SystemScope.
+type tenantScope struct {
+	tenantID int64
+	system   bool
+}
+
+// tenantArg is the tenant_id argument of the queries of a repository with
+// scope. It is null, matching every tenant, only in the system scope; an
+// unscoped repository passes tenant zero, which matches none.
+func tenantArg(scope tenantScope) pgtype.Int4 {
+	return pgtype.Int4{Int32: int32(scope.tenantID), Valid: !scope.system}
+}
+
+// createTenant is the tenant a row is created in: the one the repository
+// is scoped to, whatever the row says, or the row's own in the system
+// scope. An unscoped repository names tenant zero, which does not exist.
+func createTenant(scope tenantScope, tenantID int64) int32 {
+	if scope.system {
+		return int32(tenantID)
+	}
+	return int32(scope.tenantID)
+}
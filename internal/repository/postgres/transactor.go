@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// database is satisfied by both the pool and a transaction. Repositories
+// that open their own transactions begin them on it, which becomes a
+// savepoint when the repository is already bound to a transaction.
+type database interface {
+	postgres.DBTX
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type transactor struct {
+	db *pgxpool.Pool
+}
+
+func NewTransactor(db *pgxpool.Pool) domain.Transactor {
+	return &transactor{db: db}
+}
+
+// WithinTransaction implements domain.Transactor.
+func (t *transactor) WithinTransaction(tenantID int64, fn func(repos domain.Repositories) error) error {
+	return t.within(func(repos domain.Repositories) error {
+		return fn(repos.ForTenant(tenantID))
+	})
+}
+
+// WithinSystemTransaction implements domain.Transactor.
+func (t *transactor) WithinSystemTransaction(fn func(repos domain.Repositories) error) error {
+	return t.within(func(repos domain.Repositories) error {
+		return fn(repos.SystemScope())
+	})
+}
+
+// within runs fn in a transaction with unscoped repositories, which match
+// no tenant until fn's caller scopes them.
+func (t *transactor) within(fn func(repos domain.Repositories) error) error {
+	ctx := context.Background()
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	q := postgres.New(tx)
+	repos := domain.Repositories{
+		Users:           &userRepository{q: q},
+		Products:        &productRepository{db: tx, q: q},
+		Carts:           &cartRepository{db: tx, q: q},
+		PurchaseOrders:  &purchaseOrderRepository{db: tx, q: q},
+		Notifications:   &notificationRepository{q: q},
+		Jobs:            &jobRepository{q: q},
+		Audit:           &auditRepository{q: q},
+		PasswordResets:  &passwordResetRepository{q: q},
+		MFA:             &mfaRepository{q: q},
+		Sessions:        &sessionRepository{q: q},
+		Roles:           &roleRepository{q: q},
+		Organizations:   &organizationRepository{q: q},
+		RFQs:            &rfqRepository{q: q},
+		Approvals:       &approvalRepository{q: q},
+		VendorDocuments: &vendorDocumentRepository{q: q},
+	}
+	if err := fn(repos); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
 
@@ -10,93 +12,139 @@ import (
 )
 
 type userRepository struct {
-	q *postgres.Queries
+	q     *postgres.Queries
+	scope tenantScope
 }
 
 func NewUserRepository(db *pgxpool.Pool) domain.UserRepository {
 	return &userRepository{q: postgres.New(db)}
 }
 
+// ForTenant implements domain.UserRepository.
+func (u *userRepository) ForTenant(tenantID int64) domain.UserRepository {
+	return &userRepository{q: u.q, scope: tenantScope{tenantID: tenantID}}
+}
+
+// SystemScope implements domain.UserRepository.
+func (u *userRepository) SystemScope() domain.UserRepository {
+	return &userRepository{q: u.q, scope: tenantScope{system: true}}
+}
+
 // Create implements domain.UserRepository.
 func (u *userRepository) Create(user *domain.User) error {
 	ctx := context.Background()
-	_, err := u.q.CreateUser(ctx, postgres.CreateUserParams{
+	created, err := u.q.CreateUser(ctx, postgres.CreateUserParams{
+		TenantID: createTenant(u.scope, user.TenantID),
 		Name:     user.Name,
 		Username: user.Username,
 		Email:    user.Email,
 		Password: user.Password,
-		Role:     user.Role,
 		Status:   user.Status,
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	user.ID = int64(created.ID)
+	user.TenantID = int64(created.TenantID)
+	user.CreatedAt = created.CreatedAt.Time
+	user.UpdatedAt = created.UpdatedAt.Time
+	return nil
 }
 
 // GetByID implements domain.UserRepository.
 func (u *userRepository) GetByID(id int64) (*domain.User, error) {
 	ctx := context.Background()
-	dbUser, err := u.q.GetUserByID(ctx, int32(id))
+	dbUser, err := u.q.GetUserByID(ctx, postgres.GetUserByIDParams{
+		ID:       int32(id),
+		TenantID: tenantArg(u.scope),
+	})
 	if err != nil {
 		return nil, err
 	}
-	return &domain.User{
-		ID:        int64(dbUser.ID),
-		Name:      dbUser.Name,
-		Username:  dbUser.Username,
-		Email:     dbUser.Email,
-		Password:  dbUser.Password,
-		Role:      dbUser.Role,
-		Status:    dbUser.Status,
-		CreatedAt: dbUser.CreatedAt.Time,
-		UpdatedAt: dbUser.UpdatedAt.Time,
-	}, nil
+	user := toDomainUser(dbUser)
+	if err := u.loadRoles(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
 }
 
 // GetByEmail implements domain.UserRepository.
 func (u *userRepository) GetByEmail(email string) (*domain.User, error) {
 	ctx := context.Background()
-	dbUser, err := u.q.GetUserByEmail(ctx, email)
+	dbUser, err := u.q.GetUserByEmail(ctx, postgres.GetUserByEmailParams{
+		Email:    email,
+		TenantID: tenantArg(u.scope),
+	})
 	if err != nil {
 		return nil, err
 	}
-	return &domain.User{
-		ID:        int64(dbUser.ID),
-		Name:      dbUser.Name,
-		Username:  dbUser.Username,
-		Email:     dbUser.Email,
-		Password:  dbUser.Password,
-		Role:      dbUser.Role,
-		Status:    dbUser.Status,
-		CreatedAt: dbUser.CreatedAt.Time,
-		UpdatedAt: dbUser.UpdatedAt.Time,
-	}, nil
+	user := toDomainUser(dbUser)
+	if err := u.loadRoles(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
 }
 
 // GetAllByRole implements domain.UserRepository.
-func (u *userRepository) GetAllByRole(role string) ([]*domain.User, error) {
+func (u *userRepository) GetAllByRole(role string, verifiedOnly bool, afterID *int64, limit int) ([]*domain.User, error) {
 	ctx := context.Background()
-	dbUsers, err := u.q.GetAllByRole(ctx, role)
+	params := postgres.GetAllByRoleParams{
+		Role:         role,
+		VerifiedOnly: verifiedOnly,
+		TenantID:     tenantArg(u.scope),
+		Limit:        int32(limit),
+	}
+	if afterID != nil {
+		params.CursorID = pgtype.Int4{Int32: int32(*afterID), Valid: true}
+	}
+
+	dbUsers, err := u.q.GetAllByRole(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
 	users := make([]*domain.User, len(dbUsers))
 	for i, dbUser := range dbUsers {
-		users[i] = &domain.User{
-			ID:        int64(dbUser.ID),
-			Name:      dbUser.Name,
-			Username:  dbUser.Username,
-			Email:     dbUser.Email,
-			Role:      dbUser.Role,
-			Status:    dbUser.Status,
-			CreatedAt: dbUser.CreatedAt.Time,
-			UpdatedAt: dbUser.UpdatedAt.Time,
-		}
+		users[i] = toDomainUser(dbUser)
+		users[i].Password = ""
+	}
+	if err := u.loadRoles(ctx, users...); err != nil {
+		return nil, err
 	}
 
 	return users, nil
 }
 
+// CountByRole implements domain.UserRepository.
+func (u *userRepository) CountByRole(role string, verifiedOnly bool) (int64, error) {
+	ctx := context.Background()
+	return u.q.CountByRole(ctx, postgres.CountByRoleParams{
+		Role:         role,
+		VerifiedOnly: verifiedOnly,
+		TenantID:     tenantArg(u.scope),
+	})
+}
+
+// GetIDsWithPermission implements domain.UserRepository.
+func (u *userRepository) GetIDsWithPermission(permission string) ([]int64, error) {
+	ctx := context.Background()
+	ids, err := u.q.GetUserIDsWithPermission(ctx, postgres.GetUserIDsWithPermissionParams{
+		Permission: permission,
+		TenantID:   tenantArg(u.scope),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]int64, len(ids))
+	for i, id := range ids {
+		userIDs[i] = int64(id)
+	}
+	return userIDs, nil
+}
+
+// Update implements domain.UserRepository.
 func (u *userRepository) Update(user *domain.User) error {
 	ctx := context.Background()
 	return u.q.UpdateUser(ctx, postgres.UpdateUserParams{
@@ -105,13 +153,130 @@ func (u *userRepository) Update(user *domain.User) error {
 		Username: user.Username,
 		Email:    user.Email,
 		Password: user.Password,
-		Role:     user.Role,
 		Status:   user.Status,
+		TenantID: tenantArg(u.scope),
+	})
+}
+
+// UpdatePassword implements domain.UserRepository.
+func (u *userRepository) UpdatePassword(id int64, passwordHash string) error {
+	ctx := context.Background()
+	return u.q.UpdateUserPassword(ctx, postgres.UpdateUserPasswordParams{
+		ID:       int32(id),
+		Password: passwordHash,
+		TenantID: tenantArg(u.scope),
+	})
+}
+
+// MarkEmailVerified implements domain.UserRepository.
+func (u *userRepository) MarkEmailVerified(id int64, email string) (bool, error) {
+	ctx := context.Background()
+	count, err := u.q.MarkUserEmailVerified(ctx, postgres.MarkUserEmailVerifiedParams{
+		ID:       int32(id),
+		Email:    email,
+		TenantID: tenantArg(u.scope),
+	})
+	return count > 0, err
+}
+
+// ClaimVerificationEmail implements domain.UserRepository.
+func (u *userRepository) ClaimVerificationEmail(id int64, sentBefore time.Time) (bool, error) {
+	ctx := context.Background()
+	count, err := u.q.ClaimVerificationEmail(ctx, postgres.ClaimVerificationEmailParams{
+		ID:         int32(id),
+		SentBefore: pgtype.Timestamptz{Time: sentBefore, Valid: true},
+		TenantID:   tenantArg(u.scope),
+	})
+	return count > 0, err
+}
+
+// IncrementFailedLogins implements domain.UserRepository.
+func (u *userRepository) IncrementFailedLogins(id int64) (int, error) {
+	ctx := context.Background()
+	failures, err := u.q.IncrementFailedLogins(ctx, postgres.IncrementFailedLoginsParams{
+		ID:       int32(id),
+		TenantID: tenantArg(u.scope),
+	})
+	return int(failures), err
+}
+
+// Lock implements domain.UserRepository.
+func (u *userRepository) Lock(id int64, until time.Time) error {
+	ctx := context.Background()
+	return u.q.LockUser(ctx, postgres.LockUserParams{
+		ID:          int32(id),
+		LockedUntil: pgtype.Timestamptz{Time: until, Valid: true},
+		TenantID:    tenantArg(u.scope),
+	})
+}
+
+// ResetFailedLogins implements domain.UserRepository.
+func (u *userRepository) ResetFailedLogins(id int64) error {
+	ctx := context.Background()
+	return u.q.ResetFailedLogins(ctx, postgres.ResetFailedLoginsParams{
+		ID:       int32(id),
+		TenantID: tenantArg(u.scope),
 	})
 }
 
 // Delete implements domain.UserRepository.
 func (u *userRepository) Delete(id int64) error {
 	ctx := context.Background()
-	return u.q.DeleteUser(ctx, int32(id))
+	return u.q.DeleteUser(ctx, postgres.DeleteUserParams{
+		ID:       int32(id),
+		TenantID: tenantArg(u.scope),
+	})
+}
+
+// loadRoles sets the role names of users from user_roles.
+func (u *userRepository) loadRoles(ctx context.Context, users ...*domain.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+	ids := make([]int32, len(users))
+	byID := make(map[int64]*domain.User, len(users))
+	for i, user := range users {
+		ids[i] = int32(user.ID)
+		byID[user.ID] = user
+		user.Roles = []string{}
+	}
+
+	rows, err := u.q.GetUserRoleNames(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		user := byID[int64(row.UserID)]
+		user.Roles = append(user.Roles, row.Name)
+	}
+	return nil
+}
+
+func toDomainUser(dbUser postgres.User) *domain.User {
+	user := &domain.User{
+		ID:           int64(dbUser.ID),
+		Name:         dbUser.Name,
+		Username:     dbUser.Username,
+		Email:        dbUser.Email,
+		Password:     dbUser.Password,
+		Status:       dbUser.Status,
+		CreatedAt:    dbUser.CreatedAt.Time,
+		UpdatedAt:    dbUser.UpdatedAt.Time,
+		TokenVersion: int(dbUser.TokenVersion),
+		FailedLogins: int(dbUser.FailedLogins),
+		TenantID:     int64(dbUser.TenantID),
+	}
+	if dbUser.LockedUntil.Valid {
+		lockedUntil := dbUser.LockedUntil.Time
+		user.LockedUntil = &lockedUntil
+	}
+	if dbUser.EmailVerifiedAt.Valid {
+		emailVerifiedAt := dbUser.EmailVerifiedAt.Time
+		user.EmailVerifiedAt = &emailVerifiedAt
+	}
+	if dbUser.PasswordChangedAt.Valid {
+		passwordChangedAt := dbUser.PasswordChangedAt.Time
+		user.PasswordChangedAt = &passwordChangedAt
+	}
+	return user
 }
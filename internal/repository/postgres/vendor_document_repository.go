@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+)
+
+type vendorDocumentRepository struct {
+	q     *postgres.Queries
+	scope tenantScope
+}
+
+// ForTenant implements domain.VendorDocumentRepository.
+func (v *vendorDocumentRepository) ForTenant(tenantID int64) domain.VendorDocumentRepository {
+	return &vendorDocumentRepository{q: v.q, scope: tenantScope{tenantID: tenantID}}
+}
+
+// SystemScope implements domain.VendorDocumentRepository.
+func (v *vendorDocumentRepository) SystemScope() domain.VendorDocumentRepository {
+	return &vendorDocumentRepository{q: v.q, scope: tenantScope{system: true}}
+}
+
+// Create implements domain.VendorDocumentRepository.
+func (v *vendorDocumentRepository) Create(document *domain.VendorDocument) error {
+	ctx := context.Background()
+	created, err := v.q.CreateVendorDocument(ctx, postgres.CreateVendorDocumentParams{
+		TenantID:  createTenant(v.scope, document.TenantID),
+		VendorID:  int32(document.VendorID),
+		Name:      document.Name,
+		ExpiresAt: pgtype.Timestamptz{Time: document.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	*document = toDomainVendorDocument(created)
+	return nil
+}
+
+// ClaimExpiring implements domain.VendorDocumentRepository.
+func (v *vendorDocumentRepository) ClaimExpiring(expiresBefore time.Time, limit int) ([]domain.VendorDocument, error) {
+	ctx := context.Background()
+	rows, err := v.q.ClaimExpiringVendorDocuments(ctx, postgres.ClaimExpiringVendorDocumentsParams{
+		ExpiresBefore: pgtype.Timestamptz{Time: expiresBefore, Valid: true},
+		TenantID:      tenantArg(v.scope),
+		Limit:         int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]domain.VendorDocument, len(rows))
+	for i, row := range rows {
+		documents[i] = toDomainVendorDocument(row)
+	}
+	return documents, nil
+}
+
+func toDomainVendorDocument(row postgres.VendorDocument) domain.VendorDocument {
+	document := domain.VendorDocument{
+		ID:        int64(row.ID),
+		VendorID:  int64(row.VendorID),
+		Name:      row.Name,
+		ExpiresAt: row.ExpiresAt.Time,
+		TenantID:  int64(row.TenantID),
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+	if row.RemindedAt.Valid {
+		remindedAt := row.RemindedAt.Time
+		document.RemindedAt = &remindedAt
+	}
+	return document
+}
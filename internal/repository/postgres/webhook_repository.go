@@ -0,0 +1,255 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	postgres "github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type webhookRepository struct {
+	q *postgres.Queries
+}
+
+func NewWebhookRepository(db *pgxpool.Pool) domain.WebhookRepository {
+	return &webhookRepository{q: postgres.New(db)}
+}
+
+// CreateSubscription implements domain.WebhookRepository.
+func (w *webhookRepository) CreateSubscription(subscription *domain.WebhookSubscription) error {
+	ctx := context.Background()
+	params := postgres.CreateWebhookSubscriptionParams{
+		Url:    subscription.URL,
+		Secret: subscription.Secret,
+		Events: subscription.Events,
+		Active: subscription.Active,
+	}
+	if subscription.CreatedBy != nil {
+		params.CreatedBy = pgtype.Int4{Int32: int32(*subscription.CreatedBy), Valid: true}
+	}
+
+	created, err := w.q.CreateWebhookSubscription(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	*subscription = toDomainWebhookSubscription(created)
+	return nil
+}
+
+// GetSubscriptionByID implements domain.WebhookRepository.
+func (w *webhookRepository) GetSubscriptionByID(id int64) (*domain.WebhookSubscription, error) {
+	ctx := context.Background()
+	subscription, err := w.q.GetWebhookSubscriptionByID(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	domainSubscription := toDomainWebhookSubscription(subscription)
+	return &domainSubscription, nil
+}
+
+// GetSubscriptions implements domain.WebhookRepository.
+func (w *webhookRepository) GetSubscriptions(afterID *int64, limit int) ([]domain.WebhookSubscription, error) {
+	ctx := context.Background()
+	params := postgres.GetWebhookSubscriptionsParams{Limit: int32(limit)}
+	if afterID != nil {
+		params.CursorID = pgtype.Int4{Int32: int32(*afterID), Valid: true}
+	}
+
+	subscriptions, err := w.q.GetWebhookSubscriptions(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	domainSubscriptions := make([]domain.WebhookSubscription, len(subscriptions))
+	for i, subscription := range subscriptions {
+		domainSubscriptions[i] = toDomainWebhookSubscription(subscription)
+	}
+
+	return domainSubscriptions, nil
+}
+
+// CountSubscriptions implements domain.WebhookRepository.
+func (w *webhookRepository) CountSubscriptions() (int64, error) {
+	ctx := context.Background()
+	return w.q.CountWebhookSubscriptions(ctx)
+}
+
+// UpdateSubscription implements domain.WebhookRepository.
+func (w *webhookRepository) UpdateSubscription(subscription *domain.WebhookSubscription) (bool, error) {
+	ctx := context.Background()
+	rows, err := w.q.UpdateWebhookSubscription(ctx, postgres.UpdateWebhookSubscriptionParams{
+		ID:     int32(subscription.ID),
+		Url:    subscription.URL,
+		Events: subscription.Events,
+		Active: subscription.Active,
+	})
+
+	return rows > 0, err
+}
+
+// DeleteSubscription implements domain.WebhookRepository. The delivery log
+// of the subscription is deleted with it.
+func (w *webhookRepository) DeleteSubscription(id int64) (bool, error) {
+	ctx := context.Background()
+	rows, err := w.q.DeleteWebhookSubscription(ctx, int32(id))
+
+	return rows > 0, err
+}
+
+// Enqueue implements domain.WebhookRepository.
+func (w *webhookRepository) Enqueue(event string, payload []byte) (int64, error) {
+	ctx := context.Background()
+	return w.q.EnqueueWebhookDeliveries(ctx, postgres.EnqueueWebhookDeliveriesParams{
+		EventType: event,
+		Payload:   payload,
+		Status:    domain.WebhookDeliveryPending,
+	})
+}
+
+// Redeliver implements domain.WebhookRepository. A new pending delivery is
+// created so the log of the original one is kept.
+func (w *webhookRepository) Redeliver(deliveryID int64) (*domain.WebhookDelivery, error) {
+	ctx := context.Background()
+	delivery, err := w.q.RedeliverWebhookDelivery(ctx, postgres.RedeliverWebhookDeliveryParams{
+		ID:     int32(deliveryID),
+		Status: domain.WebhookDeliveryPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	domainDelivery := toDomainWebhookDelivery(delivery)
+	return &domainDelivery, nil
+}
+
+// GetDeliveryByID implements domain.WebhookRepository.
+func (w *webhookRepository) GetDeliveryByID(id int64) (*domain.WebhookDelivery, error) {
+	ctx := context.Background()
+	delivery, err := w.q.GetWebhookDeliveryByID(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	domainDelivery := toDomainWebhookDelivery(delivery)
+	return &domainDelivery, nil
+}
+
+// GetDeliveries implements domain.WebhookRepository. Deliveries are returned
+// newest first.
+func (w *webhookRepository) GetDeliveries(subscriptionID int64, afterID *int64, limit int) ([]domain.WebhookDelivery, error) {
+	ctx := context.Background()
+	params := postgres.GetWebhookDeliveriesBySubscriptionIDParams{
+		SubscriptionID: int32(subscriptionID),
+		Limit:          int32(limit),
+	}
+	if afterID != nil {
+		params.CursorID = pgtype.Int4{Int32: int32(*afterID), Valid: true}
+	}
+
+	deliveries, err := w.q.GetWebhookDeliveriesBySubscriptionID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	domainDeliveries := make([]domain.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		domainDeliveries[i] = toDomainWebhookDelivery(delivery)
+	}
+
+	return domainDeliveries, nil
+}
+
+// CountDeliveries implements domain.WebhookRepository.
+func (w *webhookRepository) CountDeliveries(subscriptionID int64) (int64, error) {
+	ctx := context.Background()
+	return w.q.CountWebhookDeliveriesBySubscriptionID(ctx, int32(subscriptionID))
+}
+
+// ClaimDue implements domain.WebhookRepository.
+func (w *webhookRepository) ClaimDue(limit int, lease time.Duration) ([]domain.WebhookDelivery, error) {
+	ctx := context.Background()
+	deliveries, err := w.q.ClaimDueWebhookDeliveries(ctx, postgres.ClaimDueWebhookDeliveriesParams{
+		LeaseSeconds: int32(lease.Seconds()),
+		Status:       domain.WebhookDeliveryPending,
+		Limit:        int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	domainDeliveries := make([]domain.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		domainDeliveries[i] = toDomainWebhookDelivery(delivery)
+	}
+
+	return domainDeliveries, nil
+}
+
+// RecordAttempt implements domain.WebhookRepository.
+func (w *webhookRepository) RecordAttempt(id int64, attempt domain.WebhookAttempt) error {
+	ctx := context.Background()
+	params := postgres.RecordWebhookAttemptParams{
+		ID:            int32(id),
+		Status:        attempt.Status,
+		NextAttemptAt: pgtype.Timestamptz{Time: attempt.NextAttemptAt, Valid: true},
+		LastError:     attempt.Error,
+	}
+	if attempt.StatusCode != nil {
+		params.LastStatusCode = pgtype.Int4{Int32: int32(*attempt.StatusCode), Valid: true}
+	}
+	if attempt.DeliveredAt != nil {
+		params.DeliveredAt = pgtype.Timestamptz{Time: *attempt.DeliveredAt, Valid: true}
+	}
+
+	return w.q.RecordWebhookAttempt(ctx, params)
+}
+
+func toDomainWebhookSubscription(subscription postgres.WebhookSubscription) domain.WebhookSubscription {
+	domainSubscription := domain.WebhookSubscription{
+		ID:        int64(subscription.ID),
+		URL:       subscription.Url,
+		Events:    subscription.Events,
+		Active:    subscription.Active,
+		Secret:    subscription.Secret,
+		CreatedAt: subscription.CreatedAt.Time,
+		UpdatedAt: subscription.UpdatedAt.Time,
+	}
+	if subscription.CreatedBy.Valid {
+		createdBy := int64(subscription.CreatedBy.Int32)
+		domainSubscription.CreatedBy = &createdBy
+	}
+	return domainSubscription
+}
+
+func toDomainWebhookDelivery(delivery postgres.WebhookDelivery) domain.WebhookDelivery {
+	domainDelivery := domain.WebhookDelivery{
+		ID:             int64(delivery.ID),
+		SubscriptionID: int64(delivery.SubscriptionID),
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       int(delivery.Attempts),
+		NextAttemptAt:  delivery.NextAttemptAt.Time,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.Time,
+	}
+	if delivery.LastStatusCode.Valid {
+		statusCode := int(delivery.LastStatusCode.Int32)
+		domainDelivery.LastStatusCode = &statusCode
+	}
+	if delivery.DeliveredAt.Valid {
+		deliveredAt := delivery.DeliveredAt.Time
+		domainDelivery.DeliveredAt = &deliveredAt
+	}
+	if delivery.RedeliveryOf.Valid {
+		redeliveryOf := int64(delivery.RedeliveryOf.Int32)
+		domainDelivery.RedeliveryOf = &redeliveryOf
+	}
+	return domainDelivery
+}
@@ -0,0 +1,5 @@
+-- name: TryAdvisoryLock :one
+SELECT pg_try_advisory_lock(@key::bigint);
+
+-- name: AdvisoryUnlock :one
+SELECT pg_advisory_unlock(@key::bigint);
@@ -0,0 +1,29 @@
+-- name: EscalateIdleApprovals :many
+UPDATE approvals
+SET escalated_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id IN (
+    SELECT id FROM approvals
+    WHERE status = 'pending' AND escalated_at IS NULL AND idle_since < @idle_before
+        AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+    ORDER BY id
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CreateApproval :one
+INSERT INTO approvals (tenant_id, purchase_order_id, approver_id)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetApprovalByID :one
+SELECT * FROM approvals
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: DecideApproval :one
+UPDATE approvals
+SET status = @status, idle_since = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = @id AND status = 'pending'
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+RETURNING *;
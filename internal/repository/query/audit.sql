@@ -0,0 +1,41 @@
+-- name: CreateAuditEvent :one
+INSERT INTO audit_events (actor_id, actor_role, ip, request_id, action, entity_type, entity_id, before, after, diff, tenant_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: GetAuditEvents :many
+SELECT * FROM audit_events
+WHERE (sqlc.narg('actor_id')::int IS NULL OR actor_id = sqlc.narg('actor_id')::int)
+    AND (sqlc.narg('entity_type')::varchar IS NULL OR entity_type = sqlc.narg('entity_type')::varchar)
+    AND (sqlc.narg('entity_id')::int IS NULL OR entity_id = sqlc.narg('entity_id')::int)
+    AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from')::timestamptz)
+    AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to')::timestamptz)
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+    AND (sqlc.narg('cursor_id')::bigint IS NULL OR id < sqlc.narg('cursor_id')::bigint)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountAuditEvents :one
+SELECT count(*) FROM audit_events
+WHERE (sqlc.narg('actor_id')::int IS NULL OR actor_id = sqlc.narg('actor_id')::int)
+    AND (sqlc.narg('entity_type')::varchar IS NULL OR entity_type = sqlc.narg('entity_type')::varchar)
+    AND (sqlc.narg('entity_id')::int IS NULL OR entity_id = sqlc.narg('entity_id')::int)
+    AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from')::timestamptz)
+    AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to')::timestamptz)
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: GetAuditChain :many
+SELECT e.id, e.seq, e.prev_hash, e.hash, audit_event_hash(e)::varchar AS computed_hash
+FROM audit_events e
+WHERE e.seq > sqlc.arg('after_seq')
+ORDER BY e.seq
+LIMIT sqlc.arg('limit');
+
+-- name: CreateAuditCheckpoint :one
+INSERT INTO audit_checkpoints (seq, event_id, hash, key_id, signature, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetAuditCheckpoints :many
+SELECT * FROM audit_checkpoints
+ORDER BY seq, id;
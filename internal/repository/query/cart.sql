@@ -0,0 +1,60 @@
+-- name: AddCartItem :one
+INSERT INTO cart_items (user_id, product_id, quantity)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, product_id)
+DO UPDATE SET quantity = cart_items.quantity + EXCLUDED.quantity, updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: UpdateCartItemQuantity :execrows
+UPDATE cart_items
+SET quantity = $3, updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND product_id = $2;
+
+-- name: RemoveCartItem :execrows
+DELETE FROM cart_items
+WHERE user_id = $1 AND product_id = $2;
+
+-- name: ClearCart :exec
+DELETE FROM cart_items
+WHERE user_id = $1;
+
+-- name: GetCartItems :many
+SELECT
+    ci.id,
+    ci.product_id,
+    ci.quantity,
+    ci.created_at,
+    ci.updated_at,
+    p.name AS product_name,
+    p.vendor_id,
+    u.name AS vendor_name,
+    p.price,
+    p.stock,
+    p.reserved_stock,
+    p.min_order_quantity
+FROM cart_items ci
+JOIN products p ON ci.product_id = p.id
+LEFT JOIN users u ON p.vendor_id = u.id
+WHERE ci.user_id = $1
+ORDER BY p.vendor_id, ci.id;
+
+-- name: LockCartItems :many
+SELECT
+    ci.id,
+    ci.product_id,
+    ci.quantity,
+    ci.created_at,
+    ci.updated_at,
+    p.name AS product_name,
+    p.vendor_id,
+    u.name AS vendor_name,
+    p.price,
+    p.stock,
+    p.reserved_stock,
+    p.min_order_quantity
+FROM cart_items ci
+JOIN products p ON ci.product_id = p.id
+LEFT JOIN users u ON p.vendor_id = u.id
+WHERE ci.user_id = $1
+ORDER BY p.id
+FOR UPDATE OF ci, p;
@@ -0,0 +1,62 @@
+-- name: LockProduct :one
+SELECT * FROM products
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+LIMIT 1
+FOR UPDATE;
+
+-- name: ApplyInventoryDelta :exec
+UPDATE products
+SET stock = stock + @on_hand_delta, reserved_stock = reserved_stock + @reserved_delta, updated_at = CURRENT_TIMESTAMP
+WHERE id = @id;
+
+-- name: CreateInventoryMovement :one
+INSERT INTO inventory_movements (product_id, movement_type, quantity, on_hand_delta, reserved_delta, reservation_id, note, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetInventoryBalance :one
+SELECT
+    coalesce(sum(on_hand_delta), 0)::int AS on_hand,
+    coalesce(sum(reserved_delta), 0)::int AS reserved
+FROM inventory_movements
+WHERE product_id = @product_id
+    AND product_id IN (
+        SELECT id FROM products
+        WHERE sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int
+    );
+
+-- name: GetInventoryMovements :many
+SELECT * FROM inventory_movements
+WHERE product_id = @product_id
+    AND product_id IN (
+        SELECT id FROM products
+        WHERE sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int
+    )
+    AND (sqlc.narg('cursor_id')::int IS NULL OR id < sqlc.narg('cursor_id')::int)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountInventoryMovements :one
+SELECT count(*) FROM inventory_movements
+WHERE product_id = @product_id
+    AND product_id IN (
+        SELECT id FROM products
+        WHERE sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int
+    );
+
+-- name: CreateStockReservation :one
+INSERT INTO stock_reservations (product_id, purchase_order_id, quantity, status)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: LockStockReservations :many
+SELECT * FROM stock_reservations
+WHERE purchase_order_id = $1 AND status = $2
+ORDER BY product_id
+FOR UPDATE;
+
+-- name: UpdateStockReservationStatus :exec
+UPDATE stock_reservations
+SET status = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
@@ -0,0 +1,49 @@
+-- name: EnqueueJob :exec
+INSERT INTO jobs (job_type, payload, status, max_attempts, run_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ClaimJob :one
+UPDATE jobs
+SET status = @running_status::varchar,
+    attempts = attempts + 1,
+    locked_until = CURRENT_TIMESTAMP + make_interval(secs => @lease_seconds::int),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = (
+    SELECT j.id FROM jobs j
+    WHERE (j.status = @pending_status::varchar AND j.run_at <= CURRENT_TIMESTAMP)
+        OR (j.status = @running_status::varchar AND j.locked_until < CURRENT_TIMESTAMP)
+    ORDER BY j.run_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteJob :exec
+UPDATE jobs
+SET status = $2, locked_until = NULL, completed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: FailJob :exec
+UPDATE jobs
+SET status = $2, run_at = $3, last_error = $4, locked_until = NULL, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: GetJobs :many
+SELECT * FROM jobs
+WHERE (sqlc.narg('status')::varchar IS NULL OR status = sqlc.narg('status')::varchar)
+    AND (sqlc.narg('cursor_id')::int IS NULL OR id < sqlc.narg('cursor_id')::int)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountJobs :one
+SELECT count(*) FROM jobs
+WHERE (sqlc.narg('status')::varchar IS NULL OR status = sqlc.narg('status')::varchar);
+
+-- name: RequeueJob :execrows
+UPDATE jobs
+SET status = @pending_status::varchar, attempts = 0, run_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = @id AND status = @dead_status::varchar;
+
+-- name: DeleteFinishedJobs :execrows
+DELETE FROM jobs
+WHERE status = $1 AND completed_at < $2;
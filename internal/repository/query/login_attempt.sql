@@ -0,0 +1,33 @@
+-- name: CreateLoginAttempt :exec
+INSERT INTO login_attempts (user_id, email, ip, user_agent, success, reason)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: CountRecentFailedLoginsByIP :one
+SELECT count(*), coalesce(min(created_at), CURRENT_TIMESTAMP)::timestamptz AS oldest
+FROM login_attempts
+WHERE ip = $1 AND NOT success AND reason <> 'ip_blocked' AND created_at >= $2;
+
+-- name: GetLoginAttempts :many
+SELECT * FROM login_attempts
+WHERE (sqlc.narg('user_id')::int IS NULL OR user_id = sqlc.narg('user_id')::int)
+    AND (sqlc.narg('email')::varchar IS NULL OR email = sqlc.narg('email')::varchar)
+    AND (sqlc.narg('ip')::varchar IS NULL OR ip = sqlc.narg('ip')::varchar)
+    AND (sqlc.narg('success')::boolean IS NULL OR success = sqlc.narg('success')::boolean)
+    AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from')::timestamptz)
+    AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to')::timestamptz)
+    AND (sqlc.narg('cursor_id')::bigint IS NULL OR id < sqlc.narg('cursor_id')::bigint)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountLoginAttempts :one
+SELECT count(*) FROM login_attempts
+WHERE (sqlc.narg('user_id')::int IS NULL OR user_id = sqlc.narg('user_id')::int)
+    AND (sqlc.narg('email')::varchar IS NULL OR email = sqlc.narg('email')::varchar)
+    AND (sqlc.narg('ip')::varchar IS NULL OR ip = sqlc.narg('ip')::varchar)
+    AND (sqlc.narg('success')::boolean IS NULL OR success = sqlc.narg('success')::boolean)
+    AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from')::timestamptz)
+    AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to')::timestamptz);
+
+-- name: DeleteLoginAttemptsBefore :execrows
+DELETE FROM login_attempts
+WHERE created_at < $1;
@@ -0,0 +1,64 @@
+-- name: GetUserMFA :one
+SELECT * FROM user_mfa
+WHERE user_id = $1;
+
+-- name: UpsertPendingUserMFA :execrows
+INSERT INTO user_mfa (user_id, secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, last_step = 0, created_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE user_mfa.confirmed_at IS NULL;
+
+-- name: ConfirmUserMFA :exec
+UPDATE user_mfa
+SET confirmed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $1;
+
+-- name: UseMFAStep :execrows
+UPDATE user_mfa
+SET last_step = $2, updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND last_step < $2;
+
+-- name: DeleteUserMFA :exec
+DELETE FROM user_mfa
+WHERE user_id = $1;
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE user_id = $1;
+
+-- name: CreateRecoveryCode :exec
+INSERT INTO mfa_recovery_codes (user_id, code_hash)
+VALUES ($1, $2);
+
+-- name: UseRecoveryCode :execrows
+UPDATE mfa_recovery_codes
+SET used_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: CountUnusedRecoveryCodes :one
+SELECT count(*) FROM mfa_recovery_codes
+WHERE user_id = $1 AND used_at IS NULL;
+
+-- name: CreateMFAChallenge :exec
+INSERT INTO mfa_challenges (user_id, token_hash, expires_at)
+VALUES ($1, $2, $3);
+
+-- name: GetMFAChallenge :one
+SELECT * FROM mfa_challenges
+WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP AND attempts < sqlc.arg('max_attempts');
+
+-- name: CountMFAChallengeAttempt :one
+UPDATE mfa_challenges
+SET attempts = attempts + 1
+WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP AND attempts < sqlc.arg('max_attempts')
+RETURNING *;
+
+-- name: UseMFAChallenge :execrows
+UPDATE mfa_challenges
+SET used_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND used_at IS NULL;
+
+-- name: DeleteExpiredMFAChallenges :execrows
+DELETE FROM mfa_challenges
+WHERE expires_at < $1;
@@ -0,0 +1,38 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (user_id, event_type, subject, body)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetNotificationsByUserID :many
+SELECT * FROM notifications
+WHERE user_id = @user_id
+    AND (NOT @unread_only::boolean OR read_at IS NULL)
+    AND (sqlc.narg('cursor_id')::int IS NULL OR id < sqlc.narg('cursor_id')::int)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountNotificationsByUserID :one
+SELECT count(*) FROM notifications
+WHERE user_id = @user_id
+    AND (NOT @unread_only::boolean OR read_at IS NULL);
+
+-- name: SetNotificationRead :execrows
+UPDATE notifications
+SET read_at = CASE WHEN @read::boolean THEN coalesce(read_at, CURRENT_TIMESTAMP) ELSE NULL END
+WHERE id = @id AND user_id = @user_id;
+
+-- name: MarkAllNotificationsRead :execrows
+UPDATE notifications
+SET read_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: GetNotificationPreferences :one
+SELECT * FROM notification_preferences
+WHERE user_id = $1 LIMIT 1;
+
+-- name: UpsertNotificationPreferences :one
+INSERT INTO notification_preferences (user_id, email_enabled, in_app_enabled)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET email_enabled = EXCLUDED.email_enabled, in_app_enabled = EXCLUDED.in_app_enabled, updated_at = CURRENT_TIMESTAMP
+RETURNING *;
@@ -0,0 +1,118 @@
+-- name: GetOrganizations :many
+SELECT * FROM organizations
+WHERE (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+    AND (sqlc.narg('cursor_id')::int IS NULL OR id < sqlc.narg('cursor_id')::int)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountOrganizations :one
+SELECT count(*) FROM organizations
+WHERE (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: GetOrganizationByID :one
+SELECT * FROM organizations
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: GetOrganizationByName :one
+SELECT * FROM organizations
+WHERE name = @name
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: CreateOrganization :one
+INSERT INTO organizations (tenant_id, name)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UpdateOrganization :one
+UPDATE organizations
+SET name = @name, updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+RETURNING *;
+
+-- name: DeleteOrganization :exec
+DELETE FROM organizations
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: GetDepartments :many
+SELECT * FROM departments
+WHERE organization_id = @organization_id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR organization_id IN (SELECT id FROM organizations WHERE tenant_id = sqlc.narg('tenant_id')::int))
+ORDER BY name;
+
+-- name: GetDepartmentByID :one
+SELECT * FROM departments
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR organization_id IN (SELECT id FROM organizations WHERE tenant_id = sqlc.narg('tenant_id')::int));
+
+-- name: GetDepartmentByName :one
+SELECT * FROM departments
+WHERE organization_id = @organization_id AND name = @name
+    AND (sqlc.narg('tenant_id')::int IS NULL OR organization_id IN (SELECT id FROM organizations WHERE tenant_id = sqlc.narg('tenant_id')::int));
+
+-- name: CreateDepartment :one
+INSERT INTO departments (organization_id, name, location, cost_center)
+SELECT id, sqlc.arg('name')::varchar, sqlc.arg('location')::varchar, sqlc.arg('cost_center')::varchar FROM organizations
+WHERE id = @organization_id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+RETURNING *;
+
+-- name: UpdateDepartment :one
+UPDATE departments
+SET name = @name, location = @location, cost_center = @cost_center, updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR organization_id IN (SELECT id FROM organizations WHERE tenant_id = sqlc.narg('tenant_id')::int))
+RETURNING *;
+
+-- name: DeleteDepartment :exec
+DELETE FROM departments
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR organization_id IN (SELECT id FROM organizations WHERE tenant_id = sqlc.narg('tenant_id')::int));
+
+-- name: GetOrganizationMembers :many
+SELECT m.user_id, m.organization_id, m.department_id, m.role, u.name, u.email, m.created_at, m.updated_at
+FROM organization_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.organization_id = @organization_id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR u.tenant_id = sqlc.narg('tenant_id')::int)
+    AND (sqlc.narg('cursor_id')::int IS NULL OR m.user_id < sqlc.narg('cursor_id')::int)
+ORDER BY m.user_id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountOrganizationMembers :one
+SELECT count(*) FROM organization_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.organization_id = @organization_id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR u.tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: GetOrganizationMember :one
+SELECT m.user_id, m.organization_id, m.department_id, m.role, u.name, u.email, m.created_at, m.updated_at
+FROM organization_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.user_id = @user_id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR u.tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: UpsertOrganizationMember :exec
+INSERT INTO organization_members (user_id, organization_id, department_id, role)
+SELECT u.id, o.id, sqlc.narg('department_id')::int, sqlc.arg('role')::varchar
+FROM users u
+JOIN organizations o ON o.id = @organization_id AND o.tenant_id = u.tenant_id
+WHERE u.id = @user_id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR u.tenant_id = sqlc.narg('tenant_id')::int)
+ON CONFLICT (user_id) DO UPDATE
+SET department_id = EXCLUDED.department_id, role = EXCLUDED.role, updated_at = CURRENT_TIMESTAMP
+WHERE organization_members.organization_id = EXCLUDED.organization_id;
+
+-- name: DeleteOrganizationMember :exec
+DELETE FROM organization_members m
+USING users u
+WHERE u.id = m.user_id AND m.user_id = @user_id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR u.tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: CountOrganizationAdmins :one
+SELECT count(*) FROM organization_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.organization_id = @organization_id AND m.role = 'admin'
+    AND (sqlc.narg('tenant_id')::int IS NULL OR u.tenant_id = sqlc.narg('tenant_id')::int);
@@ -1,53 +1,153 @@
 -- name: CreateProduct :one
-INSERT INTO products (vendor_id, name, price, stock)
-VALUES ($1, $2, $3, $4)
+INSERT INTO products (tenant_id, vendor_id, name, sku, description, category, price, stock, lead_time_days, min_order_quantity, low_stock_threshold)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 RETURNING *;
 
 -- name: GetProductByID :one
 SELECT * FROM products
-WHERE id = $1 LIMIT 1;
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+LIMIT 1;
 
 -- name: UpdateProduct :exec
 UPDATE products
-SET name = $2, price = $3, stock = $4
-WHERE id = $1;
+SET name = @name, sku = @sku, description = @description, category = @category, price = @price, lead_time_days = @lead_time_days, min_order_quantity = @min_order_quantity, low_stock_threshold = @low_stock_threshold, updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
 
 -- name: DeleteProduct :exec
 DELETE FROM products
-WHERE id = $1;
-
--- name: GetProducts :many
-SELECT
-    p.id,
-    p.vendor_id,
-    p.name AS product_name,
-    p.price,
-    p.stock,
-    u.id AS user_id,
-    u.name AS vendor_name
-FROM products p
-LEFT JOIN users u ON p.vendor_id = u.id
-ORDER BY p.id DESC
-LIMIT $1 OFFSET $2;
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
 
 -- name: GetProductsByVendorID :many
 SELECT * FROM products
-WHERE vendor_id = $1
+WHERE vendor_id = @vendor_id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+    AND (sqlc.narg('cursor_id')::int IS NULL OR id < sqlc.narg('cursor_id')::int)
 ORDER BY id DESC
-LIMIT $2 OFFSET $3;
-
--- name: GetProductsWithVendor :many
-SELECT
-    p.id,
-    p.vendor_id,
-    p.name AS product_name,
-    p.price,
-    p.stock,
-    u.id AS user_id,
-    u.name AS vendor_name
+LIMIT sqlc.arg('limit');
+
+-- name: CountProductsByVendorID :one
+SELECT count(*) FROM products
+WHERE vendor_id = @vendor_id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: ListProducts :many
+WITH catalog AS (
+    SELECT
+        p.id,
+        p.vendor_id,
+        p.name AS product_name,
+        p.sku,
+        p.description,
+        p.category,
+        p.price,
+        p.stock,
+        p.reserved_stock,
+        p.lead_time_days,
+        p.min_order_quantity,
+        p.created_at,
+        p.updated_at,
+        u.name AS vendor_name,
+        coalesce(product_search_rank(p.name, p.sku, p.description, u.name, sqlc.narg('query')::text), 0)::real AS rank,
+        coalesce(ts_headline(
+            'english',
+            concat_ws(' ', p.name, p.sku, p.description),
+            websearch_to_tsquery('english', sqlc.narg('query')::text),
+            'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10'
+        ), '')::text AS snippet
+    FROM products p
+    LEFT JOIN users u ON p.vendor_id = u.id
+    WHERE
+        (sqlc.narg('query')::text IS NULL OR product_search_matches(p.name, p.sku, p.description, u.name, sqlc.narg('query')::text))
+        AND (sqlc.narg('min_price')::int IS NULL OR p.price >= sqlc.narg('min_price')::int)
+        AND (sqlc.narg('max_price')::int IS NULL OR p.price <= sqlc.narg('max_price')::int)
+        AND (sqlc.narg('vendor_id')::int IS NULL OR p.vendor_id = sqlc.narg('vendor_id')::int)
+        AND (sqlc.narg('category')::text IS NULL OR p.category = sqlc.narg('category')::text)
+        AND (NOT @in_stock::boolean OR p.stock > p.reserved_stock)
+        AND (sqlc.narg('max_lead_time_days')::int IS NULL OR p.lead_time_days <= sqlc.narg('max_lead_time_days')::int)
+        AND (sqlc.narg('tenant_id')::int IS NULL OR p.tenant_id = sqlc.narg('tenant_id')::int)
+)
+SELECT id, vendor_id, product_name, sku, description, category, price, stock, reserved_stock, lead_time_days, min_order_quantity, created_at, updated_at, vendor_name, rank, snippet
+FROM catalog
+WHERE
+    sqlc.narg('cursor_id')::int IS NULL
+    OR CASE @sort::text
+        WHEN 'price_asc' THEN (price, id) > (sqlc.narg('cursor_price')::int, sqlc.narg('cursor_id')::int)
+        WHEN 'price_desc' THEN (price, id) < (sqlc.narg('cursor_price')::int, sqlc.narg('cursor_id')::int)
+        WHEN 'name' THEN (product_name, id) > (sqlc.narg('cursor_name')::text, sqlc.narg('cursor_id')::int)
+        WHEN 'relevance' THEN (rank, id) < (sqlc.narg('cursor_rank')::real, sqlc.narg('cursor_id')::int)
+        ELSE id < sqlc.narg('cursor_id')::int
+    END
+ORDER BY
+    CASE WHEN @sort::text = 'price_asc' THEN price END ASC,
+    CASE WHEN @sort::text = 'price_desc' THEN price END DESC,
+    CASE WHEN @sort::text = 'name' THEN product_name END ASC,
+    CASE WHEN @sort::text = 'relevance' THEN rank END DESC,
+    CASE WHEN @sort::text IN ('price_asc', 'name') THEN id END ASC,
+    id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetProductCategoryFacets :many
+SELECT p.category, count(*) AS count
+FROM products p
+LEFT JOIN users u ON p.vendor_id = u.id
+WHERE
+    (sqlc.narg('query')::text IS NULL OR product_search_matches(p.name, p.sku, p.description, u.name, sqlc.narg('query')::text))
+    AND (sqlc.narg('min_price')::int IS NULL OR p.price >= sqlc.narg('min_price')::int)
+    AND (sqlc.narg('max_price')::int IS NULL OR p.price <= sqlc.narg('max_price')::int)
+    AND (sqlc.narg('vendor_id')::int IS NULL OR p.vendor_id = sqlc.narg('vendor_id')::int)
+    AND (sqlc.narg('category')::text IS NULL OR p.category = sqlc.narg('category')::text)
+    AND (NOT @in_stock::boolean OR p.stock > p.reserved_stock)
+    AND (sqlc.narg('max_lead_time_days')::int IS NULL OR p.lead_time_days <= sqlc.narg('max_lead_time_days')::int)
+    AND (sqlc.narg('tenant_id')::int IS NULL OR p.tenant_id = sqlc.narg('tenant_id')::int)
+    AND p.category <> ''
+GROUP BY p.category
+ORDER BY count DESC, p.category;
+
+-- name: GetProductVendorFacets :many
+SELECT p.vendor_id, u.name AS vendor_name, count(*) AS count
+FROM products p
+LEFT JOIN users u ON p.vendor_id = u.id
+WHERE
+    (sqlc.narg('query')::text IS NULL OR product_search_matches(p.name, p.sku, p.description, u.name, sqlc.narg('query')::text))
+    AND (sqlc.narg('min_price')::int IS NULL OR p.price >= sqlc.narg('min_price')::int)
+    AND (sqlc.narg('max_price')::int IS NULL OR p.price <= sqlc.narg('max_price')::int)
+    AND (sqlc.narg('vendor_id')::int IS NULL OR p.vendor_id = sqlc.narg('vendor_id')::int)
+    AND (sqlc.narg('category')::text IS NULL OR p.category = sqlc.narg('category')::text)
+    AND (NOT @in_stock::boolean OR p.stock > p.reserved_stock)
+    AND (sqlc.narg('max_lead_time_days')::int IS NULL OR p.lead_time_days <= sqlc.narg('max_lead_time_days')::int)
+    AND (sqlc.narg('tenant_id')::int IS NULL OR p.tenant_id = sqlc.narg('tenant_id')::int)
+GROUP BY p.vendor_id, u.name
+ORDER BY count DESC, p.vendor_id;
+
+-- name: GetProductPriceFacets :many
+SELECT width_bucket(p.price, @bounds::int[])::int AS bucket, count(*) AS count
+FROM products p
+LEFT JOIN users u ON p.vendor_id = u.id
+WHERE
+    (sqlc.narg('query')::text IS NULL OR product_search_matches(p.name, p.sku, p.description, u.name, sqlc.narg('query')::text))
+    AND (sqlc.narg('min_price')::int IS NULL OR p.price >= sqlc.narg('min_price')::int)
+    AND (sqlc.narg('max_price')::int IS NULL OR p.price <= sqlc.narg('max_price')::int)
+    AND (sqlc.narg('vendor_id')::int IS NULL OR p.vendor_id = sqlc.narg('vendor_id')::int)
+    AND (sqlc.narg('category')::text IS NULL OR p.category = sqlc.narg('category')::text)
+    AND (NOT @in_stock::boolean OR p.stock > p.reserved_stock)
+    AND (sqlc.narg('max_lead_time_days')::int IS NULL OR p.lead_time_days <= sqlc.narg('max_lead_time_days')::int)
+    AND (sqlc.narg('tenant_id')::int IS NULL OR p.tenant_id = sqlc.narg('tenant_id')::int)
+GROUP BY bucket
+ORDER BY bucket;
+
+-- name: CountProducts :one
+SELECT count(*)
 FROM products p
 LEFT JOIN users u ON p.vendor_id = u.id
 WHERE
-    ($1::text IS NULL OR p.name ILIKE '%' || $1::text || '%')
-ORDER BY p.id DESC
-LIMIT $2 OFFSET $3;
\ No newline at end of file
+    (sqlc.narg('query')::text IS NULL OR product_search_matches(p.name, p.sku, p.description, u.name, sqlc.narg('query')::text))
+    AND (sqlc.narg('min_price')::int IS NULL OR p.price >= sqlc.narg('min_price')::int)
+    AND (sqlc.narg('max_price')::int IS NULL OR p.price <= sqlc.narg('max_price')::int)
+    AND (sqlc.narg('vendor_id')::int IS NULL OR p.vendor_id = sqlc.narg('vendor_id')::int)
+    AND (sqlc.narg('category')::text IS NULL OR p.category = sqlc.narg('category')::text)
+    AND (NOT @in_stock::boolean OR p.stock > p.reserved_stock)
+    AND (sqlc.narg('max_lead_time_days')::int IS NULL OR p.lead_time_days <= sqlc.narg('max_lead_time_days')::int)
+    AND (sqlc.narg('tenant_id')::int IS NULL OR p.tenant_id = sqlc.narg('tenant_id')::int);
@@ -0,0 +1,82 @@
+-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_orders (tenant_id, buyer_id, vendor_id, status, total_amount, expires_at, organization_id, department_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: CreatePurchaseOrderItem :one
+INSERT INTO purchase_order_items (purchase_order_id, product_id, product_name, unit_price, quantity)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetPurchaseOrderByID :one
+SELECT * FROM purchase_orders
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+LIMIT 1;
+
+-- name: LockPurchaseOrder :one
+SELECT * FROM purchase_orders
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+LIMIT 1
+FOR UPDATE;
+
+-- name: UpdatePurchaseOrderStatus :exec
+UPDATE purchase_orders
+SET status = @status, expires_at = @expires_at, updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: LockExpiredPurchaseOrders :many
+SELECT * FROM purchase_orders
+WHERE status = @status AND expires_at < CURRENT_TIMESTAMP
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+ORDER BY id
+LIMIT sqlc.arg('limit')
+FOR UPDATE SKIP LOCKED;
+
+-- name: GetPurchaseOrderItems :many
+SELECT * FROM purchase_order_items
+WHERE purchase_order_id = $1
+ORDER BY id;
+
+-- name: GetPurchaseOrdersByBuyerID :many
+SELECT * FROM purchase_orders
+WHERE buyer_id = @buyer_id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+    AND (sqlc.narg('cursor_id')::int IS NULL OR id < sqlc.narg('cursor_id')::int)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountPurchaseOrdersByBuyerID :one
+SELECT count(*) FROM purchase_orders
+WHERE buyer_id = @buyer_id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: GetPurchaseOrdersByVendorID :many
+SELECT * FROM purchase_orders
+WHERE vendor_id = @vendor_id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+    AND (sqlc.narg('cursor_id')::int IS NULL OR id < sqlc.narg('cursor_id')::int)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountPurchaseOrdersByVendorID :one
+SELECT count(*) FROM purchase_orders
+WHERE vendor_id = @vendor_id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: GetPurchaseOrdersByOrganizationID :many
+SELECT * FROM purchase_orders
+WHERE organization_id = @organization_id
+    AND (sqlc.narg('department_id')::int IS NULL OR department_id = sqlc.narg('department_id')::int)
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+    AND (sqlc.narg('cursor_id')::int IS NULL OR id < sqlc.narg('cursor_id')::int)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountPurchaseOrdersByOrganizationID :one
+SELECT count(*) FROM purchase_orders
+WHERE organization_id = @organization_id
+    AND (sqlc.narg('department_id')::int IS NULL OR department_id = sqlc.narg('department_id')::int)
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
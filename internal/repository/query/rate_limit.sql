@@ -0,0 +1,16 @@
+-- name: TakeRateLimitToken :one
+INSERT INTO rate_limit_buckets (key, full_at)
+VALUES (sqlc.arg('key'), sqlc.arg('now')::timestamptz + sqlc.arg('interval')::interval)
+ON CONFLICT (key) DO UPDATE
+SET full_at = GREATEST(rate_limit_buckets.full_at, sqlc.arg('now')::timestamptz) + sqlc.arg('interval')::interval
+WHERE GREATEST(rate_limit_buckets.full_at, sqlc.arg('now')::timestamptz) + sqlc.arg('interval')::interval
+    <= sqlc.arg('now')::timestamptz + sqlc.arg('period')::interval
+RETURNING full_at;
+
+-- name: GetRateLimitBucket :one
+SELECT full_at FROM rate_limit_buckets
+WHERE key = $1;
+
+-- name: DeleteFullRateLimitBuckets :execrows
+DELETE FROM rate_limit_buckets
+WHERE full_at <= $1;
@@ -0,0 +1,17 @@
+-- name: CloseDueRFQs :many
+UPDATE rfqs
+SET status = 'closed', closed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id IN (
+    SELECT id FROM rfqs
+    WHERE status = 'open' AND deadline <= CURRENT_TIMESTAMP
+        AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+    ORDER BY id
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CreateRFQ :one
+INSERT INTO rfqs (tenant_id, buyer_id, title, deadline)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
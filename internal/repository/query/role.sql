@@ -0,0 +1,73 @@
+-- name: GetRoles :many
+SELECT r.id, r.name, r.description, r.built_in, r.created_at, r.updated_at,
+    ARRAY(SELECT rp.permission FROM role_permissions rp WHERE rp.role_id = r.id ORDER BY rp.permission)::text[] AS permissions
+FROM roles r
+ORDER BY r.name;
+
+-- name: GetRoleByID :one
+SELECT r.id, r.name, r.description, r.built_in, r.created_at, r.updated_at,
+    ARRAY(SELECT rp.permission FROM role_permissions rp WHERE rp.role_id = r.id ORDER BY rp.permission)::text[] AS permissions
+FROM roles r
+WHERE r.id = $1;
+
+-- name: GetRoleByName :one
+SELECT r.id, r.name, r.description, r.built_in, r.created_at, r.updated_at,
+    ARRAY(SELECT rp.permission FROM role_permissions rp WHERE rp.role_id = r.id ORDER BY rp.permission)::text[] AS permissions
+FROM roles r
+WHERE r.name = $1;
+
+-- name: GetRolesByUserID :many
+SELECT r.id, r.name, r.description, r.built_in, r.created_at, r.updated_at,
+    ARRAY(SELECT rp.permission FROM role_permissions rp WHERE rp.role_id = r.id ORDER BY rp.permission)::text[] AS permissions
+FROM roles r
+JOIN user_roles ur ON ur.role_id = r.id
+WHERE ur.user_id = $1
+ORDER BY r.name;
+
+-- name: GetUserPermissions :many
+SELECT DISTINCT rp.permission
+FROM user_roles ur
+JOIN role_permissions rp ON rp.role_id = ur.role_id
+WHERE ur.user_id = $1
+ORDER BY rp.permission;
+
+-- name: CreateRole :one
+INSERT INTO roles (name, description)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UpdateRole :one
+UPDATE roles
+SET name = $2, description = $3, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteRole :exec
+DELETE FROM roles
+WHERE id = $1;
+
+-- name: DeleteRolePermissions :exec
+DELETE FROM role_permissions
+WHERE role_id = $1;
+
+-- name: AddRolePermissions :exec
+INSERT INTO role_permissions (role_id, permission)
+SELECT sqlc.arg('role_id'), unnest(sqlc.arg('permissions')::text[]);
+
+-- name: DeleteUserRoles :exec
+DELETE FROM user_roles
+WHERE user_id = $1;
+
+-- name: AddUserRoles :exec
+INSERT INTO user_roles (user_id, role_id)
+SELECT sqlc.arg('user_id'), unnest(sqlc.arg('role_ids')::int[]);
+
+-- name: AddUserRoleByName :exec
+INSERT INTO user_roles (user_id, role_id)
+SELECT $1, id FROM roles
+WHERE name = $2
+ON CONFLICT DO NOTHING;
+
+-- name: CountRoleUsers :one
+SELECT count(*) FROM user_roles
+WHERE role_id = $1;
@@ -0,0 +1,62 @@
+-- name: CreateSession :one
+INSERT INTO sessions (user_id, user_agent, ip, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: CreateRefreshToken :exec
+INSERT INTO refresh_tokens (session_id, token_hash)
+VALUES ($1, $2);
+
+-- name: GetRefreshTokenForUpdate :one
+SELECT rt.id, rt.session_id, rt.used_at, s.user_id, s.expires_at, s.revoked_at
+FROM refresh_tokens rt
+JOIN sessions s ON s.id = rt.session_id
+WHERE rt.token_hash = $1
+FOR UPDATE OF rt, s;
+
+-- name: UseRefreshToken :execrows
+UPDATE refresh_tokens
+SET used_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND used_at IS NULL;
+
+-- name: TouchSession :exec
+UPDATE sessions
+SET last_used_at = CURRENT_TIMESTAMP, ip = $2, user_agent = $3, expires_at = $4
+WHERE id = $1;
+
+-- name: RevokeSession :execrows
+UPDATE sessions
+SET revoked_at = CURRENT_TIMESTAMP, revoked_reason = $2
+WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeUserSessions :execrows
+UPDATE sessions
+SET revoked_at = CURRENT_TIMESTAMP, revoked_reason = $2
+WHERE user_id = $1 AND revoked_at IS NULL;
+
+-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE expires_at < $1;
+
+-- name: GetActiveUserSessions :many
+SELECT * FROM sessions
+WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+ORDER BY last_used_at DESC, id DESC;
+
+-- name: RevokeUserSession :execrows
+UPDATE sessions
+SET revoked_at = CURRENT_TIMESTAMP, revoked_reason = $3
+WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP;
+
+-- name: GetAccessState :one
+SELECT u.token_version, u.status, (s.revoked_at IS NULL AND s.expires_at > CURRENT_TIMESTAMP)::boolean AS session_active,
+    ARRAY(
+        SELECT DISTINCT rp.permission
+        FROM user_roles ur
+        JOIN role_permissions rp ON rp.role_id = ur.role_id
+        WHERE ur.user_id = u.id
+        ORDER BY rp.permission
+    )::text[] AS permissions
+FROM users u
+JOIN sessions s ON s.user_id = u.id
+WHERE u.id = $1 AND s.id = $2;
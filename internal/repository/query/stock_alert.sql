@@ -0,0 +1,39 @@
+-- name: SubscribeToProduct :exec
+INSERT INTO product_subscriptions (user_id, product_id)
+VALUES ($1, $2)
+ON CONFLICT (user_id, product_id) DO NOTHING;
+
+-- name: UnsubscribeFromProduct :execrows
+DELETE FROM product_subscriptions
+WHERE user_id = $1 AND product_id = $2;
+
+-- name: CreateStockAlert :exec
+INSERT INTO stock_alerts (user_id, product_id, alert_type, available)
+VALUES ($1, $2, $3, $4);
+
+-- name: CreateSubscriberStockAlerts :many
+INSERT INTO stock_alerts (user_id, product_id, alert_type, available)
+SELECT ps.user_id, ps.product_id, @alert_type::varchar, @available::int
+FROM product_subscriptions ps
+WHERE ps.product_id = @product_id
+RETURNING user_id;
+
+-- name: GetStockAlertsByUserID :many
+SELECT
+    sa.id,
+    sa.user_id,
+    sa.product_id,
+    p.name AS product_name,
+    sa.alert_type,
+    sa.available,
+    sa.created_at
+FROM stock_alerts sa
+JOIN products p ON p.id = sa.product_id
+WHERE sa.user_id = @user_id
+    AND (sqlc.narg('cursor_id')::int IS NULL OR sa.id < sqlc.narg('cursor_id')::int)
+ORDER BY sa.id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountStockAlertsByUserID :one
+SELECT count(*) FROM stock_alerts
+WHERE user_id = $1;
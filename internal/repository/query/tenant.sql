@@ -0,0 +1,3 @@
+-- name: GetTenantBySlug :one
+SELECT * FROM tenants
+WHERE slug = $1;
@@ -1,25 +1,108 @@
 -- name: CreateUser :one
-INSERT INTO users (name, username, email, password, role, status)
+INSERT INTO users (tenant_id, name, username, email, password, status)
 VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: GetUserByID :one
 SELECT * FROM users
-WHERE id = $1 LIMIT 1;
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+LIMIT 1;
 
 -- name: GetUserByEmail :one
 SELECT * FROM users
-WHERE email = $1 LIMIT 1;
+WHERE email = @email
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+LIMIT 1;
 
 -- name: GetAllByRole :many
 SELECT * FROM users
-WHERE role = $1;
+WHERE EXISTS (
+        SELECT 1 FROM user_roles ur
+        JOIN roles r ON r.id = ur.role_id
+        WHERE ur.user_id = users.id AND r.name = @role
+    )
+    AND (NOT sqlc.arg('verified_only')::bool OR email_verified_at IS NOT NULL)
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+    AND (sqlc.narg('cursor_id')::int IS NULL OR id < sqlc.narg('cursor_id')::int)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountByRole :one
+SELECT count(*) FROM users
+WHERE EXISTS (
+        SELECT 1 FROM user_roles ur
+        JOIN roles r ON r.id = ur.role_id
+        WHERE ur.user_id = users.id AND r.name = @role
+    )
+    AND (NOT sqlc.arg('verified_only')::bool OR email_verified_at IS NOT NULL)
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
 
 -- name: UpdateUser :exec
 UPDATE users
-SET name = $2, username = $3, email = $4, password = $5, role = $6, status = $7, updated_at = CURRENT_TIMESTAMP
-WHERE id = $1;
+SET name = @name, username = @username, email = @email, password = @password, status = @status,
+    email_verified_at = CASE WHEN email = @email THEN email_verified_at END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
 
 -- name: DeleteUser :exec
 DELETE FROM users
-WHERE id = $1;
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET password = @password, password_changed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: MarkUserEmailVerified :execrows
+UPDATE users
+SET email_verified_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = @id AND email = @email AND email_verified_at IS NULL
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: ClaimVerificationEmail :execrows
+UPDATE users
+SET verification_sent_at = CURRENT_TIMESTAMP
+WHERE id = @id
+    AND email_verified_at IS NULL
+    AND (verification_sent_at IS NULL OR verification_sent_at < @sent_before)
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: IncrementFailedLogins :one
+UPDATE users
+SET failed_logins = failed_logins + 1
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+RETURNING failed_logins;
+
+-- name: LockUser :exec
+UPDATE users
+SET locked_until = GREATEST(locked_until, @locked_until)
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: ResetFailedLogins :exec
+UPDATE users
+SET failed_logins = 0, locked_until = NULL
+WHERE id = @id
+    AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int);
+
+-- name: GetUserIDsWithPermission :many
+SELECT DISTINCT u.id
+FROM users u
+JOIN user_roles ur ON ur.user_id = u.id
+JOIN role_permissions rp ON rp.role_id = ur.role_id
+WHERE rp.permission = @permission
+    AND u.status = 'active'
+    AND (sqlc.narg('tenant_id')::int IS NULL OR u.tenant_id = sqlc.narg('tenant_id')::int)
+ORDER BY u.id;
+
+-- name: GetUserRoleNames :many
+SELECT ur.user_id, r.name
+FROM user_roles ur
+JOIN roles r ON r.id = ur.role_id
+WHERE ur.user_id = ANY(@user_ids::int[])
+ORDER BY ur.user_id, r.name;
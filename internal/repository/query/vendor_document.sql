@@ -0,0 +1,17 @@
+-- name: ClaimExpiringVendorDocuments :many
+UPDATE vendor_documents
+SET reminded_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id IN (
+    SELECT id FROM vendor_documents
+    WHERE reminded_at IS NULL AND expires_at < @expires_before
+        AND (sqlc.narg('tenant_id')::int IS NULL OR tenant_id = sqlc.narg('tenant_id')::int)
+    ORDER BY id
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CreateVendorDocument :one
+INSERT INTO vendor_documents (tenant_id, vendor_id, name, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
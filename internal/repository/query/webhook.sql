@@ -0,0 +1,77 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (url, secret, events, active, created_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetWebhookSubscriptionByID :one
+SELECT * FROM webhook_subscriptions
+WHERE id = $1 LIMIT 1;
+
+-- name: GetWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions
+WHERE (sqlc.narg('cursor_id')::int IS NULL OR id < sqlc.narg('cursor_id')::int)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountWebhookSubscriptions :one
+SELECT count(*) FROM webhook_subscriptions;
+
+-- name: UpdateWebhookSubscription :execrows
+UPDATE webhook_subscriptions
+SET url = $2, events = $3, active = $4, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: EnqueueWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (subscription_id, event_type, payload, status)
+SELECT ws.id, @event_type::varchar, @payload::jsonb, @status::varchar
+FROM webhook_subscriptions ws
+WHERE ws.active AND @event_type::varchar = ANY(ws.events);
+
+-- name: RedeliverWebhookDelivery :one
+INSERT INTO webhook_deliveries (subscription_id, event_type, payload, status, redelivery_of)
+SELECT wd.subscription_id, wd.event_type, wd.payload, @status::varchar, wd.id
+FROM webhook_deliveries wd
+WHERE wd.id = @id
+RETURNING *;
+
+-- name: GetWebhookDeliveryByID :one
+SELECT * FROM webhook_deliveries
+WHERE id = $1 LIMIT 1;
+
+-- name: GetWebhookDeliveriesBySubscriptionID :many
+SELECT * FROM webhook_deliveries
+WHERE subscription_id = @subscription_id
+    AND (sqlc.narg('cursor_id')::int IS NULL OR id < sqlc.narg('cursor_id')::int)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountWebhookDeliveriesBySubscriptionID :one
+SELECT count(*) FROM webhook_deliveries
+WHERE subscription_id = $1;
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => @lease_seconds::int), updated_at = CURRENT_TIMESTAMP
+WHERE id IN (
+    SELECT wd.id FROM webhook_deliveries wd
+    WHERE wd.status = @status AND wd.next_attempt_at <= CURRENT_TIMESTAMP
+    ORDER BY wd.next_attempt_at
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: RecordWebhookAttempt :exec
+UPDATE webhook_deliveries
+SET status = $2,
+    attempts = attempts + 1,
+    next_attempt_at = $3,
+    last_status_code = $4,
+    last_error = $5,
+    delivered_at = $6,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
@@ -0,0 +1,158 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+// Schedule decides when a job runs next.
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+type every time.Duration
+
+// Every runs a job at a fixed interval.
+func Every(interval time.Duration) Schedule {
+	return every(interval)
+}
+
+func (e every) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(e))
+}
+
+type daily struct {
+	hour, minute int
+}
+
+// Daily runs a job once a day at hour:minute UTC.
+func Daily(hour, minute int) Schedule {
+	return daily{hour: hour, minute: minute}
+}
+
+func (d daily) Next(after time.Time) time.Time {
+	after = after.UTC()
+	next := time.Date(after.Year(), after.Month(), after.Day(), d.hour, d.minute, 0, 0, time.UTC)
+	if !next.After(after) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+type entry struct {
+	name     string
+	schedule Schedule
+	run      func() error
+}
+
+// Scheduler runs named jobs on their schedules. Every replica runs the
+// same scheduler, but a job only runs on the replica holding its leader
+// lock; the others keep trying to take the lock so one of them takes over
+// when the leader goes away.
+type Scheduler struct {
+	lock    domain.LeaderLock
+	entries []entry
+	logger  *zap.Logger
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func New(lock domain.LeaderLock, logger *zap.Logger) *Scheduler {
+	return &Scheduler{lock: lock, logger: logger, stop: make(chan struct{})}
+}
+
+// Add registers run under name. Names identify the job across replicas and
+// must be unique. Add must be called before Start.
+func (s *Scheduler) Add(name string, schedule Schedule, run func() error) {
+	s.entries = append(s.entries, entry{name: name, schedule: schedule, run: run})
+}
+
+// Start launches the jobs.
+func (s *Scheduler) Start() {
+	s.logger.Info("Starting scheduler", zap.Int("jobs", len(s.entries)))
+	for _, e := range s.entries {
+		s.wg.Add(1)
+		go s.loop(e)
+	}
+}
+
+// Shutdown stops scheduling jobs, waits for the running ones to finish or
+// for ctx to be done, and gives up leadership.
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	close(s.stop)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+		s.logger.Info("Scheduler stopped")
+	case <-ctx.Done():
+		s.logger.Warn("Scheduler did not stop in time", zap.Error(ctx.Err()))
+		err = ctx.Err()
+	}
+
+	if closeErr := s.lock.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (s *Scheduler) loop(e entry) {
+	defer s.wg.Done()
+
+	for {
+		timer := time.NewTimer(time.Until(e.schedule.Next(time.Now())))
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.runOnce(e)
+	}
+}
+
+func (s *Scheduler) runOnce(e entry) {
+	logger := s.logger.With(zap.String("job", e.name))
+
+	leader, err := s.lock.TryAcquire(e.name)
+	if err != nil {
+		logger.Error("Failed to acquire scheduler lock", zap.Error(err))
+		return
+	}
+	if !leader {
+		logger.Debug("Scheduled job is run by another replica")
+		return
+	}
+
+	start := time.Now()
+	if err := s.call(e); err != nil {
+		logger.Error("Scheduled job failed", zap.Error(err), zap.Duration("duration", time.Since(start)))
+		return
+	}
+	logger.Debug("Scheduled job finished", zap.Duration("duration", time.Since(start)))
+}
+
+// call runs the job, turning a panic into an error so the job keeps its
+// schedule.
+func (s *Scheduler) call(e entry) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return e.run()
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+
+	"go.uber.org/zap"
+)
+
+type approvalUsecase struct {
+	transactor domain.Transactor
+	logger     *zap.Logger
+}
+
+func NewApprovalUsecase(transactor domain.Transactor, logger *zap.Logger) domain.ApprovalUsecase {
+	return &approvalUsecase{transactor: transactor, logger: logger}
+}
+
+// Request implements domain.ApprovalUsecase. Orders are approved before
+// they are confirmed, so only pending orders can be sent for approval. The
+// approver must be an active user of the tenant holding po:approve, and is
+// notified.
+func (a *approvalUsecase) Request(purchaseOrderID int64, request domain.ApprovalRequest, actor domain.Actor) (*domain.Approval, error) {
+	a.logger.Debug("Request approval function called", zap.Int64("purchase_order_id", purchaseOrderID), zap.Int64("approver_id", request.ApproverID))
+
+	approval := &domain.Approval{PurchaseOrderID: purchaseOrderID, ApproverID: request.ApproverID, TenantID: actor.TenantID}
+	err := a.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		order, err := repos.PurchaseOrders.GetByID(purchaseOrderID)
+		if err != nil {
+			return errors.ErrPurchaseOrderNotFound
+		}
+		if order.BuyerID != actor.UserID {
+			return errors.ErrForbidden
+		}
+		if order.Status != domain.PurchaseOrderStatusPending {
+			return errors.ErrInvalidTransition
+		}
+		if err := checkApprover(repos, order, request.ApproverID); err != nil {
+			return err
+		}
+
+		if err := repos.Approvals.Create(approval); err != nil {
+			return err
+		}
+		if err := recordAudit(repos, actor, domain.AuditActionCreate, domain.AuditEntityApproval, approval.ID, nil, approval); err != nil {
+			return err
+		}
+		notification, err := domain.NewNotificationJob(approval.ApproverID, domain.NotificationApprovalRequested, map[string]interface{}{
+			"ApprovalID":      approval.ID,
+			"PurchaseOrderID": order.ID,
+			"TotalAmount":     order.TotalAmount,
+		})
+		if err != nil {
+			return err
+		}
+		return repos.Jobs.Enqueue(notification)
+	})
+	if err != nil {
+		a.logger.Warn("Failed to request approval", zap.Error(err), zap.Int64("purchase_order_id", purchaseOrderID))
+		if err == errors.ErrForbidden {
+			return nil, errors.NewAppError(err, "Purchase order does not belong to the user", http.StatusForbidden)
+		}
+		return nil, approvalError(err, "Failed to request approval")
+	}
+
+	a.logger.Info("Approval requested successfully", zap.Int64("approval_id", approval.ID), zap.Int64("purchase_order_id", purchaseOrderID))
+	return approval, nil
+}
+
+// checkApprover verifies that approverID may approve the order: someone
+// other than its buyer, active in the tenant and holding po:approve.
+func checkApprover(repos domain.Repositories, order *domain.PurchaseOrder, approverID int64) error {
+	if approverID == order.BuyerID {
+		return errors.ErrInvalidApprover
+	}
+	approver, err := repos.Users.GetByID(approverID)
+	if err != nil || approver.Status != "active" {
+		return errors.ErrInvalidApprover
+	}
+	permissions, err := repos.Roles.GetUserPermissions(approverID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(permissions, domain.PermissionPOApprove) {
+		return errors.ErrInvalidApprover
+	}
+	return nil
+}
+
+// Approve implements domain.ApprovalUsecase.
+func (a *approvalUsecase) Approve(id int64, actor domain.Actor) (*domain.Approval, error) {
+	return a.decide(id, domain.ApprovalStatusApproved, actor)
+}
+
+// Reject implements domain.ApprovalUsecase.
+func (a *approvalUsecase) Reject(id int64, actor domain.Actor) (*domain.Approval, error) {
+	return a.decide(id, domain.ApprovalStatusRejected, actor)
+}
+
+func (a *approvalUsecase) decide(id int64, status string, actor domain.Actor) (*domain.Approval, error) {
+	a.logger.Debug("Decide approval function called", zap.Int64("approval_id", id), zap.String("status", status))
+
+	var after *domain.Approval
+	err := a.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		before, err := repos.Approvals.GetByID(id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errors.ErrApprovalNotFound
+		}
+		if before.ApproverID != actor.UserID {
+			return errors.ErrForbidden
+		}
+		if after, err = repos.Approvals.Decide(id, status); err != nil {
+			return err
+		}
+		if after == nil {
+			return errors.ErrInvalidTransition
+		}
+		return recordAudit(repos, actor, domain.AuditActionUpdate, domain.AuditEntityApproval, id, before, after)
+	})
+	if err != nil {
+		a.logger.Warn("Failed to decide approval", zap.Error(err), zap.Int64("approval_id", id), zap.String("status", status))
+		return nil, approvalError(err, "Failed to decide approval")
+	}
+
+	a.logger.Info("Approval decided successfully", zap.Int64("approval_id", id), zap.String("status", status))
+	return after, nil
+}
+
+// EscalateIdle implements domain.ApprovalUsecase. Approvals are escalated
+// in batches, each with its audit events and notifications in one
+// transaction.
+func (a *approvalUsecase) EscalateIdle(idleFor time.Duration) (int, error) {
+	const batchSize = 100
+
+	idleBefore := time.Now().Add(-idleFor)
+	escalated := 0
+	for {
+		var approvals []domain.Approval
+		err := a.transactor.WithinSystemTransaction(func(repos domain.Repositories) error {
+			var err error
+			if approvals, err = repos.Approvals.EscalateIdle(idleBefore, batchSize); err != nil {
+				return err
+			}
+			var jobs []*domain.Job
+			for i := range approvals {
+				before := approvals[i]
+				before.EscalatedAt = nil
+				actor := domain.SystemActor
+				actor.TenantID = approvals[i].TenantID
+				if err := recordAudit(repos, actor, domain.AuditActionUpdate, domain.AuditEntityApproval, approvals[i].ID, before, approvals[i]); err != nil {
+					return err
+				}
+				escalationJobs, err := escalationNotifications(repos, approvals[i])
+				if err != nil {
+					return err
+				}
+				jobs = append(jobs, escalationJobs...)
+			}
+			if len(jobs) == 0 {
+				return nil
+			}
+			return repos.Jobs.Enqueue(jobs...)
+		})
+		if err != nil {
+			a.logger.Error("Failed to escalate idle approvals", zap.Error(err))
+			return escalated, errors.NewAppError(err, "Failed to escalate idle approvals", http.StatusInternalServerError)
+		}
+		escalated += len(approvals)
+		for _, approval := range approvals {
+			a.logger.Info("Approval escalated", zap.Int64("approval_id", approval.ID), zap.Int64("purchase_order_id", approval.PurchaseOrderID))
+		}
+		if len(approvals) < batchSize {
+			return escalated, nil
+		}
+	}
+}
+
+func approvalError(err error, message string) error {
+	switch err {
+	case errors.ErrPurchaseOrderNotFound:
+		return errors.NewAppError(err, "Purchase order not found", http.StatusNotFound)
+	case errors.ErrApprovalNotFound:
+		return errors.NewAppError(err, "Approval not found", http.StatusNotFound)
+	case errors.ErrForbidden:
+		return errors.NewAppError(err, "Approval is assigned to another user", http.StatusForbidden)
+	case errors.ErrInvalidApprover:
+		return errors.NewAppError(err, "The approver must be another active user of the tenant with po:approve", http.StatusUnprocessableEntity)
+	case errors.ErrInvalidTransition:
+		return errors.NewAppError(err, "Only pending purchase orders and approvals can be acted on", http.StatusConflict)
+	default:
+		return errors.NewAppError(err, message, http.StatusInternalServerError)
+	}
+}
+
+// escalationNotifications tells the holders of organization:manage in the
+// approval's tenant that it is overdue.
+func escalationNotifications(repos domain.Repositories, approval domain.Approval) ([]*domain.Job, error) {
+	managerIDs, err := repos.Users.ForTenant(approval.TenantID).GetIDsWithPermission(domain.PermissionOrganizationManage)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*domain.Job, 0, len(managerIDs))
+	for _, managerID := range managerIDs {
+		notification, err := domain.NewNotificationJob(managerID, domain.NotificationApprovalEscalated, map[string]interface{}{
+			"ApprovalID":      approval.ID,
+			"PurchaseOrderID": approval.PurchaseOrderID,
+			"ApproverID":      approval.ApproverID,
+			"IdleSince":       approval.IdleSince.UTC().Format(time.RFC1123),
+		})
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, notification)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"go.uber.org/zap"
+)
+
+func newApprovalUsecaseTest() (domain.ApprovalUsecase, *fakeTransactor) {
+	transactor := newFakeTransactor()
+	transactor.users.users[7] = &domain.User{ID: 7, TenantID: 1, Status: "active"}
+	transactor.users.users[9] = &domain.User{ID: 9, TenantID: 1, Status: "active"}
+	transactor.users.users[10] = &domain.User{ID: 10, TenantID: 1, Status: "suspended"}
+	transactor.users.users[20] = &domain.User{ID: 20, TenantID: 2, Status: "active"}
+	transactor.orders.orders[5] = &domain.PurchaseOrder{ID: 5, TenantID: 1, BuyerID: 7, Status: domain.PurchaseOrderStatusPending, TotalAmount: 300}
+	transactor.orders.orders[6] = &domain.PurchaseOrder{ID: 6, TenantID: 1, BuyerID: 7, Status: domain.PurchaseOrderStatusIssued, TotalAmount: 300}
+	return NewApprovalUsecase(transactor, zap.NewNop()), transactor
+}
+
+func TestApprovalUsecaseRequest(t *testing.T) {
+	approve := []string{domain.PermissionPOApprove}
+
+	tests := []struct {
+		name                string
+		actor               domain.Actor
+		purchaseOrderID     int64
+		approverID          int64
+		approverPermissions []string
+		wantCode            int
+	}{
+		{name: "buyer", actor: domain.Actor{UserID: 7, TenantID: 1}, purchaseOrderID: 5, approverID: 9, approverPermissions: approve},
+		{name: "not the buyer", actor: domain.Actor{UserID: 9, TenantID: 1}, purchaseOrderID: 5, approverID: 9, approverPermissions: approve, wantCode: http.StatusForbidden},
+		{name: "cross-tenant buyer", actor: domain.Actor{UserID: 7, TenantID: 2}, purchaseOrderID: 5, approverID: 9, approverPermissions: approve, wantCode: http.StatusNotFound},
+		{name: "missing order", actor: domain.Actor{UserID: 7, TenantID: 1}, purchaseOrderID: 99, approverID: 9, approverPermissions: approve, wantCode: http.StatusNotFound},
+		{name: "issued order", actor: domain.Actor{UserID: 7, TenantID: 1}, purchaseOrderID: 6, approverID: 9, approverPermissions: approve, wantCode: http.StatusConflict},
+		{name: "self approval", actor: domain.Actor{UserID: 7, TenantID: 1}, purchaseOrderID: 5, approverID: 7, approverPermissions: approve, wantCode: http.StatusUnprocessableEntity},
+		{name: "approver without po:approve", actor: domain.Actor{UserID: 7, TenantID: 1}, purchaseOrderID: 5, approverID: 9, wantCode: http.StatusUnprocessableEntity},
+		{name: "suspended approver", actor: domain.Actor{UserID: 7, TenantID: 1}, purchaseOrderID: 5, approverID: 10, approverPermissions: approve, wantCode: http.StatusUnprocessableEntity},
+		{name: "approver in another tenant", actor: domain.Actor{UserID: 7, TenantID: 1}, purchaseOrderID: 5, approverID: 20, approverPermissions: approve, wantCode: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, transactor := newApprovalUsecaseTest()
+			transactor.roles.permissions = tt.approverPermissions
+
+			approval, err := u.Request(tt.purchaseOrderID, domain.ApprovalRequest{ApproverID: tt.approverID}, tt.actor)
+			if tt.wantCode != 0 {
+				if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != tt.wantCode {
+					t.Fatalf("Request() error = %v, want code %d", err, tt.wantCode)
+				}
+				if len(transactor.approvals.approvals) != 0 || len(transactor.jobs.jobs) != 0 || len(transactor.audit.events) != 0 {
+					t.Errorf("stored %d approvals, %d jobs and %d audit events, want none", len(transactor.approvals.approvals), len(transactor.jobs.jobs), len(transactor.audit.events))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Request() error = %v", err)
+			}
+			stored := transactor.approvals.approvals[approval.ID]
+			if stored == nil || stored.ApproverID != 9 || stored.PurchaseOrderID != 5 || stored.TenantID != 1 || stored.Status != domain.ApprovalStatusPending {
+				t.Errorf("stored approval = %+v, want a pending approval of order 5 by 9 in tenant 1", stored)
+			}
+			notifications := notificationJobs(t, transactor.jobs.jobs)
+			if len(notifications) != 1 || notifications[0].UserID != 9 || notifications[0].Event != domain.NotificationApprovalRequested {
+				t.Errorf("notifications = %+v, want %s to 9", notifications, domain.NotificationApprovalRequested)
+			}
+			if len(transactor.audit.events) != 1 {
+				t.Errorf("audit events = %d, want 1", len(transactor.audit.events))
+			}
+		})
+	}
+}
+
+func TestApprovalUsecaseDecide(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         int64
+		actor      domain.Actor
+		reject     bool
+		wantCode   int
+		wantStatus string
+	}{
+		{name: "approve", id: 1, actor: domain.Actor{UserID: 9, TenantID: 1}, wantStatus: domain.ApprovalStatusApproved},
+		{name: "reject", id: 1, actor: domain.Actor{UserID: 9, TenantID: 1}, reject: true, wantStatus: domain.ApprovalStatusRejected},
+		{name: "another user", id: 1, actor: domain.Actor{UserID: 7, TenantID: 1}, wantCode: http.StatusForbidden, wantStatus: domain.ApprovalStatusPending},
+		{name: "cross-tenant approver id", id: 1, actor: domain.Actor{UserID: 9, TenantID: 2}, wantCode: http.StatusNotFound, wantStatus: domain.ApprovalStatusPending},
+		{name: "already decided", id: 2, actor: domain.Actor{UserID: 9, TenantID: 1}, wantCode: http.StatusConflict, wantStatus: domain.ApprovalStatusApproved},
+		{name: "missing approval", id: 99, actor: domain.Actor{UserID: 9, TenantID: 1}, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, transactor := newApprovalUsecaseTest()
+			transactor.approvals.approvals[1] = &domain.Approval{ID: 1, TenantID: 1, PurchaseOrderID: 5, ApproverID: 9, Status: domain.ApprovalStatusPending}
+			transactor.approvals.approvals[2] = &domain.Approval{ID: 2, TenantID: 1, PurchaseOrderID: 6, ApproverID: 9, Status: domain.ApprovalStatusApproved}
+
+			decide := u.Approve
+			if tt.reject {
+				decide = u.Reject
+			}
+			_, err := decide(tt.id, tt.actor)
+			if tt.wantCode != 0 {
+				if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != tt.wantCode {
+					t.Errorf("decide error = %v, want code %d", err, tt.wantCode)
+				}
+			} else if err != nil {
+				t.Fatalf("decide error = %v", err)
+			}
+			if stored, ok := transactor.approvals.approvals[tt.id]; ok && stored.Status != tt.wantStatus {
+				t.Errorf("stored status = %s, want %s", stored.Status, tt.wantStatus)
+			}
+			wantEvents := 0
+			if tt.wantCode == 0 {
+				wantEvents = 1
+			}
+			if len(transactor.audit.events) != wantEvents {
+				t.Errorf("audit events = %d, want %d", len(transactor.audit.events), wantEvents)
+			}
+		})
+	}
+}
+
+func TestApprovalUsecaseEscalateIdle(t *testing.T) {
+	day := 24 * time.Hour
+	escalated := time.Now().Add(-day)
+	u, transactor := newApprovalUsecaseTest()
+	transactor.users.users[1] = &domain.User{ID: 1, TenantID: 1, Status: "active"}
+	transactor.users.users[21] = &domain.User{ID: 21, TenantID: 2, Status: "active"}
+	transactor.users.withPermission[domain.PermissionOrganizationManage] = []int64{1, 21}
+	transactor.approvals.approvals[1] = &domain.Approval{ID: 1, TenantID: 1, PurchaseOrderID: 5, ApproverID: 9, Status: domain.ApprovalStatusPending, IdleSince: time.Now().Add(-3 * day)}
+	transactor.approvals.approvals[2] = &domain.Approval{ID: 2, TenantID: 2, PurchaseOrderID: 8, ApproverID: 20, Status: domain.ApprovalStatusPending, IdleSince: time.Now().Add(-3 * day)}
+	transactor.approvals.approvals[3] = &domain.Approval{ID: 3, TenantID: 1, PurchaseOrderID: 5, ApproverID: 9, Status: domain.ApprovalStatusPending, IdleSince: time.Now().Add(-time.Hour)}
+	transactor.approvals.approvals[4] = &domain.Approval{ID: 4, TenantID: 1, PurchaseOrderID: 5, ApproverID: 9, Status: domain.ApprovalStatusPending, IdleSince: time.Now().Add(-3 * day), EscalatedAt: &escalated}
+	transactor.approvals.approvals[5] = &domain.Approval{ID: 5, TenantID: 1, PurchaseOrderID: 6, ApproverID: 9, Status: domain.ApprovalStatusApproved, IdleSince: time.Now().Add(-3 * day)}
+
+	count, err := u.EscalateIdle(2 * day)
+	if err != nil {
+		t.Fatalf("EscalateIdle() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("escalated = %d, want 2", count)
+	}
+	if len(transactor.audit.events) != 2 {
+		t.Errorf("audit events = %d, want 2", len(transactor.audit.events))
+	}
+	// Each approval goes to the managers of its own tenant only.
+	notifications := notificationJobs(t, transactor.jobs.jobs)
+	if got := recipients(notifications); !slices.Equal(got, []int64{1, 21}) {
+		t.Errorf("notified %v, want [1 21]", got)
+	}
+	for _, notification := range notifications {
+		if notification.Event != domain.NotificationApprovalEscalated {
+			t.Errorf("notification event = %s, want %s", notification.Event, domain.NotificationApprovalEscalated)
+		}
+	}
+
+	if count, err := u.EscalateIdle(2 * day); err != nil || count != 0 {
+		t.Errorf("second EscalateIdle() = %d, %v, want 0, nil", count, err)
+	}
+}
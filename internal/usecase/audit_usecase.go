@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/keys"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+
+	"go.uber.org/zap"
+)
+
+// verifyBatchSize is how many chain links are read at a time.
+const verifyBatchSize = 1000
+
+type auditUsecase struct {
+	auditRepo     domain.AuditRepository
+	checkpointKey ed25519.PrivateKey
+	checkpointKID string
+	exporter      domain.AuditCheckpointExporter
+	logger        *zap.Logger
+}
+
+// NewAuditUsecase creates the audit usecase. Checkpoints are signed with
+// checkpointKey and exported through exporter; with a nil key they are
+// disabled, and existing checkpoints are verified for their hashes only.
+func NewAuditUsecase(auditRepo domain.AuditRepository, checkpointKey ed25519.PrivateKey, exporter domain.AuditCheckpointExporter, logger *zap.Logger) (domain.AuditUsecase, error) {
+	a := &auditUsecase{auditRepo: auditRepo, checkpointKey: checkpointKey, exporter: exporter, logger: logger}
+	if checkpointKey != nil {
+		kid, err := keys.ID(checkpointKey.Public())
+		if err != nil {
+			return nil, err
+		}
+		a.checkpointKID = kid
+	}
+	return a, nil
+}
+
+// GetEvents implements domain.AuditUsecase. Audit events are read by the
+// operators, so events of every tenant are listed unless the filter names
+// one.
+func (a *auditUsecase) GetEvents(filter domain.AuditFilter, page pagination.Params, actor domain.Actor) (*pagination.Page[domain.AuditEvent], error) {
+	page = page.Normalize()
+
+	afterID, err := decodeIDCursor(page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	auditRepo := a.auditRepo.SystemScope()
+	if filter.TenantID != nil {
+		auditRepo = auditRepo.ForTenant(*filter.TenantID)
+	}
+	events, err := auditRepo.GetEvents(filter, afterID, page.Limit+1)
+	if err != nil {
+		a.logger.Error("Failed to get audit events", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to get audit events", http.StatusInternalServerError)
+	}
+
+	total, err := auditRepo.CountEvents(filter)
+	if err != nil {
+		a.logger.Error("Failed to count audit events", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to count audit events", http.StatusInternalServerError)
+	}
+
+	result, err := pagination.NewPage(events, page.Limit, total, func(event domain.AuditEvent) interface{} {
+		return pagination.IDCursor{ID: event.ID}
+	})
+	if err != nil {
+		return nil, errors.NewAppError(err, "Failed to get audit events", http.StatusInternalServerError)
+	}
+
+	return result, nil
+}
+
+// Verify implements domain.AuditUsecase. Every event must directly follow
+// the one before it, name its hash as prev_hash, and still hash to its
+// recorded hash; every checkpoint must carry a valid signature and match
+// the event it names. The first violation is reported in BrokenAt.
+func (a *auditUsecase) Verify() (*domain.AuditVerification, error) {
+	a.logger.Debug("Verify function called")
+
+	checkpoints, err := a.auditRepo.GetCheckpoints()
+	if err != nil {
+		a.logger.Error("Failed to get audit checkpoints", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to verify audit log", http.StatusInternalServerError)
+	}
+
+	result := &domain.AuditVerification{Valid: true}
+	for _, checkpoint := range checkpoints {
+		verified, valid := a.checkSignature(checkpoint)
+		if !verified {
+			result.UnverifiedCheckpoints++
+		} else if !valid {
+			result.Valid = false
+			result.BrokenAt = &domain.AuditChainBreak{
+				Seq:     checkpoint.Seq,
+				EventID: checkpoint.EventID,
+				Reason:  fmt.Sprintf("checkpoint %d has an invalid signature", checkpoint.ID),
+			}
+			a.logger.Error("Audit checkpoint signature is invalid", zap.Int64("checkpoint_id", checkpoint.ID))
+			return result, nil
+		}
+		result.Checkpoints++
+	}
+
+	if err := a.walk(result, checkpoints); err != nil {
+		a.logger.Error("Failed to walk audit chain", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to verify audit log", http.StatusInternalServerError)
+	}
+
+	if result.Valid {
+		a.logger.Info("Audit log verified", zap.Int64("events", result.Events), zap.Int64("head_seq", result.HeadSeq))
+	} else {
+		a.logger.Error("Audit chain is broken",
+			zap.Int64("seq", result.BrokenAt.Seq),
+			zap.Int64("event_id", result.BrokenAt.EventID),
+			zap.String("reason", result.BrokenAt.Reason),
+		)
+	}
+	return result, nil
+}
+
+// CreateCheckpoint implements domain.AuditUsecase. The events recorded
+// since the last checkpoint are verified first, so a broken chain is never
+// signed.
+func (a *auditUsecase) CreateCheckpoint() (*domain.AuditCheckpoint, error) {
+	a.logger.Debug("CreateCheckpoint function called")
+
+	if a.checkpointKey == nil {
+		return nil, errors.NewAppError(errors.ErrCheckpointsDisabled, "Audit checkpoints are not configured", http.StatusServiceUnavailable)
+	}
+
+	checkpoints, err := a.auditRepo.GetCheckpoints()
+	if err != nil {
+		a.logger.Error("Failed to get audit checkpoints", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to create audit checkpoint", http.StatusInternalServerError)
+	}
+
+	// Continue from the newest checkpoint we signed ourselves.
+	result := &domain.AuditVerification{Valid: true}
+	for i := len(checkpoints) - 1; i >= 0; i-- {
+		if verified, valid := a.checkSignature(checkpoints[i]); verified && valid {
+			result.HeadSeq = checkpoints[i].Seq
+			result.HeadEventID = checkpoints[i].EventID
+			result.HeadHash = checkpoints[i].Hash
+			break
+		}
+	}
+
+	if err := a.walk(result, nil); err != nil {
+		a.logger.Error("Failed to walk audit chain", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to create audit checkpoint", http.StatusInternalServerError)
+	}
+	if !result.Valid {
+		a.logger.Error("Refusing to checkpoint a broken audit chain",
+			zap.Int64("seq", result.BrokenAt.Seq),
+			zap.String("reason", result.BrokenAt.Reason),
+		)
+		return nil, errors.NewAppError(errors.ErrAuditChainBroken, fmt.Sprintf("Audit chain is broken at seq %d: %s", result.BrokenAt.Seq, result.BrokenAt.Reason), http.StatusConflict)
+	}
+
+	checkpoint := &domain.AuditCheckpoint{
+		Seq:       result.HeadSeq,
+		EventID:   result.HeadEventID,
+		Hash:      result.HeadHash,
+		KeyID:     a.checkpointKID,
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	checkpoint.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(a.checkpointKey, checkpoint.SignedPayload()))
+
+	if err := a.auditRepo.CreateCheckpoint(checkpoint); err != nil {
+		a.logger.Error("Failed to create audit checkpoint", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to create audit checkpoint", http.StatusInternalServerError)
+	}
+
+	if err := a.exporter.Export(*checkpoint); err != nil {
+		a.logger.Error("Failed to export audit checkpoint", zap.Error(err), zap.Int64("checkpoint_id", checkpoint.ID))
+		return nil, errors.NewAppError(err, "Failed to export audit checkpoint", http.StatusInternalServerError)
+	}
+
+	a.logger.Info("Audit checkpoint created", zap.Int64("checkpoint_id", checkpoint.ID), zap.Int64("seq", checkpoint.Seq))
+	return checkpoint, nil
+}
+
+// walk follows the chain from result's head to its end, advancing the head
+// and counting events, and compares it with the checkpoints after the
+// starting point. It stops at the first broken link.
+func (a *auditUsecase) walk(result *domain.AuditVerification, checkpoints []domain.AuditCheckpoint) error {
+	pending := make(map[int64][]domain.AuditCheckpoint)
+	for _, checkpoint := range checkpoints {
+		if checkpoint.Seq > result.HeadSeq {
+			pending[checkpoint.Seq] = append(pending[checkpoint.Seq], checkpoint)
+		}
+	}
+
+	for {
+		links, err := a.auditRepo.GetChain(result.HeadSeq, verifyBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, link := range links {
+			if reason := checkLink(link, result.HeadSeq, result.HeadHash, pending[link.Seq]); reason != "" {
+				result.Valid = false
+				result.BrokenAt = &domain.AuditChainBreak{Seq: link.Seq, EventID: link.ID, Reason: reason}
+				return nil
+			}
+			delete(pending, link.Seq)
+			result.Events++
+			result.HeadSeq = link.Seq
+			result.HeadEventID = link.ID
+			result.HeadHash = link.Hash
+		}
+
+		if len(links) < verifyBatchSize {
+			break
+		}
+	}
+
+	// Checkpoints past the end of the chain name events that are gone.
+	for seq, missing := range pending {
+		if result.BrokenAt == nil || seq < result.BrokenAt.Seq {
+			result.Valid = false
+			result.BrokenAt = &domain.AuditChainBreak{
+				Seq:     seq,
+				EventID: missing[0].EventID,
+				Reason:  fmt.Sprintf("event named by checkpoint %d is missing", missing[0].ID),
+			}
+		}
+	}
+	return nil
+}
+
+// checkLink returns why link does not follow the event at prevSeq with
+// hash prevHash, or an empty string if it does.
+func checkLink(link domain.AuditChainLink, prevSeq int64, prevHash string, checkpoints []domain.AuditCheckpoint) string {
+	if link.Seq != prevSeq+1 {
+		return fmt.Sprintf("events after seq %d are missing", prevSeq)
+	}
+	if link.PrevHash != prevHash {
+		return "prev_hash does not match the hash of the previous event"
+	}
+	if link.Hash != link.ComputedHash {
+		return "event contents do not match its hash"
+	}
+	for _, checkpoint := range checkpoints {
+		if checkpoint.EventID != link.ID || checkpoint.Hash != link.Hash {
+			return fmt.Sprintf("event does not match checkpoint %d", checkpoint.ID)
+		}
+	}
+	return ""
+}
+
+// checkSignature reports whether the checkpoint was signed with the
+// current key and, if so, whether its signature is valid.
+func (a *auditUsecase) checkSignature(checkpoint domain.AuditCheckpoint) (verified, valid bool) {
+	if a.checkpointKey == nil || checkpoint.KeyID != a.checkpointKID {
+		return false, false
+	}
+	signature, err := base64.StdEncoding.DecodeString(checkpoint.Signature)
+	if err != nil {
+		return true, false
+	}
+	public := a.checkpointKey.Public().(ed25519.PublicKey)
+	return true, ed25519.Verify(public, checkpoint.SignedPayload(), signature)
+}
+
+// recordAudit writes an audit event for a change made through repos, so it
+// commits or rolls back with the change.
+func recordAudit(repos domain.Repositories, actor domain.Actor, action, entityType string, entityID int64, before, after interface{}) error {
+	event, err := domain.NewAuditEvent(actor, action, entityType, entityID, before, after)
+	if err != nil {
+		return err
+	}
+	return repos.Audit.Record(event)
+}
@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+
+	"go.uber.org/zap"
+)
+
+type cartUsecase struct {
+	cartRepo       domain.CartRepository
+	productRepo    domain.ProductRepository
+	transactor     domain.Transactor
+	reservationTTL time.Duration
+	logger         *zap.Logger
+}
+
+// NewCartUsecase creates a cart usecase whose checkouts reserve stock for
+// reservationTTL before the pending purchase orders expire.
+func NewCartUsecase(cartRepo domain.CartRepository, productRepo domain.ProductRepository, transactor domain.Transactor, reservationTTL time.Duration, logger *zap.Logger) domain.CartUsecase {
+	return &cartUsecase{cartRepo: cartRepo, productRepo: productRepo, transactor: transactor, reservationTTL: reservationTTL, logger: logger}
+}
+
+// GetCart implements domain.CartUsecase.
+func (c *cartUsecase) GetCart(userID int64) (*domain.Cart, error) {
+	items, err := c.cartRepo.GetItems(userID)
+	if err != nil {
+		c.logger.Error("Failed to get cart items", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to get cart", http.StatusInternalServerError)
+	}
+
+	return buildCart(items), nil
+}
+
+// AddItem implements domain.CartUsecase. Only products of the actor's
+// tenant can be added.
+func (c *cartUsecase) AddItem(userID int64, request domain.CartItemRequest, actor domain.Actor) (*domain.Cart, error) {
+	c.logger.Debug("AddItem function called", zap.Int64("user_id", userID), zap.Int64("product_id", request.ProductID), zap.Int("quantity", request.Quantity))
+
+	if _, err := c.productRepo.ForTenant(actor.TenantID).GetByID(request.ProductID); err != nil {
+		c.logger.Warn("Failed to get product", zap.Error(err), zap.Int64("product_id", request.ProductID))
+		return nil, errors.NewAppError(errors.ErrProductNotFound, "Product not found", http.StatusNotFound)
+	}
+
+	if err := c.cartRepo.AddItem(userID, request.ProductID, request.Quantity); err != nil {
+		c.logger.Error("Failed to add cart item", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to add item to cart", http.StatusInternalServerError)
+	}
+
+	c.logger.Info("Cart item added successfully", zap.Int64("user_id", userID), zap.Int64("product_id", request.ProductID))
+	return c.GetCart(userID)
+}
+
+// UpdateItem implements domain.CartUsecase.
+func (c *cartUsecase) UpdateItem(userID int64, request domain.CartItemRequest) (*domain.Cart, error) {
+	c.logger.Debug("UpdateItem function called", zap.Int64("user_id", userID), zap.Int64("product_id", request.ProductID), zap.Int("quantity", request.Quantity))
+
+	found, err := c.cartRepo.UpdateItemQuantity(userID, request.ProductID, request.Quantity)
+	if err != nil {
+		c.logger.Error("Failed to update cart item", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to update cart item", http.StatusInternalServerError)
+	}
+	if !found {
+		return nil, errors.NewAppError(errors.ErrCartItemNotFound, "Product is not in the cart", http.StatusNotFound)
+	}
+
+	c.logger.Info("Cart item updated successfully", zap.Int64("user_id", userID), zap.Int64("product_id", request.ProductID))
+	return c.GetCart(userID)
+}
+
+// RemoveItem implements domain.CartUsecase.
+func (c *cartUsecase) RemoveItem(userID, productID int64) (*domain.Cart, error) {
+	found, err := c.cartRepo.RemoveItem(userID, productID)
+	if err != nil {
+		c.logger.Error("Failed to remove cart item", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to remove cart item", http.StatusInternalServerError)
+	}
+	if !found {
+		return nil, errors.NewAppError(errors.ErrCartItemNotFound, "Product is not in the cart", http.StatusNotFound)
+	}
+
+	c.logger.Info("Cart item removed successfully", zap.Int64("user_id", userID), zap.Int64("product_id", productID))
+	return c.GetCart(userID)
+}
+
+// Clear implements domain.CartUsecase.
+func (c *cartUsecase) Clear(userID int64) error {
+	if err := c.cartRepo.Clear(userID); err != nil {
+		c.logger.Error("Failed to clear cart", zap.Error(err), zap.Int64("user_id", userID))
+		return errors.NewAppError(err, "Failed to clear cart", http.StatusInternalServerError)
+	}
+
+	c.logger.Info("Cart cleared successfully", zap.Int64("user_id", userID))
+	return nil
+}
+
+// Checkout implements domain.CartUsecase. The cart is split into one pending
+// purchase order per vendor; available stock and MOQ are checked against the
+// locked product rows so concurrent checkouts cannot oversell. The ordered
+// stock stays reserved until the buyer confirms or the orders expire.
+func (c *cartUsecase) Checkout(userID int64, actor domain.Actor) ([]domain.PurchaseOrder, error) {
+	c.logger.Debug("Checkout function called", zap.Int64("user_id", userID))
+
+	expiresAt := time.Now().Add(c.reservationTTL)
+	var orders []domain.PurchaseOrder
+	err := c.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		member, err := repos.Organizations.GetMember(userID)
+		if err != nil {
+			return err
+		}
+		orders, err = repos.Carts.Checkout(userID, func(items []domain.CartItem) ([]domain.PurchaseOrder, error) {
+			return planCheckout(userID, actor.TenantID, member, items, expiresAt)
+		})
+		if err != nil {
+			return err
+		}
+		for i := range orders {
+			if err := recordAudit(repos, actor, domain.AuditActionCreate, domain.AuditEntityPurchaseOrder, orders[i].ID, nil, orders[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if checkoutErr, ok := err.(*domain.CheckoutError); ok {
+			c.logger.Warn("Checkout rejected", zap.Int64("user_id", userID), zap.Error(err))
+			return nil, errors.NewAppError(checkoutErr, "Some cart items cannot be ordered", http.StatusConflict)
+		}
+		if err == errors.ErrCartEmpty {
+			return nil, errors.NewAppError(err, "Cart is empty", http.StatusBadRequest)
+		}
+		if err == errors.ErrInsufficientStock {
+			c.logger.Warn("Checkout rejected", zap.Int64("user_id", userID), zap.Error(err))
+			return nil, errors.NewAppError(err, "Some cart items are out of stock", http.StatusConflict)
+		}
+		c.logger.Error("Failed to checkout cart", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to checkout cart", http.StatusInternalServerError)
+	}
+
+	c.logger.Info("Cart checked out successfully", zap.Int64("user_id", userID), zap.Int("purchase_orders", len(orders)))
+	return orders, nil
+}
+
+// planCheckout validates available stock and MOQ for every item and groups
+// the items into one pending purchase order per vendor that expires at
+// expiresAt, in the buyer's tenant. Orders of a buyer in an organization are
+// placed for it and the buyer's department.
+func planCheckout(buyerID, tenantID int64, member *domain.OrganizationMember, items []domain.CartItem, expiresAt time.Time) ([]domain.PurchaseOrder, error) {
+	if len(items) == 0 {
+		return nil, errors.ErrCartEmpty
+	}
+
+	var issues []domain.CheckoutIssue
+	for _, item := range items {
+		if item.Quantity > item.AvailableStock {
+			issues = append(issues, domain.CheckoutIssue{
+				ProductID:        item.ProductID,
+				ProductName:      item.ProductName,
+				Reason:           "insufficient stock",
+				Requested:        item.Quantity,
+				Available:        item.AvailableStock,
+				MinOrderQuantity: item.MinOrderQuantity,
+			})
+		}
+		if item.Quantity < item.MinOrderQuantity {
+			issues = append(issues, domain.CheckoutIssue{
+				ProductID:        item.ProductID,
+				ProductName:      item.ProductName,
+				Reason:           "below minimum order quantity",
+				Requested:        item.Quantity,
+				Available:        item.AvailableStock,
+				MinOrderQuantity: item.MinOrderQuantity,
+			})
+		}
+	}
+	if len(issues) > 0 {
+		return nil, &domain.CheckoutError{Issues: issues}
+	}
+
+	var orders []domain.PurchaseOrder
+	orderIndex := map[int64]int{}
+	for _, item := range items {
+		i, ok := orderIndex[item.VendorID]
+		if !ok {
+			orders = append(orders, domain.PurchaseOrder{
+				BuyerID:   buyerID,
+				VendorID:  item.VendorID,
+				TenantID:  tenantID,
+				Status:    domain.PurchaseOrderStatusPending,
+				ExpiresAt: &expiresAt,
+			})
+			i = len(orders) - 1
+			if member != nil {
+				orders[i].OrganizationID = &member.OrganizationID
+				orders[i].DepartmentID = member.DepartmentID
+			}
+			orderIndex[item.VendorID] = i
+		}
+
+		orders[i].Items = append(orders[i].Items, domain.PurchaseOrderItem{
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			UnitPrice:   item.UnitPrice,
+			Quantity:    item.Quantity,
+			Subtotal:    item.Subtotal,
+		})
+		orders[i].TotalAmount += item.Subtotal
+	}
+
+	return orders, nil
+}
+
+// buildCart groups cart items by vendor and computes the subtotals.
+func buildCart(items []domain.CartItem) *domain.Cart {
+	cart := &domain.Cart{Vendors: []domain.VendorCart{}}
+	vendorIndex := map[int64]int{}
+	for _, item := range items {
+		i, ok := vendorIndex[item.VendorID]
+		if !ok {
+			cart.Vendors = append(cart.Vendors, domain.VendorCart{
+				VendorID:   item.VendorID,
+				VendorName: item.VendorName,
+			})
+			i = len(cart.Vendors) - 1
+			vendorIndex[item.VendorID] = i
+		}
+
+		cart.Vendors[i].Items = append(cart.Vendors[i].Items, item)
+		cart.Vendors[i].Subtotal += item.Subtotal
+		cart.ItemCount += item.Quantity
+		cart.Total += item.Subtotal
+	}
+
+	return cart
+}
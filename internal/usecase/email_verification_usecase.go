@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain/converter"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/signedtoken"
+
+	"go.uber.org/zap"
+)
+
+// verificationResendCooldown is the least time between two verification
+// emails to the same user.
+const verificationResendCooldown = time.Minute
+
+// verificationSubjectPrefix binds verification tokens to their purpose.
+const verificationSubjectPrefix = "email-verification"
+
+type emailVerificationUsecase struct {
+	userRepo   domain.UserRepository
+	jobRepo    domain.JobRepository
+	transactor domain.Transactor
+	secret     []byte
+	verifyURL  string
+	linkTTL    time.Duration
+	logger     *zap.Logger
+}
+
+// NewEmailVerificationUsecase creates the email verification usecase.
+// Links point at verifyURL with a token signed with secret in the token
+// query parameter and stay valid for linkTTL.
+func NewEmailVerificationUsecase(userRepo domain.UserRepository, jobRepo domain.JobRepository, transactor domain.Transactor, secret []byte, verifyURL string, linkTTL time.Duration, logger *zap.Logger) domain.EmailVerificationUsecase {
+	return &emailVerificationUsecase{
+		userRepo:   userRepo,
+		jobRepo:    jobRepo,
+		transactor: transactor,
+		secret:     secret,
+		verifyURL:  verifyURL,
+		linkTTL:    linkTTL,
+		logger:     logger,
+	}
+}
+
+// SendVerificationEmail implements domain.EmailVerificationUsecase. The
+// email is sent straight to the address, bypassing notification
+// preferences.
+func (e *emailVerificationUsecase) SendVerificationEmail(job domain.VerificationEmailJob) error {
+	e.logger.Debug("SendVerificationEmail function called", zap.Int64("user_id", job.UserID))
+
+	user, err := e.userRepo.SystemScope().GetByID(job.UserID)
+	if err != nil {
+		e.logger.Error("Failed to get user to verify", zap.Error(err), zap.Int64("user_id", job.UserID))
+		return &domain.PermanentJobError{Err: errors.ErrUserNotFound}
+	}
+	if user.EmailVerifiedAt != nil {
+		e.logger.Info("Email already verified, skipping verification email", zap.Int64("user_id", user.ID))
+		return nil
+	}
+
+	link, err := url.Parse(e.verifyURL)
+	if err != nil {
+		return &domain.PermanentJobError{Err: err}
+	}
+	subject := fmt.Sprintf("%s:%d:%s", verificationSubjectPrefix, user.ID, user.Email)
+	query := link.Query()
+	query.Set("token", signedtoken.New(e.secret, subject, time.Now().Add(e.linkTTL)))
+	link.RawQuery = query.Encode()
+
+	email, err := domain.NewJob(domain.JobEmailSend, domain.EmailJob{
+		To:      []string{user.Email},
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(`Hi %s,
+
+Please confirm your email address by opening the link below:
+
+%s
+
+The link expires in %s. Your registration is reviewed once your email is verified.`, user.Name, link, e.linkTTL),
+	})
+	if err != nil {
+		return &domain.PermanentJobError{Err: err}
+	}
+
+	if err := e.jobRepo.Enqueue(email); err != nil {
+		e.logger.Error("Failed to queue verification email", zap.Error(err), zap.Int64("user_id", user.ID))
+		return err
+	}
+
+	e.logger.Info("Verification email queued", zap.Int64("user_id", user.ID))
+	return nil
+}
+
+// Verify implements domain.EmailVerificationUsecase. Verifying twice
+// succeeds; a link for an address the user has since changed does not.
+func (e *emailVerificationUsecase) Verify(token string, actor domain.Actor) error {
+	e.logger.Debug("Verify function called")
+
+	subject, err := signedtoken.Parse(e.secret, token, time.Now())
+	if err == signedtoken.ErrExpiredToken {
+		e.logger.Warn("Expired verification link")
+		return errors.NewAppError(err, "Verification link has expired, request a new one", http.StatusBadRequest)
+	}
+	if err != nil {
+		e.logger.Warn("Invalid verification link", zap.Error(err))
+		return errors.NewAppError(errors.ErrInvalidVerification, "Invalid verification link", http.StatusBadRequest)
+	}
+
+	userID, email, ok := parseVerificationSubject(subject)
+	if !ok {
+		e.logger.Warn("Invalid verification subject", zap.String("subject", subject))
+		return errors.NewAppError(errors.ErrInvalidVerification, "Invalid verification link", http.StatusBadRequest)
+	}
+
+	err = e.transactor.WithinSystemTransaction(func(repos domain.Repositories) error {
+		before, err := repos.Users.GetByID(userID)
+		if err != nil {
+			return errors.ErrInvalidVerification
+		}
+		if before.Email == email && before.EmailVerifiedAt != nil {
+			return nil
+		}
+
+		verified, err := repos.Users.MarkEmailVerified(userID, email)
+		if err != nil {
+			return err
+		}
+		if !verified {
+			return errors.ErrInvalidVerification
+		}
+
+		after, err := repos.Users.GetByID(userID)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(repos, actor, domain.AuditActionUpdate, domain.AuditEntityUser, userID, converter.UserToUserResponse(before), converter.UserToUserResponse(after)); err != nil {
+			return err
+		}
+		if !after.HasRole(domain.RoleVendor) || after.Status != "pending" {
+			return nil
+		}
+		return queueVendorPendingApproval(repos, after)
+	})
+	switch err {
+	case nil:
+	case errors.ErrInvalidVerification:
+		e.logger.Warn("Verification link for a changed or deleted account", zap.Int64("user_id", userID))
+		return errors.NewAppError(err, "Invalid verification link", http.StatusBadRequest)
+	default:
+		e.logger.Error("Failed to verify email", zap.Error(err), zap.Int64("user_id", userID))
+		return errors.NewAppError(err, "Failed to verify email", http.StatusInternalServerError)
+	}
+
+	e.logger.Info("Email verified successfully", zap.Int64("user_id", userID))
+	return nil
+}
+
+// Resend implements domain.EmailVerificationUsecase. Requests within
+// verificationResendCooldown of the last email are dropped silently.
+func (e *emailVerificationUsecase) Resend(email string) error {
+	e.logger.Debug("Resend function called", zap.String("email", email))
+
+	user, err := e.userRepo.SystemScope().GetByEmail(email)
+	if err != nil {
+		e.logger.Warn("Verification resend requested for unknown email", zap.String("email", email), zap.Error(err))
+		return nil
+	}
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	err = e.transactor.WithinTransaction(user.TenantID, func(repos domain.Repositories) error {
+		return queueVerificationEmail(repos, user.ID, time.Now().Add(-verificationResendCooldown))
+	})
+	if err != nil {
+		e.logger.Error("Failed to queue verification email", zap.Error(err), zap.Int64("user_id", user.ID))
+		return errors.NewAppError(err, "Failed to resend verification email", http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// queueVerificationEmail enqueues a verification email for the user
+// through repos, unless the email is verified or one was sent after
+// sentBefore.
+func queueVerificationEmail(repos domain.Repositories, userID int64, sentBefore time.Time) error {
+	claimed, err := repos.Users.ClaimVerificationEmail(userID, sentBefore)
+	if err != nil || !claimed {
+		return err
+	}
+
+	job, err := domain.NewVerificationEmailJob(userID)
+	if err != nil {
+		return err
+	}
+	return repos.Jobs.Enqueue(job)
+}
+
+// queueVendorPendingApproval notifies every user who may approve vendor
+// in its tenant that it is waiting for them.
+func queueVendorPendingApproval(repos domain.Repositories, vendor *domain.User) error {
+	approverIDs, err := repos.Users.ForTenant(vendor.TenantID).GetIDsWithPermission(domain.PermissionVendorApprove)
+	if err != nil {
+		return err
+	}
+
+	jobs := make([]*domain.Job, 0, len(approverIDs))
+	for _, approverID := range approverIDs {
+		notification, err := domain.NewNotificationJob(approverID, domain.NotificationVendorPendingApproval, map[string]interface{}{
+			"VendorID":    vendor.ID,
+			"VendorName":  vendor.Name,
+			"VendorEmail": vendor.Email,
+		})
+		if err != nil {
+			return err
+		}
+		jobs = append(jobs, notification)
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+	return repos.Jobs.Enqueue(jobs...)
+}
+
+func parseVerificationSubject(subject string) (int64, string, bool) {
+	parts := strings.SplitN(subject, ":", 3)
+	if len(parts) != 3 || parts[0] != verificationSubjectPrefix {
+		return 0, "", false
+	}
+	userID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return userID, parts[2], true
+}
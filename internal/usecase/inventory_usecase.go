@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"net/http"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+
+	"go.uber.org/zap"
+)
+
+type inventoryUsecase struct {
+	inventoryRepo domain.InventoryRepository
+	productRepo   domain.ProductRepository
+	logger        *zap.Logger
+}
+
+func NewInventoryUsecase(inventoryRepo domain.InventoryRepository, productRepo domain.ProductRepository, logger *zap.Logger) domain.InventoryUsecase {
+	return &inventoryUsecase{inventoryRepo: inventoryRepo, productRepo: productRepo, logger: logger}
+}
+
+// GetBalance implements domain.InventoryUsecase.
+func (i *inventoryUsecase) GetBalance(productID int64, actor domain.Actor) (*domain.InventoryBalance, error) {
+	if err := i.checkOwnership(productID, actor); err != nil {
+		return nil, err
+	}
+
+	balance, err := i.inventoryRepo.ForTenant(actor.TenantID).GetBalance(productID)
+	if err != nil {
+		i.logger.Error("Failed to get inventory balance", zap.Error(err), zap.Int64("product_id", productID))
+		return nil, errors.NewAppError(err, "Failed to get inventory balance", http.StatusInternalServerError)
+	}
+
+	return balance, nil
+}
+
+// GetMovements implements domain.InventoryUsecase. Movements are listed
+// newest first.
+func (i *inventoryUsecase) GetMovements(productID int64, page pagination.Params, actor domain.Actor) (*pagination.Page[domain.InventoryMovement], error) {
+	if err := i.checkOwnership(productID, actor); err != nil {
+		return nil, err
+	}
+
+	page = page.Normalize()
+
+	afterID, err := decodeIDCursor(page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryRepo := i.inventoryRepo.ForTenant(actor.TenantID)
+	movements, err := inventoryRepo.GetMovements(productID, afterID, page.Limit+1)
+	if err != nil {
+		i.logger.Error("Failed to get inventory movements", zap.Error(err), zap.Int64("product_id", productID))
+		return nil, errors.NewAppError(err, "Failed to get inventory movements", http.StatusInternalServerError)
+	}
+
+	total, err := inventoryRepo.CountMovements(productID)
+	if err != nil {
+		i.logger.Error("Failed to count inventory movements", zap.Error(err), zap.Int64("product_id", productID))
+		return nil, errors.NewAppError(err, "Failed to count inventory movements", http.StatusInternalServerError)
+	}
+
+	result, err := pagination.NewPage(movements, page.Limit, total, func(movement domain.InventoryMovement) interface{} {
+		return pagination.IDCursor{ID: movement.ID}
+	})
+	if err != nil {
+		return nil, errors.NewAppError(err, "Failed to get inventory movements", http.StatusInternalServerError)
+	}
+
+	return result, nil
+}
+
+// Receive implements domain.InventoryUsecase.
+func (i *inventoryUsecase) Receive(productID int64, request domain.InventoryReceiptRequest, actor domain.Actor) (*domain.InventoryMovement, error) {
+	i.logger.Debug("Receive function called", zap.Int64("product_id", productID), zap.Int("quantity", request.Quantity))
+
+	return i.record(productID, actor, &domain.InventoryMovement{
+		ProductID:    productID,
+		MovementType: domain.MovementReceipt,
+		Quantity:     request.Quantity,
+		OnHandDelta:  request.Quantity,
+		Note:         request.Note,
+		CreatedBy:    &actor.UserID,
+	})
+}
+
+// Adjust implements domain.InventoryUsecase. Stock that is reserved for
+// purchase orders cannot be adjusted away.
+func (i *inventoryUsecase) Adjust(productID int64, request domain.InventoryAdjustmentRequest, actor domain.Actor) (*domain.InventoryMovement, error) {
+	i.logger.Debug("Adjust function called", zap.Int64("product_id", productID), zap.Int("quantity", request.Quantity))
+
+	quantity := request.Quantity
+	if quantity < 0 {
+		quantity = -quantity
+	}
+
+	return i.record(productID, actor, &domain.InventoryMovement{
+		ProductID:    productID,
+		MovementType: domain.MovementAdjustment,
+		Quantity:     quantity,
+		OnHandDelta:  request.Quantity,
+		Note:         request.Note,
+		CreatedBy:    &actor.UserID,
+	})
+}
+
+func (i *inventoryUsecase) record(productID int64, actor domain.Actor, movement *domain.InventoryMovement) (*domain.InventoryMovement, error) {
+	if err := i.checkOwnership(productID, actor); err != nil {
+		return nil, err
+	}
+
+	if err := i.inventoryRepo.ForTenant(actor.TenantID).Record(movement); err != nil {
+		if err == errors.ErrInsufficientStock {
+			i.logger.Warn("Inventory movement rejected", zap.Int64("product_id", productID), zap.Int("on_hand_delta", movement.OnHandDelta))
+			return nil, errors.NewAppError(err, "On-hand stock cannot drop below zero or below the reserved quantity", http.StatusConflict)
+		}
+		i.logger.Error("Failed to record inventory movement", zap.Error(err), zap.Int64("product_id", productID))
+		return nil, errors.NewAppError(err, "Failed to record inventory movement", http.StatusInternalServerError)
+	}
+
+	i.logger.Info("Inventory movement recorded successfully", zap.Int64("product_id", productID), zap.String("movement_type", movement.MovementType), zap.Int("quantity", movement.Quantity))
+	return movement, nil
+}
+
+// checkOwnership verifies that the product exists in the actor's tenant
+// and belongs to the actor.
+func (i *inventoryUsecase) checkOwnership(productID int64, actor domain.Actor) error {
+	product, err := i.productRepo.ForTenant(actor.TenantID).GetByID(productID)
+	if err != nil {
+		i.logger.Warn("Failed to get product", zap.Error(err), zap.Int64("product_id", productID))
+		return errors.NewAppError(errors.ErrProductNotFound, "Product not found", http.StatusNotFound)
+	}
+
+	if product.VendorID != actor.UserID {
+		i.logger.Warn("Product does not belong to the vendor", zap.Int64("product_id", productID), zap.Int64("vendor_id", actor.UserID))
+		return errors.NewAppError(nil, "Product does not belong to the vendor", http.StatusForbidden)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+
+	"go.uber.org/zap"
+)
+
+type jobUsecase struct {
+	jobRepo domain.JobRepository
+	logger  *zap.Logger
+}
+
+func NewJobUsecase(jobRepo domain.JobRepository, logger *zap.Logger) domain.JobUsecase {
+	return &jobUsecase{jobRepo: jobRepo, logger: logger}
+}
+
+// GetJobs implements domain.JobUsecase. An empty status lists every job.
+func (j *jobUsecase) GetJobs(status string, page pagination.Params) (*pagination.Page[domain.Job], error) {
+	page = page.Normalize()
+
+	afterID, err := decodeIDCursor(page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := j.jobRepo.GetJobs(status, afterID, page.Limit+1)
+	if err != nil {
+		j.logger.Error("Failed to get jobs", zap.Error(err), zap.String("status", status))
+		return nil, errors.NewAppError(err, "Failed to get jobs", http.StatusInternalServerError)
+	}
+
+	total, err := j.jobRepo.CountJobs(status)
+	if err != nil {
+		j.logger.Error("Failed to count jobs", zap.Error(err), zap.String("status", status))
+		return nil, errors.NewAppError(err, "Failed to count jobs", http.StatusInternalServerError)
+	}
+
+	result, err := pagination.NewPage(jobs, page.Limit, total, func(job domain.Job) interface{} {
+		return pagination.IDCursor{ID: job.ID}
+	})
+	if err != nil {
+		return nil, errors.NewAppError(err, "Failed to get jobs", http.StatusInternalServerError)
+	}
+
+	return result, nil
+}
+
+// Retry implements domain.JobUsecase. Only dead jobs can be retried; they
+// start again with a full set of attempts.
+func (j *jobUsecase) Retry(id int64) error {
+	j.logger.Debug("Retry function called", zap.Int64("job_id", id))
+
+	found, err := j.jobRepo.Requeue(id)
+	if err != nil {
+		j.logger.Error("Failed to requeue job", zap.Error(err), zap.Int64("job_id", id))
+		return errors.NewAppError(err, "Failed to retry job", http.StatusInternalServerError)
+	}
+	if !found {
+		return errors.NewAppError(errors.ErrJobNotFound, "Dead job not found", http.StatusNotFound)
+	}
+
+	j.logger.Info("Job requeued successfully", zap.Int64("job_id", id))
+	return nil
+}
+
+// Prune implements domain.JobUsecase. Dead jobs are kept for inspection.
+func (j *jobUsecase) Prune(retention time.Duration) (int64, error) {
+	count, err := j.jobRepo.DeleteSucceeded(time.Now().Add(-retention))
+	if err != nil {
+		j.logger.Error("Failed to prune jobs", zap.Error(err))
+		return 0, errors.NewAppError(err, "Failed to prune jobs", http.StatusInternalServerError)
+	}
+
+	j.logger.Info("Succeeded jobs pruned", zap.Int64("count", count))
+	return count, nil
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+
+	"go.uber.org/zap"
+)
+
+type loginAttemptUsecase struct {
+	loginAttemptRepo domain.LoginAttemptRepository
+	logger           *zap.Logger
+}
+
+func NewLoginAttemptUsecase(loginAttemptRepo domain.LoginAttemptRepository, logger *zap.Logger) domain.LoginAttemptUsecase {
+	return &loginAttemptUsecase{
+		loginAttemptRepo: loginAttemptRepo,
+		logger:           logger,
+	}
+}
+
+// GetAttempts implements domain.LoginAttemptUsecase.
+func (l *loginAttemptUsecase) GetAttempts(filter domain.LoginAttemptFilter, page pagination.Params) (*pagination.Page[domain.LoginAttempt], error) {
+	page = page.Normalize()
+
+	afterID, err := decodeIDCursor(page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := l.loginAttemptRepo.GetAll(filter, afterID, page.Limit+1)
+	if err != nil {
+		l.logger.Error("Failed to get login attempts", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to get login attempts", http.StatusInternalServerError)
+	}
+
+	total, err := l.loginAttemptRepo.Count(filter)
+	if err != nil {
+		l.logger.Error("Failed to count login attempts", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to count login attempts", http.StatusInternalServerError)
+	}
+
+	result, err := pagination.NewPage(attempts, page.Limit, total, func(attempt domain.LoginAttempt) interface{} {
+		return pagination.IDCursor{ID: attempt.ID}
+	})
+	if err != nil {
+		return nil, errors.NewAppError(err, "Failed to get login attempts", http.StatusInternalServerError)
+	}
+
+	return result, nil
+}
+
+// Prune implements domain.LoginAttemptUsecase.
+func (l *loginAttemptUsecase) Prune(retention time.Duration) (int64, error) {
+	count, err := l.loginAttemptRepo.DeleteBefore(time.Now().Add(-retention))
+	if err != nil {
+		l.logger.Error("Failed to prune login attempts", zap.Error(err))
+		return 0, errors.NewAppError(err, "Failed to prune login attempts", http.StatusInternalServerError)
+	}
+
+	l.logger.Info("Login attempts pruned", zap.Int64("count", count))
+	return count, nil
+}
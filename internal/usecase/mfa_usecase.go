@@ -0,0 +1,432 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/hash"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/secretbox"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/totp"
+
+	"go.uber.org/zap"
+)
+
+// recoveryCodeCount is how many recovery codes a user gets at a time.
+const recoveryCodeCount = 10
+
+// totpSkew is how many time steps of clock drift codes are accepted with.
+const totpSkew = 1
+
+type mfaUsecase struct {
+	userRepo   domain.UserRepository
+	mfaRepo    domain.MFARepository
+	roleRepo   domain.RoleRepository
+	transactor domain.Transactor
+	box        *secretbox.Box
+	issuer     string
+	sessions   domain.SessionUsecase
+	logger     *zap.Logger
+}
+
+// NewMFAUsecase creates the MFA usecase. TOTP secrets are encrypted with
+// box before they are stored. issuer is the name authenticator apps show
+// for the account.
+func NewMFAUsecase(userRepo domain.UserRepository, mfaRepo domain.MFARepository, roleRepo domain.RoleRepository, transactor domain.Transactor, box *secretbox.Box, issuer string, sessions domain.SessionUsecase, logger *zap.Logger) domain.MFAUsecase {
+	return &mfaUsecase{
+		userRepo:   userRepo,
+		mfaRepo:    mfaRepo,
+		roleRepo:   roleRepo,
+		transactor: transactor,
+		box:        box,
+		issuer:     issuer,
+		sessions:   sessions,
+		logger:     logger,
+	}
+}
+
+// GetStatus implements domain.MFAUsecase.
+func (m *mfaUsecase) GetStatus(userID int64) (*domain.MFAStatus, error) {
+	user, err := m.userRepo.SystemScope().GetByID(userID)
+	if err != nil {
+		m.logger.Warn("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(errors.ErrUserNotFound, "User not found", http.StatusNotFound)
+	}
+
+	mfa, err := m.mfaRepo.Get(userID)
+	if err != nil {
+		m.logger.Error("Failed to get MFA enrollment", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to get MFA status", http.StatusInternalServerError)
+	}
+
+	required, err := mfaRequired(m.roleRepo, user)
+	if err != nil {
+		m.logger.Error("Failed to get user permissions", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to get MFA status", http.StatusInternalServerError)
+	}
+
+	status := &domain.MFAStatus{Required: required}
+	if mfa != nil {
+		status.Enabled = mfa.ConfirmedAt != nil
+		status.PendingEnrollment = mfa.ConfirmedAt == nil
+	}
+	if status.Enabled {
+		status.RecoveryCodesLeft, err = m.mfaRepo.CountRecoveryCodes(userID)
+		if err != nil {
+			m.logger.Error("Failed to count recovery codes", zap.Error(err), zap.Int64("user_id", userID))
+			return nil, errors.NewAppError(err, "Failed to get MFA status", http.StatusInternalServerError)
+		}
+	}
+	return status, nil
+}
+
+// Enroll implements domain.MFAUsecase.
+func (m *mfaUsecase) Enroll(userID int64) (*domain.MFAEnrollment, error) {
+	m.logger.Debug("Enroll function called", zap.Int64("user_id", userID))
+
+	user, err := m.userRepo.SystemScope().GetByID(userID)
+	if err != nil {
+		m.logger.Warn("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(errors.ErrUserNotFound, "User not found", http.StatusNotFound)
+	}
+
+	secret, err := totp.NewSecret()
+	if err != nil {
+		m.logger.Error("Failed to generate TOTP secret", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to start MFA enrollment", http.StatusInternalServerError)
+	}
+	sealed, err := m.box.Seal(secret)
+	if err != nil {
+		m.logger.Error("Failed to encrypt TOTP secret", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to start MFA enrollment", http.StatusInternalServerError)
+	}
+
+	ok, err := m.mfaRepo.SavePending(userID, sealed)
+	if err != nil {
+		m.logger.Error("Failed to save MFA enrollment", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to start MFA enrollment", http.StatusInternalServerError)
+	}
+	if !ok {
+		m.logger.Warn("MFA enrollment with MFA already enabled", zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(errors.ErrMFAAlreadyEnabled, "MFA is already enabled", http.StatusConflict)
+	}
+
+	m.logger.Info("MFA enrollment started", zap.Int64("user_id", userID))
+	return &domain.MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(m.issuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm implements domain.MFAUsecase.
+func (m *mfaUsecase) Confirm(userID int64, code string, actor domain.Actor) ([]string, error) {
+	m.logger.Debug("Confirm function called", zap.Int64("user_id", userID))
+
+	var recoveryCodes []string
+	err := m.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		mfa, err := repos.MFA.Get(userID)
+		if err != nil {
+			return err
+		}
+		if mfa == nil {
+			return errors.ErrMFANotEnrolled
+		}
+		if mfa.ConfirmedAt != nil {
+			return errors.ErrMFAAlreadyEnabled
+		}
+		if err := m.checkCode(repos.MFA, mfa, code); err != nil {
+			return err
+		}
+		recoveryCodes, err = m.enable(repos, userID, actor)
+		return err
+	})
+	if err != nil {
+		m.logger.Warn("Failed to confirm MFA enrollment", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, mfaError(err, "Failed to confirm MFA enrollment")
+	}
+
+	m.logger.Info("MFA enabled", zap.Int64("user_id", userID))
+	return recoveryCodes, nil
+}
+
+// Disable implements domain.MFAUsecase.
+func (m *mfaUsecase) Disable(userID int64, code string, actor domain.Actor) error {
+	m.logger.Debug("Disable function called", zap.Int64("user_id", userID))
+
+	user, err := m.userRepo.SystemScope().GetByID(userID)
+	if err != nil {
+		m.logger.Warn("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return errors.NewAppError(errors.ErrUserNotFound, "User not found", http.StatusNotFound)
+	}
+	required, err := mfaRequired(m.roleRepo, user)
+	if err != nil {
+		m.logger.Error("Failed to get user permissions", zap.Error(err), zap.Int64("user_id", userID))
+		return errors.NewAppError(err, "Failed to disable MFA", http.StatusInternalServerError)
+	}
+	if required {
+		m.logger.Warn("Attempt to disable required MFA", zap.Int64("user_id", userID))
+		return errors.NewAppError(errors.ErrMFARequired, "MFA cannot be disabled for this account", http.StatusForbidden)
+	}
+
+	err = m.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		mfa, err := repos.MFA.Get(userID)
+		if err != nil {
+			return err
+		}
+		if mfa == nil || mfa.ConfirmedAt == nil {
+			return errors.ErrMFANotEnrolled
+		}
+		if err := m.checkCode(repos.MFA, mfa, code); err != nil {
+			return err
+		}
+		if err := repos.MFA.Delete(userID); err != nil {
+			return err
+		}
+		return recordAudit(repos, actor, domain.AuditActionUpdate, domain.AuditEntityUser, userID, mfaAuditState(true), mfaAuditState(false))
+	})
+	if err != nil {
+		m.logger.Warn("Failed to disable MFA", zap.Error(err), zap.Int64("user_id", userID))
+		return mfaError(err, "Failed to disable MFA")
+	}
+
+	m.logger.Info("MFA disabled", zap.Int64("user_id", userID))
+	return nil
+}
+
+// RegenerateRecoveryCodes implements domain.MFAUsecase. The old codes stop
+// working.
+func (m *mfaUsecase) RegenerateRecoveryCodes(userID int64, code string) ([]string, error) {
+	m.logger.Debug("RegenerateRecoveryCodes function called", zap.Int64("user_id", userID))
+
+	var recoveryCodes []string
+	err := m.transactor.WithinSystemTransaction(func(repos domain.Repositories) error {
+		mfa, err := repos.MFA.Get(userID)
+		if err != nil {
+			return err
+		}
+		if mfa == nil || mfa.ConfirmedAt == nil {
+			return errors.ErrMFANotEnrolled
+		}
+		if err := m.checkCode(repos.MFA, mfa, code); err != nil {
+			return err
+		}
+		recoveryCodes, err = replaceRecoveryCodes(repos.MFA, userID)
+		return err
+	})
+	if err != nil {
+		m.logger.Warn("Failed to regenerate recovery codes", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, mfaError(err, "Failed to regenerate recovery codes")
+	}
+
+	m.logger.Info("Recovery codes regenerated", zap.Int64("user_id", userID))
+	return recoveryCodes, nil
+}
+
+// EnrollWithChallenge implements domain.MFAUsecase. It does not use up an
+// attempt, since it checks nothing the password login did not.
+func (m *mfaUsecase) EnrollWithChallenge(challengeToken string) (*domain.MFAEnrollment, error) {
+	challenge, err := m.mfaRepo.GetChallenge(hash.HashToken(challengeToken))
+	if err != nil {
+		m.logger.Error("Failed to get MFA challenge", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to start MFA enrollment", http.StatusInternalServerError)
+	}
+	if challenge == nil {
+		m.logger.Warn("MFA enrollment with invalid challenge")
+		return nil, errors.NewAppError(errors.ErrInvalidMFAChallenge, "Invalid or expired MFA challenge", http.StatusUnauthorized)
+	}
+	return m.Enroll(challenge.UserID)
+}
+
+// VerifyLogin implements domain.MFAUsecase. Every call uses up one of the
+// challenge's attempts, whether the code is right or not. For a user who
+// is enrolling, only a TOTP code is accepted and it confirms the
+// enrollment; the result then carries the new recovery codes.
+func (m *mfaUsecase) VerifyLogin(challengeToken, code string, actor domain.Actor) (*domain.LoginResult, error) {
+	m.logger.Debug("VerifyLogin function called")
+
+	challenge, err := m.mfaRepo.CountChallengeAttempt(hash.HashToken(challengeToken))
+	if err != nil {
+		m.logger.Error("Failed to get MFA challenge", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to verify MFA code", http.StatusInternalServerError)
+	}
+	if challenge == nil {
+		m.logger.Warn("MFA login with invalid challenge")
+		return nil, errors.NewAppError(errors.ErrInvalidMFAChallenge, "Invalid or expired MFA challenge", http.StatusUnauthorized)
+	}
+
+	user, err := m.userRepo.SystemScope().GetByID(challenge.UserID)
+	if err != nil {
+		m.logger.Warn("Failed to get user", zap.Error(err), zap.Int64("user_id", challenge.UserID))
+		return nil, errors.NewAppError(errors.ErrInvalidMFAChallenge, "Invalid or expired MFA challenge", http.StatusUnauthorized)
+	}
+	if user.Status != "active" {
+		m.logger.Warn("MFA login with non-active user", zap.Int64("user_id", user.ID))
+		return nil, errors.NewAppError(errors.ErrUserNotActive, "trying to login with non-active user", http.StatusUnauthorized)
+	}
+
+	var recoveryCodes []string
+	err = m.transactor.WithinTransaction(user.TenantID, func(repos domain.Repositories) error {
+		mfa, err := repos.MFA.Get(user.ID)
+		if err != nil {
+			return err
+		}
+		if mfa == nil {
+			return errors.ErrMFANotEnrolled
+		}
+		if err := m.checkCode(repos.MFA, mfa, code); err != nil {
+			return err
+		}
+		ok, err := repos.MFA.UseChallenge(challenge.ID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.ErrInvalidMFAChallenge
+		}
+		if mfa.ConfirmedAt == nil {
+			if actor.UserID == 0 {
+				actor.UserID = user.ID
+				actor.Role = strings.Join(user.Roles, ",")
+			}
+			recoveryCodes, err = m.enable(repos, user.ID, actor)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		m.logger.Warn("MFA login failed", zap.Error(err), zap.Int64("user_id", user.ID))
+		return nil, mfaError(err, "Failed to verify MFA code")
+	}
+
+	result, err := m.sessions.Start(user, actor)
+	if err != nil {
+		return nil, err
+	}
+	result.RecoveryCodes = recoveryCodes
+
+	m.logger.Info("User logged in successfully", zap.Int64("user_id", user.ID))
+	return result, nil
+}
+
+// Prune implements domain.MFAUsecase.
+func (m *mfaUsecase) Prune() (int64, error) {
+	deleted, err := m.mfaRepo.DeleteExpiredChallenges(time.Now())
+	if err != nil {
+		m.logger.Error("Failed to prune MFA challenges", zap.Error(err))
+		return 0, err
+	}
+	m.logger.Info("MFA challenges pruned", zap.Int64("deleted", deleted))
+	return deleted, nil
+}
+
+// checkCode accepts a TOTP code, once per time step, or, once enrollment
+// is confirmed, an unused recovery code. It returns
+// errors.ErrInvalidMFACode otherwise.
+func (m *mfaUsecase) checkCode(repo domain.MFARepository, mfa *domain.MFA, code string) error {
+	code = normalizeMFACode(code)
+
+	var ok bool
+	if isTOTPCode(code) {
+		secret, err := m.box.Open(mfa.Secret)
+		if err != nil {
+			return err
+		}
+		step, valid := totp.Validate(secret, code, time.Now(), totpSkew)
+		if valid {
+			ok, err = repo.UseStep(mfa.UserID, step)
+			if err != nil {
+				return err
+			}
+		}
+	} else if mfa.ConfirmedAt != nil {
+		var err error
+		ok, err = repo.UseRecoveryCode(mfa.UserID, hash.HashToken(code))
+		if err != nil {
+			return err
+		}
+	}
+
+	if !ok {
+		return errors.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// enable confirms the enrollment and issues the first recovery codes.
+func (m *mfaUsecase) enable(repos domain.Repositories, userID int64, actor domain.Actor) ([]string, error) {
+	if err := repos.MFA.Confirm(userID); err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := replaceRecoveryCodes(repos.MFA, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordAudit(repos, actor, domain.AuditActionUpdate, domain.AuditEntityUser, userID, mfaAuditState(false), mfaAuditState(true)); err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// replaceRecoveryCodes generates a new set of recovery codes, formatted
+// XXXX-XXXX-XXXX-XXXX, and stores their hashes.
+func replaceRecoveryCodes(repo domain.MFARepository, userID int64) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		raw := base32.StdEncoding.EncodeToString(b)
+		codes[i] = raw[0:4] + "-" + raw[4:8] + "-" + raw[8:12] + "-" + raw[12:16]
+		hashes[i] = hash.HashToken(raw)
+	}
+	if err := repo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// normalizeMFACode drops the separators users tend to type and uppercases
+// recovery codes, which are base32.
+func normalizeMFACode(code string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// mfaAuditState is what audit events record about a user's MFA.
+func mfaAuditState(enabled bool) map[string]bool {
+	return map[string]bool{"mfa_enabled": enabled}
+}
+
+// mfaError maps the errors returned inside the MFA transactions to
+// responses.
+func mfaError(err error, message string) error {
+	switch err {
+	case errors.ErrInvalidMFACode:
+		return errors.NewAppError(err, "Invalid MFA code", http.StatusUnauthorized)
+	case errors.ErrInvalidMFAChallenge:
+		return errors.NewAppError(err, "Invalid or expired MFA challenge", http.StatusUnauthorized)
+	case errors.ErrMFANotEnrolled:
+		return errors.NewAppError(err, "MFA is not set up, enroll an authenticator first", http.StatusConflict)
+	case errors.ErrMFAAlreadyEnabled:
+		return errors.NewAppError(err, "MFA is already enabled", http.StatusConflict)
+	default:
+		return errors.NewAppError(err, message, http.StatusInternalServerError)
+	}
+}
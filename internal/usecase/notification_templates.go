@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"text/template"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+)
+
+type notificationTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+func newNotificationTemplate(event, subject, body string) notificationTemplate {
+	return notificationTemplate{
+		subject: template.Must(template.New(event + "_subject").Parse(subject)),
+		body:    template.Must(template.New(event + "_body").Parse(body)),
+	}
+}
+
+// notificationTemplates maps each notification event to its message. The
+// templates are rendered with the data of the notification job plus the
+// recipient's Name.
+var notificationTemplates = map[string]notificationTemplate{
+	domain.NotificationVendorPendingApproval: newNotificationTemplate(domain.NotificationVendorPendingApproval,
+		"Vendor {{.VendorName}} is awaiting approval",
+		`Hi {{.Name}},
+
+{{.VendorName}} ({{.VendorEmail}}) verified their email and is waiting for approval as vendor #{{.VendorID}}.`),
+	domain.NotificationVendorApproved: newNotificationTemplate(domain.NotificationVendorApproved,
+		"Your vendor account has been approved",
+		`Hi {{.Name}},
+
+Your vendor account has been approved. You can now sign in and list your products.`),
+	domain.NotificationVendorRejected: newNotificationTemplate(domain.NotificationVendorRejected,
+		"Your vendor registration was not approved",
+		`Hi {{.Name}},
+
+Unfortunately your vendor registration was not approved. Please contact the procurement team for details.`),
+	domain.NotificationPurchaseOrderIssued: newNotificationTemplate(domain.NotificationPurchaseOrderIssued,
+		"New purchase order #{{.PurchaseOrderID}}",
+		`Hi {{.Name}},
+
+Purchase order #{{.PurchaseOrderID}} for a total of {{.TotalAmount}} has been issued to you and is ready to ship.`),
+	domain.NotificationPurchaseOrderCancelled: newNotificationTemplate(domain.NotificationPurchaseOrderCancelled,
+		"Purchase order #{{.PurchaseOrderID}} was cancelled",
+		`Hi {{.Name}},
+
+The buyer cancelled purchase order #{{.PurchaseOrderID}}. Do not ship it.`),
+	domain.NotificationPurchaseOrderShipped: newNotificationTemplate(domain.NotificationPurchaseOrderShipped,
+		"Purchase order #{{.PurchaseOrderID}} has shipped",
+		`Hi {{.Name}},
+
+Your purchase order #{{.PurchaseOrderID}} has been shipped by the vendor.`),
+	domain.NotificationPasswordChanged: newNotificationTemplate(domain.NotificationPasswordChanged,
+		"Your password was changed",
+		`Hi {{.Name}},
+
+The password for your account was just reset. If you did not do this, contact the procurement team immediately.`),
+	domain.NotificationAccountLocked: newNotificationTemplate(domain.NotificationAccountLocked,
+		"Your account has been locked",
+		`Hi {{.Name}},
+
+After {{.Failures}} failed sign-in attempts your account is locked until {{.LockedUntil}}. If this was not you, reset your password and contact the procurement team.`),
+	domain.NotificationRFQClosed: newNotificationTemplate(domain.NotificationRFQClosed,
+		"RFQ {{.Title}} has closed",
+		`Hi {{.Name}},
+
+Your request for quotation #{{.RFQID}}, {{.Title}}, reached its deadline of {{.Deadline}} and no longer accepts quotes.`),
+	domain.NotificationApprovalRequested: newNotificationTemplate(domain.NotificationApprovalRequested,
+		"Purchase order #{{.PurchaseOrderID}} awaits your approval",
+		`Hi {{.Name}},
+
+The buyer asked you to approve purchase order #{{.PurchaseOrderID}} for a total of {{.TotalAmount}}. Approve or reject approval #{{.ApprovalID}}.`),
+	domain.NotificationApprovalEscalated: newNotificationTemplate(domain.NotificationApprovalEscalated,
+		"Approval of purchase order #{{.PurchaseOrderID}} is overdue",
+		`Hi {{.Name}},
+
+Purchase order #{{.PurchaseOrderID}} has been waiting for approval by user #{{.ApproverID}} since {{.IdleSince}}. Please follow up or reassign the approval.`),
+	domain.NotificationVendorDocumentExpiring: newNotificationTemplate(domain.NotificationVendorDocumentExpiring,
+		"Your document {{.DocumentName}} expires soon",
+		`Hi {{.Name}},
+
+Your document {{.DocumentName}} expires on {{.ExpiresAt}}. Please upload a renewed copy before then.`),
+	domain.NotificationLowStock: newNotificationTemplate(domain.NotificationLowStock,
+		"{{.ProductName}} is running low",
+		`Hi {{.Name}},
+
+Only {{.Available}} of {{.ProductName}} (product #{{.ProductID}}) are available, at or below the low-stock threshold you set. Receive more stock to keep it orderable.`),
+	domain.NotificationOutOfStock: newNotificationTemplate(domain.NotificationOutOfStock,
+		"{{.ProductName}} is out of stock",
+		`Hi {{.Name}},
+
+{{.ProductName}} (product #{{.ProductID}}) has no available stock left. Buyers cannot order it until you receive more.`),
+	domain.NotificationBackInStock: newNotificationTemplate(domain.NotificationBackInStock,
+		"{{.ProductName}} is back in stock",
+		`Hi {{.Name}},
+
+{{.ProductName}} (product #{{.ProductID}}), which you follow, is back in stock with {{.Available}} available.`),
+}
@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/mailer"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+
+	"go.uber.org/zap"
+)
+
+type notificationUsecase struct {
+	notificationRepo domain.NotificationRepository
+	userRepo         domain.UserRepository
+	transactor       domain.Transactor
+	mailer           mailer.Mailer
+	logger           *zap.Logger
+}
+
+// NewNotificationUsecase creates a notification usecase. A nil mailer
+// disables the email channel.
+func NewNotificationUsecase(notificationRepo domain.NotificationRepository, userRepo domain.UserRepository, transactor domain.Transactor, mailer mailer.Mailer, logger *zap.Logger) domain.NotificationUsecase {
+	return &notificationUsecase{notificationRepo: notificationRepo, userRepo: userRepo, transactor: transactor, mailer: mailer, logger: logger}
+}
+
+// Deliver implements domain.NotificationUsecase. The message is stored in
+// the inbox and an email job is queued according to the user's channel
+// preferences, both in one transaction so a retry does not duplicate
+// either.
+func (n *notificationUsecase) Deliver(job domain.NotificationJob) error {
+	n.logger.Debug("Deliver function called", zap.Int64("user_id", job.UserID), zap.String("event", job.Event))
+
+	user, err := n.userRepo.SystemScope().GetByID(job.UserID)
+	if err != nil {
+		n.logger.Error("Failed to get notification recipient", zap.Error(err), zap.Int64("user_id", job.UserID))
+		return &domain.PermanentJobError{Err: errors.ErrUserNotFound}
+	}
+
+	subject, body, err := renderNotification(job.Event, user, job.Data)
+	if err != nil {
+		n.logger.Error("Failed to render notification", zap.Error(err), zap.String("event", job.Event))
+		return &domain.PermanentJobError{Err: err}
+	}
+
+	err = n.transactor.WithinTransaction(user.TenantID, func(repos domain.Repositories) error {
+		preferences, err := repos.Notifications.GetPreferences(job.UserID)
+		if err != nil {
+			return err
+		}
+
+		if preferences.InApp {
+			notification := &domain.Notification{UserID: job.UserID, EventType: job.Event, Subject: subject, Body: body}
+			if err := repos.Notifications.Create(notification); err != nil {
+				return err
+			}
+		}
+
+		if preferences.Email && n.mailer != nil {
+			email, err := domain.NewJob(domain.JobEmailSend, domain.EmailJob{To: []string{user.Email}, Subject: subject, Body: body})
+			if err != nil {
+				return err
+			}
+			if err := repos.Jobs.Enqueue(email); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		n.logger.Error("Failed to deliver notification", zap.Error(err), zap.Int64("user_id", job.UserID), zap.String("event", job.Event))
+		return err
+	}
+
+	n.logger.Info("Notification delivered", zap.Int64("user_id", job.UserID), zap.String("event", job.Event))
+	return nil
+}
+
+// SendEmail implements domain.NotificationUsecase.
+func (n *notificationUsecase) SendEmail(job domain.EmailJob) error {
+	if n.mailer == nil {
+		return &domain.PermanentJobError{Err: fmt.Errorf("email is disabled")}
+	}
+
+	if err := n.mailer.Send(mailer.Message{To: job.To, Subject: job.Subject, Body: job.Body}); err != nil {
+		n.logger.Warn("Failed to send email", zap.Error(err), zap.Strings("to", job.To))
+		return err
+	}
+
+	n.logger.Info("Email sent", zap.Strings("to", job.To), zap.String("subject", job.Subject))
+	return nil
+}
+
+// GetInbox implements domain.NotificationUsecase.
+func (n *notificationUsecase) GetInbox(userID int64, unreadOnly bool, page pagination.Params) (*domain.NotificationInbox, error) {
+	page = page.Normalize()
+
+	afterID, err := decodeIDCursor(page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := n.notificationRepo.GetByUserID(userID, unreadOnly, afterID, page.Limit+1)
+	if err != nil {
+		n.logger.Error("Failed to get notifications", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to get notifications", http.StatusInternalServerError)
+	}
+
+	total, err := n.notificationRepo.CountByUserID(userID, unreadOnly)
+	if err != nil {
+		n.logger.Error("Failed to count notifications", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to count notifications", http.StatusInternalServerError)
+	}
+
+	unread := total
+	if !unreadOnly {
+		if unread, err = n.notificationRepo.CountByUserID(userID, true); err != nil {
+			n.logger.Error("Failed to count unread notifications", zap.Error(err), zap.Int64("user_id", userID))
+			return nil, errors.NewAppError(err, "Failed to count notifications", http.StatusInternalServerError)
+		}
+	}
+
+	result, err := pagination.NewPage(notifications, page.Limit, total, func(notification domain.Notification) interface{} {
+		return pagination.IDCursor{ID: notification.ID}
+	})
+	if err != nil {
+		return nil, errors.NewAppError(err, "Failed to get notifications", http.StatusInternalServerError)
+	}
+
+	return &domain.NotificationInbox{Page: *result, Unread: unread}, nil
+}
+
+// SetRead implements domain.NotificationUsecase.
+func (n *notificationUsecase) SetRead(id, userID int64, read bool) error {
+	found, err := n.notificationRepo.SetRead(id, userID, read)
+	if err != nil {
+		n.logger.Error("Failed to update notification", zap.Error(err), zap.Int64("notification_id", id))
+		return errors.NewAppError(err, "Failed to update notification", http.StatusInternalServerError)
+	}
+	if !found {
+		return errors.NewAppError(errors.ErrNotificationNotFound, "Notification not found", http.StatusNotFound)
+	}
+
+	return nil
+}
+
+// MarkAllRead implements domain.NotificationUsecase. It returns the number
+// of notifications that were unread.
+func (n *notificationUsecase) MarkAllRead(userID int64) (int64, error) {
+	count, err := n.notificationRepo.MarkAllRead(userID)
+	if err != nil {
+		n.logger.Error("Failed to mark notifications read", zap.Error(err), zap.Int64("user_id", userID))
+		return 0, errors.NewAppError(err, "Failed to mark notifications read", http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
+// GetPreferences implements domain.NotificationUsecase.
+func (n *notificationUsecase) GetPreferences(userID int64) (*domain.NotificationPreferences, error) {
+	preferences, err := n.notificationRepo.GetPreferences(userID)
+	if err != nil {
+		n.logger.Error("Failed to get notification preferences", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to get notification preferences", http.StatusInternalServerError)
+	}
+
+	return preferences, nil
+}
+
+// UpdatePreferences implements domain.NotificationUsecase.
+func (n *notificationUsecase) UpdatePreferences(userID int64, preferences domain.NotificationPreferences) error {
+	if err := n.notificationRepo.UpdatePreferences(userID, preferences); err != nil {
+		n.logger.Error("Failed to update notification preferences", zap.Error(err), zap.Int64("user_id", userID))
+		return errors.NewAppError(err, "Failed to update notification preferences", http.StatusInternalServerError)
+	}
+
+	n.logger.Info("Notification preferences updated successfully", zap.Int64("user_id", userID))
+	return nil
+}
+
+// renderNotification fills in the event's templates. The recipient's name
+// is available to every template as Name.
+func renderNotification(event string, user *domain.User, data map[string]interface{}) (string, string, error) {
+	tmpl, ok := notificationTemplates[event]
+	if !ok {
+		return "", "", fmt.Errorf("unknown notification event %q", event)
+	}
+
+	values := map[string]interface{}{"Name": user.Name}
+	for key, value := range data {
+		values[key] = value
+	}
+
+	var subject, body strings.Builder
+	if err := tmpl.subject.Execute(&subject, values); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.body.Execute(&body, values); err != nil {
+		return "", "", err
+	}
+
+	return subject.String(), body.String(), nil
+}
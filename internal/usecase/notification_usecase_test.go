@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+)
+
+func TestStockAlertNotifications(t *testing.T) {
+	tests := []struct {
+		alertType string
+		wantEvent string
+		wantText  string
+	}{
+		{alertType: domain.StockAlertLowStock, wantEvent: domain.NotificationLowStock, wantText: "Only 2 of Paper"},
+		{alertType: domain.StockAlertOutOfStock, wantEvent: domain.NotificationOutOfStock, wantText: "Paper (product #3) has no available stock"},
+		{alertType: domain.StockAlertBackInStock, wantEvent: domain.NotificationBackInStock, wantText: "back in stock with 2 available"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alertType, func(t *testing.T) {
+			jobs, err := domain.NewStockAlertNotificationJobs(tt.alertType, 3, "Paper", 2, []int64{7, 8})
+			if err != nil {
+				t.Fatalf("NewStockAlertNotificationJobs() error = %v", err)
+			}
+			if len(jobs) != 2 {
+				t.Fatalf("jobs = %d, want one per recipient", len(jobs))
+			}
+
+			for i, job := range jobs {
+				// Decode the payload as the worker does, so the template
+				// sees the data after a round trip through the outbox.
+				var payload domain.NotificationJob
+				if err := json.Unmarshal(job.Payload, &payload); err != nil {
+					t.Fatalf("decode payload: %v", err)
+				}
+				if job.Type != domain.JobNotificationDeliver || payload.UserID != []int64{7, 8}[i] || payload.Event != tt.wantEvent {
+					t.Errorf("job = %s to %d for %q, want %s to %d for %q", job.Type, payload.UserID, payload.Event, domain.JobNotificationDeliver, []int64{7, 8}[i], tt.wantEvent)
+				}
+
+				subject, body, err := renderNotification(payload.Event, &domain.User{Name: "Vera"}, payload.Data)
+				if err != nil {
+					t.Fatalf("renderNotification() error = %v", err)
+				}
+				if !strings.Contains(subject, "Paper") || !strings.Contains(body, tt.wantText) {
+					t.Errorf("rendered %q / %q, want the product and %q", subject, body, tt.wantText)
+				}
+			}
+		})
+	}
+}
+
+func TestStockAlertNotificationsUnknownType(t *testing.T) {
+	if _, err := domain.NewStockAlertNotificationJobs("restocked", 3, "Paper", 2, []int64{7}); err == nil {
+		t.Error("NewStockAlertNotificationJobs() error = nil, want an error for an unknown alert type")
+	}
+}
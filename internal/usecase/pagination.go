@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"net/http"
+
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+)
+
+// decodeIDCursor returns the id to continue after, or nil for the first page.
+func decodeIDCursor(cursor string) (*int64, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	var position pagination.IDCursor
+	if err := pagination.DecodeCursor(cursor, &position); err != nil {
+		return nil, errors.NewAppError(err, "Invalid cursor", http.StatusBadRequest)
+	}
+	return &position.ID, nil
+}
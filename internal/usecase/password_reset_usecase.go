@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain/converter"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/hash"
+
+	"go.uber.org/zap"
+)
+
+type passwordResetUsecase struct {
+	userRepo          domain.UserRepository
+	passwordResetRepo domain.PasswordResetRepository
+	transactor        domain.Transactor
+	tokenTTL          time.Duration
+	resetURL          string
+	logger            *zap.Logger
+}
+
+// NewPasswordResetUsecase creates the password reset usecase. Reset links
+// point at resetURL with the token in the token query parameter and stay
+// valid for tokenTTL.
+func NewPasswordResetUsecase(userRepo domain.UserRepository, passwordResetRepo domain.PasswordResetRepository, transactor domain.Transactor, tokenTTL time.Duration, resetURL string, logger *zap.Logger) domain.PasswordResetUsecase {
+	return &passwordResetUsecase{
+		userRepo:          userRepo,
+		passwordResetRepo: passwordResetRepo,
+		transactor:        transactor,
+		tokenTTL:          tokenTTL,
+		resetURL:          resetURL,
+		logger:            logger,
+	}
+}
+
+// ForgotPassword implements domain.PasswordResetUsecase. The email is sent
+// straight to the address, bypassing notification preferences, and
+// through the outbox, so the response time does not depend on SMTP.
+func (p *passwordResetUsecase) ForgotPassword(email string) error {
+	p.logger.Debug("ForgotPassword function called", zap.String("email", email))
+
+	user, err := p.userRepo.SystemScope().GetByEmail(email)
+	if err != nil {
+		p.logger.Warn("Password reset requested for unknown email", zap.String("email", email), zap.Error(err))
+		return nil
+	}
+
+	token, tokenHash, err := hash.NewToken()
+	if err != nil {
+		p.logger.Error("Failed to generate reset token", zap.Error(err))
+		return errors.NewAppError(err, "Failed to request password reset", http.StatusInternalServerError)
+	}
+
+	link, err := url.Parse(p.resetURL)
+	if err != nil {
+		p.logger.Error("Invalid password reset URL", zap.Error(err), zap.String("url", p.resetURL))
+		return errors.NewAppError(err, "Failed to request password reset", http.StatusInternalServerError)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	job, err := domain.NewJob(domain.JobEmailSend, domain.EmailJob{
+		To:      []string{user.Email},
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(`Hi %s,
+
+We received a request to reset your password. Open the link below to choose a new one:
+
+%s
+
+The link expires in %s and can be used once. If you did not ask for this, you can ignore this email.`, user.Name, link, p.tokenTTL),
+	})
+	if err != nil {
+		p.logger.Error("Failed to build reset email", zap.Error(err))
+		return errors.NewAppError(err, "Failed to request password reset", http.StatusInternalServerError)
+	}
+
+	err = p.transactor.WithinTransaction(user.TenantID, func(repos domain.Repositories) error {
+		if err := repos.PasswordResets.Create(user.ID, tokenHash, time.Now().Add(p.tokenTTL)); err != nil {
+			return err
+		}
+		return repos.Jobs.Enqueue(job)
+	})
+	if err != nil {
+		p.logger.Error("Failed to create reset token", zap.Error(err), zap.Int64("user_id", user.ID))
+		return errors.NewAppError(err, "Failed to request password reset", http.StatusInternalServerError)
+	}
+
+	p.logger.Info("Password reset requested", zap.Int64("user_id", user.ID))
+	return nil
+}
+
+// ResetPassword implements domain.PasswordResetUsecase. Using a token
+// invalidates the user's other reset tokens and revokes all their
+// sessions.
+func (p *passwordResetUsecase) ResetPassword(request domain.ResetPasswordRequest, actor domain.Actor) error {
+	p.logger.Debug("ResetPassword function called")
+
+	hashedPassword, err := hash.HashPassword(request.Password)
+	if err != nil {
+		p.logger.Error("Failed to hash password", zap.Error(err))
+		return errors.NewAppError(err, "Failed to process password", http.StatusInternalServerError)
+	}
+
+	var userID int64
+	err = p.transactor.WithinSystemTransaction(func(repos domain.Repositories) error {
+		id, ok, err := repos.PasswordResets.Consume(hash.HashToken(request.Token))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.ErrInvalidResetToken
+		}
+		userID = id
+
+		before, err := repos.Users.GetByID(userID)
+		if err != nil {
+			return err
+		}
+		if err := repos.Users.UpdatePassword(userID, hashedPassword); err != nil {
+			return err
+		}
+		if err := repos.PasswordResets.InvalidateAll(userID); err != nil {
+			return err
+		}
+		if _, err := repos.Sessions.RevokeAll(userID, domain.SessionRevokedPasswordReset); err != nil {
+			return err
+		}
+		after, err := repos.Users.GetByID(userID)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(repos, actor, domain.AuditActionUpdate, domain.AuditEntityUser, userID, converter.UserToUserResponse(before), converter.UserToUserResponse(after)); err != nil {
+			return err
+		}
+
+		notification, err := domain.NewNotificationJob(userID, domain.NotificationPasswordChanged, nil)
+		if err != nil {
+			return err
+		}
+		return repos.Jobs.Enqueue(notification)
+	})
+	switch err {
+	case nil:
+	case errors.ErrInvalidResetToken:
+		p.logger.Warn("Password reset with invalid token")
+		return errors.NewAppError(err, "Invalid or expired reset token", http.StatusBadRequest)
+	default:
+		p.logger.Error("Failed to reset password", zap.Error(err))
+		return errors.NewAppError(err, "Failed to reset password", http.StatusInternalServerError)
+	}
+
+	p.logger.Info("Password reset successfully", zap.Int64("user_id", userID))
+	return nil
+}
+
+// Prune implements domain.PasswordResetUsecase.
+func (p *passwordResetUsecase) Prune() (int64, error) {
+	count, err := p.passwordResetRepo.DeleteExpired(time.Now())
+	if err != nil {
+		p.logger.Error("Failed to prune reset tokens", zap.Error(err))
+		return 0, errors.NewAppError(err, "Failed to prune reset tokens", http.StatusInternalServerError)
+	}
+
+	p.logger.Info("Expired reset tokens pruned", zap.Int64("count", count))
+	return count, nil
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+)
+
+// authorize decides whether actor may act on a resource owned by ownerID.
+// Holders of anyPermission may act on every such resource, and the owner on
+// its own if it holds ownPermission, or without one when it is empty.
+// Otherwise it returns errors.ErrForbidden.
+func authorize(actor domain.Actor, ownerID int64, ownPermission, anyPermission string) error {
+	if actor.Can(anyPermission) {
+		return nil
+	}
+	if actor.UserID != 0 && actor.UserID == ownerID && (ownPermission == "" || actor.Can(ownPermission)) {
+		return nil
+	}
+	return errors.ErrForbidden
+}
+
+// authorizeOrganization decides whether actor may act on an organization:
+// as one of its admins, or as any member when role is empty. Holders of
+// organization:manage may act on every organization. Otherwise it returns
+// errors.ErrForbidden.
+func authorizeOrganization(organizationRepo domain.OrganizationRepository, actor domain.Actor, organizationID int64, role string) error {
+	if actor.Can(domain.PermissionOrganizationManage) {
+		return nil
+	}
+	if actor.UserID == 0 {
+		return errors.ErrForbidden
+	}
+	member, err := organizationRepo.GetMember(actor.UserID)
+	if err != nil {
+		return err
+	}
+	if member == nil || member.OrganizationID != organizationID || (role != "" && member.Role != role) {
+		return errors.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+)
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name          string
+		actor         domain.Actor
+		ownerID       int64
+		ownPermission string
+		anyPermission string
+		want          error
+	}{
+		{
+			name:          "owner without own permission required",
+			actor:         domain.Actor{UserID: 7},
+			ownerID:       7,
+			anyPermission: domain.PermissionUserRead,
+		},
+		{
+			name:          "owner holding own permission",
+			actor:         domain.Actor{UserID: 7, Permissions: []string{domain.PermissionProductWrite}},
+			ownerID:       7,
+			ownPermission: domain.PermissionProductWrite,
+			anyPermission: domain.PermissionProductManage,
+		},
+		{
+			name:          "owner missing own permission",
+			actor:         domain.Actor{UserID: 7},
+			ownerID:       7,
+			ownPermission: domain.PermissionProductWrite,
+			anyPermission: domain.PermissionProductManage,
+			want:          errors.ErrForbidden,
+		},
+		{
+			name:          "non-owner",
+			actor:         domain.Actor{UserID: 8, Permissions: []string{domain.PermissionProductWrite}},
+			ownerID:       7,
+			ownPermission: domain.PermissionProductWrite,
+			anyPermission: domain.PermissionProductManage,
+			want:          errors.ErrForbidden,
+		},
+		{
+			name:          "admin override",
+			actor:         domain.Actor{UserID: 1, Permissions: []string{domain.PermissionProductManage}},
+			ownerID:       7,
+			ownPermission: domain.PermissionProductWrite,
+			anyPermission: domain.PermissionProductManage,
+		},
+		{
+			name:          "admin override without being a user",
+			actor:         domain.Actor{Permissions: []string{domain.PermissionUserRead}},
+			ownerID:       7,
+			anyPermission: domain.PermissionUserRead,
+		},
+		{
+			name:          "anonymous is not the owner of ownerless resources",
+			actor:         domain.Actor{},
+			ownerID:       0,
+			anyPermission: domain.PermissionUserRead,
+			want:          errors.ErrForbidden,
+		},
+		{
+			name:          "other permissions do not override",
+			actor:         domain.Actor{UserID: 8, Permissions: []string{domain.PermissionUserRead}},
+			ownerID:       7,
+			anyPermission: domain.PermissionUserWrite,
+			want:          errors.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := authorize(tt.actor, tt.ownerID, tt.ownPermission, tt.anyPermission)
+			if got != tt.want {
+				t.Errorf("authorize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,27 +2,53 @@ package usecase
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
 	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
 
 	"go.uber.org/zap"
 )
 
 type productUsecase struct {
 	productRepo domain.ProductRepository
+	transactor  domain.Transactor
 	logger      *zap.Logger
 }
 
-func NewProductUsecase(productRepo domain.ProductRepository, logger *zap.Logger) domain.ProductUsecase {
-	return &productUsecase{productRepo: productRepo, logger: logger}
+func NewProductUsecase(productRepo domain.ProductRepository, transactor domain.Transactor, logger *zap.Logger) domain.ProductUsecase {
+	return &productUsecase{productRepo: productRepo, transactor: transactor, logger: logger}
 }
 
 // CreateProduct implements domain.ProductUsecase.
-func (p *productUsecase) CreateProduct(product *domain.Product) error {
+func (p *productUsecase) CreateProduct(product *domain.Product, actor domain.Actor) error {
 	p.logger.Debug("CreateProduct function called", zap.String("product", product.Name))
 
-	if err := p.productRepo.Create(product); err != nil {
+	if product.MinOrderQuantity == 0 {
+		product.MinOrderQuantity = 1
+	}
+	product.TenantID = actor.TenantID
+
+	err := p.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		if err := repos.Products.Create(product); err != nil {
+			return err
+		}
+		created, err := repos.Products.GetByID(product.ID)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(repos, actor, domain.AuditActionCreate, domain.AuditEntityProduct, product.ID, nil, created); err != nil {
+			return err
+		}
+
+		event, err := domain.NewWebhookEventJob(domain.EventProductCreated, product)
+		if err != nil {
+			return err
+		}
+		return repos.Jobs.Enqueue(event)
+	})
+	if err != nil {
 		p.logger.Error("Failed to create product", zap.Error(err))
 		return errors.NewAppError(err, "Failed to create product", http.StatusInternalServerError)
 	}
@@ -32,51 +58,145 @@ func (p *productUsecase) CreateProduct(product *domain.Product) error {
 }
 
 // GetAll implements domain.ProductUsecase.
-func (p *productUsecase) GetAll(name string, limit int, offset int) ([]domain.ProductWithVendor, error) {
-	p.logger.Debug("GetAll function called", zap.String("name", name), zap.Int("limit", limit), zap.Int("offset", offset))
+func (p *productUsecase) GetAll(filter domain.ProductFilter, page pagination.Params, actor domain.Actor) (*domain.ProductList, error) {
+	p.logger.Debug("GetAll function called", zap.String("query", filter.Query), zap.String("sort", filter.Sort), zap.Int("limit", page.Limit), zap.String("cursor", page.Cursor))
 
-	if limit <= 0 {
-		limit = 10
+	filter.Query = strings.TrimSpace(filter.Query)
+	page = page.Normalize()
+
+	switch filter.Sort {
+	case "":
+		filter.Sort = domain.ProductSortNewest
+		if filter.Query != "" {
+			filter.Sort = domain.ProductSortRelevance
+		}
+	case domain.ProductSortRelevance:
+		// relevance is meaningless without a search term
+		if filter.Query == "" {
+			filter.Sort = domain.ProductSortNewest
+		}
+	case domain.ProductSortNewest, domain.ProductSortPriceAsc, domain.ProductSortPriceDesc, domain.ProductSortName:
+	default:
+		p.logger.Warn("Invalid product sort", zap.String("sort", filter.Sort))
+		return nil, errors.NewAppError(errors.ErrInvalidInput, "Invalid sort, must be one of relevance, newest, price_asc, price_desc, name", http.StatusBadRequest)
+	}
+
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		p.logger.Warn("Invalid price range", zap.Int32("min_price", *filter.MinPrice), zap.Int32("max_price", *filter.MaxPrice))
+		return nil, errors.NewAppError(errors.ErrInvalidInput, "min_price must not be greater than max_price", http.StatusBadRequest)
 	}
 
-	if offset < 0 {
-		offset = 0
+	var cursor *domain.ProductCursor
+	if page.Cursor != "" {
+		cursor = &domain.ProductCursor{}
+		// a cursor is only valid for the sort order it was issued for
+		if err := pagination.DecodeCursor(page.Cursor, cursor); err != nil || cursor.Sort != filter.Sort {
+			p.logger.Warn("Invalid product cursor", zap.String("cursor", page.Cursor))
+			return nil, errors.NewAppError(pagination.ErrInvalidCursor, "Invalid cursor", http.StatusBadRequest)
+		}
 	}
 
-	products, err := p.productRepo.GetAll(name, limit, offset)
+	productRepo := p.productRepo.ForTenant(actor.TenantID)
+	products, err := productRepo.GetAll(filter, cursor, page.Limit+1)
 	if err != nil {
 		p.logger.Error("Failed to get products", zap.Error(err))
 		return nil, errors.NewAppError(err, "Failed to get products", http.StatusInternalServerError)
 	}
 
-	if len(products) == 0 {
-		p.logger.Info("No products found")
-		return []domain.ProductWithVendor{}, nil
+	total, err := productRepo.Count(filter)
+	if err != nil {
+		p.logger.Error("Failed to count products", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to count products", http.StatusInternalServerError)
+	}
+
+	facets, err := productRepo.GetFacets(filter)
+	if err != nil {
+		p.logger.Error("Failed to get product facets", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to get product facets", http.StatusInternalServerError)
+	}
+
+	result, err := pagination.NewPage(products, page.Limit, total, func(product domain.ProductWithVendor) interface{} {
+		return domain.ProductCursor{
+			Sort:  filter.Sort,
+			ID:    product.ID,
+			Price: product.Price,
+			Name:  product.ProductName,
+			Rank:  product.Rank,
+		}
+	})
+	if err != nil {
+		p.logger.Error("Failed to build product page", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to get products", http.StatusInternalServerError)
 	}
 
-	p.logger.Info("Products retrieved successfully", zap.Int("count", len(products)))
-	return products, nil
+	p.logger.Info("Products retrieved successfully", zap.Int("count", len(result.Items)), zap.Int64("total", total))
+	return &domain.ProductList{Page: *result, Facets: facets}, nil
 }
 
 // GetProductsByVendorID implements domain.ProductUsecase.
-func (p *productUsecase) GetProductsByVendorID(vendorID int64, limit int, offset int) ([]domain.Product, error) {
-	p.logger.Debug("GetProductsByVendorID function called", zap.Int64("vendorID", vendorID), zap.Int("limit", limit), zap.Int("offset", offset))
+func (p *productUsecase) GetProductsByVendorID(vendorID int64, page pagination.Params, actor domain.Actor) (*pagination.Page[domain.Product], error) {
+	p.logger.Debug("GetProductsByVendorID function called", zap.Int64("vendorID", vendorID), zap.Int("limit", page.Limit), zap.String("cursor", page.Cursor))
+
+	page = page.Normalize()
 
-	products, err := p.productRepo.GetProductsByVendorID(vendorID, limit, offset)
+	afterID, err := decodeIDCursor(page.Cursor)
+	if err != nil {
+		p.logger.Warn("Invalid product cursor", zap.String("cursor", page.Cursor))
+		return nil, err
+	}
+
+	productRepo := p.productRepo.ForTenant(actor.TenantID)
+	products, err := productRepo.GetProductsByVendorID(vendorID, afterID, page.Limit+1)
 	if err != nil {
 		p.logger.Error("Failed to get products by vendor ID", zap.Error(err))
 		return nil, errors.NewAppError(err, "Failed to get products by vendor ID", http.StatusInternalServerError)
 	}
 
-	p.logger.Info("Products retrieved successfully", zap.Int("count", len(products)))
-	return products, nil
+	total, err := productRepo.CountByVendorID(vendorID)
+	if err != nil {
+		p.logger.Error("Failed to count products by vendor ID", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to count products by vendor ID", http.StatusInternalServerError)
+	}
+
+	result, err := pagination.NewPage(products, page.Limit, total, func(product domain.Product) interface{} {
+		return pagination.IDCursor{ID: product.ID}
+	})
+	if err != nil {
+		p.logger.Error("Failed to build product page", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to get products by vendor ID", http.StatusInternalServerError)
+	}
+
+	p.logger.Info("Products retrieved successfully", zap.Int("count", len(result.Items)), zap.Int64("total", total))
+	return result, nil
 }
 
-// DeleteProduct implements domain.ProductUsecase.
-func (p *productUsecase) DeleteProduct(id int64) error {
+// DeleteProduct implements domain.ProductUsecase. Vendors can delete their
+// own products, and holders of product:manage any.
+func (p *productUsecase) DeleteProduct(id int64, actor domain.Actor) error {
 	p.logger.Debug("DeleteProduct function called", zap.Int64("id", id))
 
-	if err := p.productRepo.Delete(id); err != nil {
+	err := p.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		before, err := repos.Products.GetByID(id)
+		if err != nil {
+			return errors.ErrProductNotFound
+		}
+		if err := authorize(actor, before.VendorID, domain.PermissionProductWrite, domain.PermissionProductManage); err != nil {
+			return err
+		}
+		if err := repos.Products.Delete(id); err != nil {
+			return err
+		}
+		return recordAudit(repos, actor, domain.AuditActionDelete, domain.AuditEntityProduct, id, before, nil)
+	})
+	switch err {
+	case nil:
+	case errors.ErrProductNotFound:
+		p.logger.Warn("Deleting non-existent product", zap.Int64("id", id))
+		return errors.NewAppError(err, "Product not found", http.StatusNotFound)
+	case errors.ErrForbidden:
+		p.logger.Warn("Product does not belong to the vendor", zap.Int64("id", id), zap.Int64("actor_id", actor.UserID))
+		return errors.NewAppError(err, "Product does not belong to the vendor", http.StatusForbidden)
+	default:
 		p.logger.Error("Failed to delete product", zap.Error(err))
 		return errors.NewAppError(err, "Failed to delete product", http.StatusInternalServerError)
 	}
@@ -86,10 +206,10 @@ func (p *productUsecase) DeleteProduct(id int64) error {
 }
 
 // GetProductByID implements domain.ProductUsecase.
-func (p *productUsecase) GetProductByID(id int64) (*domain.Product, error) {
+func (p *productUsecase) GetProductByID(id int64, actor domain.Actor) (*domain.Product, error) {
 	p.logger.Debug("GetProductByID function called", zap.Int64("id", id))
 
-	product, err := p.productRepo.GetByID(id)
+	product, err := p.productRepo.ForTenant(actor.TenantID).GetByID(id)
 	if err != nil {
 		p.logger.Error("Failed to get product by ID", zap.Error(err))
 		return nil, errors.NewAppError(err, "Failed to get product by ID", http.StatusInternalServerError)
@@ -99,8 +219,9 @@ func (p *productUsecase) GetProductByID(id int64) (*domain.Product, error) {
 	return product, nil
 }
 
-// UpdateProduct implements domain.ProductUsecase.
-func (p *productUsecase) UpdateProduct(product *domain.Product) error {
+// UpdateProduct implements domain.ProductUsecase. Vendors can update their
+// own products, and holders of product:manage any; the vendor stays the same.
+func (p *productUsecase) UpdateProduct(product *domain.Product, actor domain.Actor) error {
 	p.logger.Debug("UpdateProduct function called", zap.String("product", product.Name))
 
 	// check product is exist
@@ -109,18 +230,41 @@ func (p *productUsecase) UpdateProduct(product *domain.Product) error {
 		return errors.NewAppError(nil, "Product ID is required", http.StatusBadRequest)
 	}
 
-	existingProduct, err := p.productRepo.GetByID(product.ID)
+	existingProduct, err := p.productRepo.ForTenant(actor.TenantID).GetByID(product.ID)
 	if err != nil {
-		p.logger.Error("Failed to get product by ID", zap.Error(err))
-		return errors.NewAppError(err, "Failed to get product by ID", http.StatusInternalServerError)
+		p.logger.Warn("Failed to get product by ID", zap.Error(err), zap.Int64("id", product.ID))
+		return errors.NewAppError(errors.ErrProductNotFound, "Product not found", http.StatusNotFound)
 	}
 
-	if product.VendorID != existingProduct.VendorID {
-		p.logger.Error("Product does not belong to the vendor")
-		return errors.NewAppError(nil, "Product does not belong to the vendor", http.StatusForbidden)
+	if product.MinOrderQuantity == 0 {
+		product.MinOrderQuantity = 1
 	}
 
-	if err := p.productRepo.Update(product); err != nil {
+	if err := authorize(actor, existingProduct.VendorID, domain.PermissionProductWrite, domain.PermissionProductManage); err != nil {
+		p.logger.Warn("Product does not belong to the vendor", zap.Int64("id", product.ID), zap.Int64("actor_id", actor.UserID))
+		return errors.NewAppError(err, "Product does not belong to the vendor", http.StatusForbidden)
+	}
+	product.VendorID = existingProduct.VendorID
+
+	err = p.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		if err := repos.Products.Update(product); err != nil {
+			return err
+		}
+		updated, err := repos.Products.GetByID(product.ID)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(repos, actor, domain.AuditActionUpdate, domain.AuditEntityProduct, product.ID, existingProduct, updated); err != nil {
+			return err
+		}
+
+		event, err := domain.NewWebhookEventJob(domain.EventProductUpdated, product)
+		if err != nil {
+			return err
+		}
+		return repos.Jobs.Enqueue(event)
+	})
+	if err != nil {
 		p.logger.Error("Failed to update product", zap.Error(err))
 		return errors.NewAppError(err, "Failed to update product", http.StatusInternalServerError)
 	}
@@ -0,0 +1,298 @@
+package usecase
+
+import (
+	"net/http"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+
+	"go.uber.org/zap"
+)
+
+type purchaseOrderUsecase struct {
+	purchaseOrderRepo domain.PurchaseOrderRepository
+	organizationRepo  domain.OrganizationRepository
+	transactor        domain.Transactor
+	logger            *zap.Logger
+}
+
+func NewPurchaseOrderUsecase(purchaseOrderRepo domain.PurchaseOrderRepository, organizationRepo domain.OrganizationRepository, transactor domain.Transactor, logger *zap.Logger) domain.PurchaseOrderUsecase {
+	return &purchaseOrderUsecase{purchaseOrderRepo: purchaseOrderRepo, organizationRepo: organizationRepo, transactor: transactor, logger: logger}
+}
+
+// GetByID implements domain.PurchaseOrderUsecase. The buyer and the vendor
+// can get an order, the admins of the organization it was placed for too,
+// and holders of po:read any.
+func (p *purchaseOrderUsecase) GetByID(id int64, actor domain.Actor) (*domain.PurchaseOrder, error) {
+	order, err := p.purchaseOrderRepo.ForTenant(actor.TenantID).GetByID(id)
+	if err != nil {
+		p.logger.Warn("Failed to get purchase order", zap.Error(err), zap.Int64("purchase_order_id", id))
+		return nil, errors.NewAppError(errors.ErrPurchaseOrderNotFound, "Purchase order not found", http.StatusNotFound)
+	}
+
+	if authorize(actor, order.BuyerID, "", domain.PermissionPORead) == nil || authorize(actor, order.VendorID, "", domain.PermissionPORead) == nil {
+		return order, nil
+	}
+	if order.OrganizationID != nil {
+		err := authorizeOrganization(p.organizationRepo.ForTenant(actor.TenantID), actor, *order.OrganizationID, domain.OrganizationRoleAdmin)
+		if err == nil {
+			return order, nil
+		}
+		if err != errors.ErrForbidden {
+			p.logger.Error("Failed to get organization member", zap.Error(err), zap.Int64("user_id", actor.UserID))
+			return nil, errors.NewAppError(err, "Failed to get purchase order", http.StatusInternalServerError)
+		}
+	}
+
+	p.logger.Warn("Purchase order does not belong to the user", zap.Int64("purchase_order_id", id), zap.Int64("user_id", actor.UserID))
+	return nil, errors.NewAppError(errors.ErrForbidden, "Purchase order does not belong to the user", http.StatusForbidden)
+}
+
+// GetByBuyerID implements domain.PurchaseOrderUsecase.
+func (p *purchaseOrderUsecase) GetByBuyerID(buyerID int64, page pagination.Params, actor domain.Actor) (*pagination.Page[domain.PurchaseOrder], error) {
+	page = page.Normalize()
+
+	afterID, err := decodeIDCursor(page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	purchaseOrderRepo := p.purchaseOrderRepo.ForTenant(actor.TenantID)
+	orders, err := purchaseOrderRepo.GetByBuyerID(buyerID, afterID, page.Limit+1)
+	if err != nil {
+		p.logger.Error("Failed to get purchase orders", zap.Error(err), zap.Int64("buyer_id", buyerID))
+		return nil, errors.NewAppError(err, "Failed to get purchase orders", http.StatusInternalServerError)
+	}
+
+	total, err := purchaseOrderRepo.CountByBuyerID(buyerID)
+	if err != nil {
+		p.logger.Error("Failed to count purchase orders", zap.Error(err), zap.Int64("buyer_id", buyerID))
+		return nil, errors.NewAppError(err, "Failed to count purchase orders", http.StatusInternalServerError)
+	}
+
+	return newPurchaseOrderPage(orders, page.Limit, total)
+}
+
+// GetByVendorID implements domain.PurchaseOrderUsecase.
+func (p *purchaseOrderUsecase) GetByVendorID(vendorID int64, page pagination.Params, actor domain.Actor) (*pagination.Page[domain.PurchaseOrder], error) {
+	page = page.Normalize()
+
+	afterID, err := decodeIDCursor(page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	purchaseOrderRepo := p.purchaseOrderRepo.ForTenant(actor.TenantID)
+	orders, err := purchaseOrderRepo.GetByVendorID(vendorID, afterID, page.Limit+1)
+	if err != nil {
+		p.logger.Error("Failed to get purchase orders", zap.Error(err), zap.Int64("vendor_id", vendorID))
+		return nil, errors.NewAppError(err, "Failed to get purchase orders", http.StatusInternalServerError)
+	}
+
+	total, err := purchaseOrderRepo.CountByVendorID(vendorID)
+	if err != nil {
+		p.logger.Error("Failed to count purchase orders", zap.Error(err), zap.Int64("vendor_id", vendorID))
+		return nil, errors.NewAppError(err, "Failed to count purchase orders", http.StatusInternalServerError)
+	}
+
+	return newPurchaseOrderPage(orders, page.Limit, total)
+}
+
+// GetByOrganizationID implements domain.PurchaseOrderUsecase.
+func (p *purchaseOrderUsecase) GetByOrganizationID(organizationID int64, departmentID *int64, page pagination.Params, actor domain.Actor) (*pagination.Page[domain.PurchaseOrder], error) {
+	if !actor.Can(domain.PermissionPORead) {
+		switch err := authorizeOrganization(p.organizationRepo.ForTenant(actor.TenantID), actor, organizationID, domain.OrganizationRoleAdmin); err {
+		case nil:
+		case errors.ErrForbidden:
+			p.logger.Warn("Listing purchase orders of another organization", zap.Int64("organization_id", organizationID), zap.Int64("user_id", actor.UserID))
+			return nil, errors.NewAppError(err, "You are not an admin of this organization", http.StatusForbidden)
+		default:
+			p.logger.Error("Failed to get organization member", zap.Error(err), zap.Int64("user_id", actor.UserID))
+			return nil, errors.NewAppError(err, "Failed to get purchase orders", http.StatusInternalServerError)
+		}
+	}
+
+	page = page.Normalize()
+
+	afterID, err := decodeIDCursor(page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	purchaseOrderRepo := p.purchaseOrderRepo.ForTenant(actor.TenantID)
+	orders, err := purchaseOrderRepo.GetByOrganizationID(organizationID, departmentID, afterID, page.Limit+1)
+	if err != nil {
+		p.logger.Error("Failed to get purchase orders", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, errors.NewAppError(err, "Failed to get purchase orders", http.StatusInternalServerError)
+	}
+
+	total, err := purchaseOrderRepo.CountByOrganizationID(organizationID, departmentID)
+	if err != nil {
+		p.logger.Error("Failed to count purchase orders", zap.Error(err), zap.Int64("organization_id", organizationID))
+		return nil, errors.NewAppError(err, "Failed to count purchase orders", http.StatusInternalServerError)
+	}
+
+	return newPurchaseOrderPage(orders, page.Limit, total)
+}
+
+// Confirm implements domain.PurchaseOrderUsecase.
+func (p *purchaseOrderUsecase) Confirm(id, buyerID int64, actor domain.Actor) (*domain.PurchaseOrder, error) {
+	p.logger.Debug("Confirm function called", zap.Int64("purchase_order_id", id), zap.Int64("buyer_id", buyerID))
+	return p.transition(id, buyerID, actor, domain.PurchaseOrderStatusIssued, func(order *domain.PurchaseOrder) bool {
+		return order.BuyerID == buyerID
+	})
+}
+
+// Cancel implements domain.PurchaseOrderUsecase. The reserved stock is
+// released.
+func (p *purchaseOrderUsecase) Cancel(id, buyerID int64, actor domain.Actor) (*domain.PurchaseOrder, error) {
+	p.logger.Debug("Cancel function called", zap.Int64("purchase_order_id", id), zap.Int64("buyer_id", buyerID))
+	return p.transition(id, buyerID, actor, domain.PurchaseOrderStatusCancelled, func(order *domain.PurchaseOrder) bool {
+		return order.BuyerID == buyerID
+	})
+}
+
+// Ship implements domain.PurchaseOrderUsecase. The reserved stock leaves the
+// vendor's on-hand inventory.
+func (p *purchaseOrderUsecase) Ship(id, vendorID int64, actor domain.Actor) (*domain.PurchaseOrder, error) {
+	p.logger.Debug("Ship function called", zap.Int64("purchase_order_id", id), zap.Int64("vendor_id", vendorID))
+	return p.transition(id, vendorID, actor, domain.PurchaseOrderStatusShipped, func(order *domain.PurchaseOrder) bool {
+		return order.VendorID == vendorID
+	})
+}
+
+// ExpirePending implements domain.PurchaseOrderUsecase. Orders are expired
+// in batches until none are left.
+func (p *purchaseOrderUsecase) ExpirePending() (int, error) {
+	const batchSize = 100
+
+	expired := 0
+	for {
+		var orders []domain.PurchaseOrder
+		err := p.transactor.WithinSystemTransaction(func(repos domain.Repositories) error {
+			var err error
+			if orders, err = repos.PurchaseOrders.ExpirePending(batchSize); err != nil {
+				return err
+			}
+			for i := range orders {
+				before := orders[i]
+				before.Status = domain.PurchaseOrderStatusPending
+				// The system acts in every tenant, but the event belongs
+				// to the order's.
+				actor := domain.SystemActor
+				actor.TenantID = orders[i].TenantID
+				if err := recordAudit(repos, actor, domain.AuditActionUpdate, domain.AuditEntityPurchaseOrder, orders[i].ID, before, orders[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			p.logger.Error("Failed to expire pending purchase orders", zap.Error(err))
+			return expired, errors.NewAppError(err, "Failed to expire pending purchase orders", http.StatusInternalServerError)
+		}
+		expired += len(orders)
+		for _, order := range orders {
+			p.logger.Info("Purchase order expired", zap.Int64("purchase_order_id", order.ID), zap.Int64("buyer_id", order.BuyerID))
+		}
+		if len(orders) < batchSize {
+			return expired, nil
+		}
+	}
+}
+
+// transition checks that userID may act on the order and moves it to status
+// to.
+func (p *purchaseOrderUsecase) transition(id, userID int64, actor domain.Actor, to string, allowed func(order *domain.PurchaseOrder) bool) (*domain.PurchaseOrder, error) {
+	order, err := p.purchaseOrderRepo.ForTenant(actor.TenantID).GetByID(id)
+	if err != nil {
+		p.logger.Warn("Failed to get purchase order", zap.Error(err), zap.Int64("purchase_order_id", id))
+		return nil, errors.NewAppError(errors.ErrPurchaseOrderNotFound, "Purchase order not found", http.StatusNotFound)
+	}
+
+	if !allowed(order) {
+		p.logger.Warn("Purchase order does not belong to the user", zap.Int64("purchase_order_id", id), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(nil, "Purchase order does not belong to the user", http.StatusForbidden)
+	}
+
+	var updated *domain.PurchaseOrder
+	err = p.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		var err error
+		if updated, err = repos.PurchaseOrders.Transition(id, to, &userID); err != nil {
+			return err
+		}
+		if err := recordAudit(repos, actor, domain.AuditActionUpdate, domain.AuditEntityPurchaseOrder, id, order, updated); err != nil {
+			return err
+		}
+
+		jobs, err := transitionJobs(updated)
+		if err != nil {
+			return err
+		}
+		return repos.Jobs.Enqueue(jobs...)
+	})
+	if err != nil {
+		switch err {
+		case errors.ErrInvalidTransition:
+			p.logger.Warn("Invalid purchase order transition", zap.Int64("purchase_order_id", id), zap.String("from", order.Status), zap.String("to", to))
+			return nil, errors.NewAppError(err, "Purchase order cannot move from "+order.Status+" to "+to, http.StatusConflict)
+		case errors.ErrPurchaseOrderExpired:
+			p.logger.Warn("Purchase order expired", zap.Int64("purchase_order_id", id))
+			return nil, errors.NewAppError(err, "Purchase order has expired", http.StatusConflict)
+		}
+		p.logger.Error("Failed to update purchase order status", zap.Error(err), zap.Int64("purchase_order_id", id), zap.String("to", to))
+		return nil, errors.NewAppError(err, "Failed to update purchase order", http.StatusInternalServerError)
+	}
+
+	p.logger.Info("Purchase order status updated successfully", zap.Int64("purchase_order_id", id), zap.String("status", to))
+
+	return updated, nil
+}
+
+// transitionJobs returns the outbox jobs for an order's new status: a
+// notification to the other party of the order and, for issued and shipped
+// orders, a webhook event.
+func transitionJobs(order *domain.PurchaseOrder) ([]*domain.Job, error) {
+	var recipient int64
+	var notificationEvent, webhookEvent string
+	switch order.Status {
+	case domain.PurchaseOrderStatusIssued:
+		recipient, notificationEvent, webhookEvent = order.VendorID, domain.NotificationPurchaseOrderIssued, domain.EventPurchaseOrderIssued
+	case domain.PurchaseOrderStatusCancelled:
+		recipient, notificationEvent = order.VendorID, domain.NotificationPurchaseOrderCancelled
+	case domain.PurchaseOrderStatusShipped:
+		recipient, notificationEvent, webhookEvent = order.BuyerID, domain.NotificationPurchaseOrderShipped, domain.EventPurchaseOrderShipped
+	default:
+		return nil, nil
+	}
+
+	data := map[string]interface{}{
+		"PurchaseOrderID": order.ID,
+		"TotalAmount":     order.TotalAmount,
+	}
+	notification, err := domain.NewNotificationJob(recipient, notificationEvent, data)
+	if err != nil {
+		return nil, err
+	}
+	jobs := []*domain.Job{notification}
+
+	if webhookEvent != "" {
+		event, err := domain.NewWebhookEventJob(webhookEvent, order)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, event)
+	}
+	return jobs, nil
+}
+
+func newPurchaseOrderPage(orders []domain.PurchaseOrder, limit int, total int64) (*pagination.Page[domain.PurchaseOrder], error) {
+	result, err := pagination.NewPage(orders, limit, total, func(order domain.PurchaseOrder) interface{} {
+		return pagination.IDCursor{ID: order.ID}
+	})
+	if err != nil {
+		return nil, errors.NewAppError(err, "Failed to get purchase orders", http.StatusInternalServerError)
+	}
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+
+	"go.uber.org/zap"
+)
+
+type rfqUsecase struct {
+	transactor domain.Transactor
+	logger     *zap.Logger
+}
+
+func NewRFQUsecase(transactor domain.Transactor, logger *zap.Logger) domain.RFQUsecase {
+	return &rfqUsecase{transactor: transactor, logger: logger}
+}
+
+// Create implements domain.RFQUsecase.
+func (r *rfqUsecase) Create(request domain.RFQRequest, actor domain.Actor) (*domain.RFQ, error) {
+	r.logger.Debug("Create RFQ function called", zap.String("title", request.Title))
+
+	if !request.Deadline.After(time.Now()) {
+		r.logger.Warn("RFQ deadline is not in the future", zap.Time("deadline", request.Deadline))
+		return nil, errors.NewAppError(errors.ErrInvalidInput, "The deadline must be in the future", http.StatusBadRequest)
+	}
+
+	rfq := &domain.RFQ{BuyerID: actor.UserID, Title: request.Title, Deadline: request.Deadline, TenantID: actor.TenantID}
+	err := r.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		if err := repos.RFQs.Create(rfq); err != nil {
+			return err
+		}
+		return recordAudit(repos, actor, domain.AuditActionCreate, domain.AuditEntityRFQ, rfq.ID, nil, rfq)
+	})
+	if err != nil {
+		r.logger.Error("Failed to create RFQ", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to create RFQ", http.StatusInternalServerError)
+	}
+
+	r.logger.Info("RFQ created successfully", zap.Int64("rfq_id", rfq.ID), zap.Int64("buyer_id", rfq.BuyerID))
+	return rfq, nil
+}
+
+// CloseDue implements domain.RFQUsecase. RFQs are closed in batches, each
+// with its audit events and buyer notifications in one transaction.
+func (r *rfqUsecase) CloseDue() (int, error) {
+	const batchSize = 100
+
+	closed := 0
+	for {
+		var rfqs []domain.RFQ
+		err := r.transactor.WithinSystemTransaction(func(repos domain.Repositories) error {
+			var err error
+			if rfqs, err = repos.RFQs.CloseDue(batchSize); err != nil {
+				return err
+			}
+			jobs := make([]*domain.Job, 0, len(rfqs))
+			for i := range rfqs {
+				before := rfqs[i]
+				before.Status = domain.RFQStatusOpen
+				before.ClosedAt = nil
+				actor := domain.SystemActor
+				actor.TenantID = rfqs[i].TenantID
+				if err := recordAudit(repos, actor, domain.AuditActionUpdate, domain.AuditEntityRFQ, rfqs[i].ID, before, rfqs[i]); err != nil {
+					return err
+				}
+				notification, err := domain.NewNotificationJob(rfqs[i].BuyerID, domain.NotificationRFQClosed, map[string]interface{}{
+					"RFQID":    rfqs[i].ID,
+					"Title":    rfqs[i].Title,
+					"Deadline": rfqs[i].Deadline.UTC().Format(time.RFC1123),
+				})
+				if err != nil {
+					return err
+				}
+				jobs = append(jobs, notification)
+			}
+			if len(jobs) == 0 {
+				return nil
+			}
+			return repos.Jobs.Enqueue(jobs...)
+		})
+		if err != nil {
+			r.logger.Error("Failed to close due RFQs", zap.Error(err))
+			return closed, errors.NewAppError(err, "Failed to close due RFQs", http.StatusInternalServerError)
+		}
+		closed += len(rfqs)
+		for _, rfq := range rfqs {
+			r.logger.Info("RFQ closed", zap.Int64("rfq_id", rfq.ID), zap.Int64("buyer_id", rfq.BuyerID))
+		}
+		if len(rfqs) < batchSize {
+			return closed, nil
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"go.uber.org/zap"
+)
+
+func TestRFQUsecaseCreate(t *testing.T) {
+	tests := []struct {
+		name     string
+		deadline time.Time
+		wantCode int
+	}{
+		{name: "future deadline", deadline: time.Now().Add(24 * time.Hour)},
+		{name: "past deadline", deadline: time.Now().Add(-time.Hour), wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactor := newFakeTransactor()
+			u := NewRFQUsecase(transactor, zap.NewNop())
+
+			rfq, err := u.Create(domain.RFQRequest{Title: "Paper", Deadline: tt.deadline}, domain.Actor{UserID: 7, TenantID: 1})
+			if tt.wantCode != 0 {
+				if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != tt.wantCode {
+					t.Fatalf("Create() error = %v, want code %d", err, tt.wantCode)
+				}
+				if len(transactor.rfqs.rfqs) != 0 || len(transactor.audit.events) != 0 {
+					t.Errorf("stored %d RFQs and %d audit events, want none", len(transactor.rfqs.rfqs), len(transactor.audit.events))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			stored := transactor.rfqs.rfqs[rfq.ID]
+			if stored == nil || stored.BuyerID != 7 || stored.TenantID != 1 || stored.Status != domain.RFQStatusOpen {
+				t.Errorf("stored RFQ = %+v, want an open RFQ of buyer 7 in tenant 1", stored)
+			}
+			if len(transactor.audit.events) != 1 || transactor.audit.events[0].Action != domain.AuditActionCreate {
+				t.Errorf("audit events = %d, want one create", len(transactor.audit.events))
+			}
+		})
+	}
+}
+
+func TestRFQUsecaseCloseDue(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	transactor := newFakeTransactor()
+	transactor.rfqs.rfqs[1] = &domain.RFQ{ID: 1, TenantID: 1, BuyerID: 7, Title: "Paper", Status: domain.RFQStatusOpen, Deadline: past}
+	transactor.rfqs.rfqs[2] = &domain.RFQ{ID: 2, TenantID: 2, BuyerID: 20, Title: "Toner", Status: domain.RFQStatusOpen, Deadline: past}
+	transactor.rfqs.rfqs[3] = &domain.RFQ{ID: 3, TenantID: 1, BuyerID: 7, Title: "Pens", Status: domain.RFQStatusOpen, Deadline: time.Now().Add(time.Hour)}
+	transactor.rfqs.rfqs[4] = &domain.RFQ{ID: 4, TenantID: 1, BuyerID: 7, Title: "Ink", Status: domain.RFQStatusClosed, Deadline: past}
+	u := NewRFQUsecase(transactor, zap.NewNop())
+
+	closed, err := u.CloseDue()
+	if err != nil {
+		t.Fatalf("CloseDue() error = %v", err)
+	}
+	if closed != 2 {
+		t.Errorf("closed = %d, want 2", closed)
+	}
+	for id, want := range map[int64]string{1: domain.RFQStatusClosed, 2: domain.RFQStatusClosed, 3: domain.RFQStatusOpen, 4: domain.RFQStatusClosed} {
+		if got := transactor.rfqs.rfqs[id].Status; got != want {
+			t.Errorf("RFQ %d status = %s, want %s", id, got, want)
+		}
+	}
+
+	// Each RFQ is audited in its own tenant, and its buyer notified.
+	if len(transactor.audit.events) != 2 {
+		t.Fatalf("audit events = %d, want 2", len(transactor.audit.events))
+	}
+	for i, tenantID := range []int64{1, 2} {
+		if event := transactor.audit.events[i]; event.TenantID == nil || *event.TenantID != tenantID {
+			t.Errorf("audit event %d tenant = %v, want %d", i, event.TenantID, tenantID)
+		}
+	}
+	notifications := notificationJobs(t, transactor.jobs.jobs)
+	if got := recipients(notifications); !slices.Equal(got, []int64{7, 20}) {
+		t.Errorf("notified %v, want [7 20]", got)
+	}
+	for _, notification := range notifications {
+		if notification.Event != domain.NotificationRFQClosed {
+			t.Errorf("notification event = %s, want %s", notification.Event, domain.NotificationRFQClosed)
+		}
+	}
+
+	if closed, err := u.CloseDue(); err != nil || closed != 0 {
+		t.Errorf("second CloseDue() = %d, %v, want 0, nil", closed, err)
+	}
+}
@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+
+	"go.uber.org/zap"
+)
+
+type roleUsecase struct {
+	roleRepo   domain.RoleRepository
+	userRepo   domain.UserRepository
+	transactor domain.Transactor
+	logger     *zap.Logger
+}
+
+func NewRoleUsecase(roleRepo domain.RoleRepository, userRepo domain.UserRepository, transactor domain.Transactor, logger *zap.Logger) domain.RoleUsecase {
+	return &roleUsecase{
+		roleRepo:   roleRepo,
+		userRepo:   userRepo,
+		transactor: transactor,
+		logger:     logger,
+	}
+}
+
+// GetAll implements domain.RoleUsecase.
+func (r *roleUsecase) GetAll() ([]domain.Role, error) {
+	roles, err := r.roleRepo.GetAll()
+	if err != nil {
+		r.logger.Error("Failed to get roles", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to get roles", http.StatusInternalServerError)
+	}
+	return roles, nil
+}
+
+// GetByID implements domain.RoleUsecase.
+func (r *roleUsecase) GetByID(id int64) (*domain.Role, error) {
+	role, err := r.roleRepo.GetByID(id)
+	if err != nil {
+		r.logger.Error("Failed to get role", zap.Error(err), zap.Int64("role_id", id))
+		return nil, errors.NewAppError(err, "Failed to get role", http.StatusInternalServerError)
+	}
+	if role == nil {
+		return nil, errors.NewAppError(errors.ErrRoleNotFound, "Role not found", http.StatusNotFound)
+	}
+	return role, nil
+}
+
+// Create implements domain.RoleUsecase.
+func (r *roleUsecase) Create(req domain.RoleRequest, actor domain.Actor) (*domain.Role, error) {
+	r.logger.Debug("Create role function called", zap.String("name", req.Name))
+
+	permissions, err := normalizePermissions(req.Permissions)
+	if err != nil {
+		return nil, roleError(err, "Failed to create role")
+	}
+
+	role := &domain.Role{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: permissions,
+	}
+	err = r.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		existing, err := repos.Roles.GetByName(role.Name)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return errors.ErrRoleExists
+		}
+		if err := repos.Roles.Create(role); err != nil {
+			return err
+		}
+		if err := repos.Roles.SetPermissions(role.ID, role.Permissions); err != nil {
+			return err
+		}
+		return recordAudit(repos, actor, domain.AuditActionCreate, domain.AuditEntityRole, role.ID, nil, role)
+	})
+	if err != nil {
+		r.logger.Warn("Failed to create role", zap.Error(err), zap.String("name", req.Name))
+		return nil, roleError(err, "Failed to create role")
+	}
+
+	r.logger.Info("Role created successfully", zap.Int64("role_id", role.ID), zap.Strings("permissions", role.Permissions))
+	return role, nil
+}
+
+// Update implements domain.RoleUsecase. Built-in roles keep their name, and
+// the operator role cannot be changed.
+func (r *roleUsecase) Update(id int64, req domain.RoleRequest, actor domain.Actor) (*domain.Role, error) {
+	r.logger.Debug("Update role function called", zap.Int64("role_id", id))
+
+	permissions, err := normalizePermissions(req.Permissions)
+	if err != nil {
+		return nil, roleError(err, "Failed to update role")
+	}
+
+	var after *domain.Role
+	err = r.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		before, err := repos.Roles.GetByID(id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errors.ErrRoleNotFound
+		}
+		if before.Name == domain.RoleOperator || (before.BuiltIn && req.Name != before.Name) {
+			return errors.ErrRoleBuiltIn
+		}
+		if req.Name != before.Name {
+			existing, err := repos.Roles.GetByName(req.Name)
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				return errors.ErrRoleExists
+			}
+		}
+
+		role := *before
+		role.Name = req.Name
+		role.Description = req.Description
+		if err := repos.Roles.Update(&role); err != nil {
+			return err
+		}
+		if err := repos.Roles.SetPermissions(id, permissions); err != nil {
+			return err
+		}
+		if after, err = repos.Roles.GetByID(id); err != nil {
+			return err
+		}
+		return recordAudit(repos, actor, domain.AuditActionUpdate, domain.AuditEntityRole, id, before, after)
+	})
+	if err != nil {
+		r.logger.Warn("Failed to update role", zap.Error(err), zap.Int64("role_id", id))
+		return nil, roleError(err, "Failed to update role")
+	}
+
+	r.logger.Info("Role updated successfully", zap.Int64("role_id", id), zap.Strings("permissions", after.Permissions))
+	return after, nil
+}
+
+// Delete implements domain.RoleUsecase. Users lose the role's permissions
+// at once; built-in roles cannot be deleted.
+func (r *roleUsecase) Delete(id int64, actor domain.Actor) error {
+	err := r.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		before, err := repos.Roles.GetByID(id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return errors.ErrRoleNotFound
+		}
+		if before.BuiltIn {
+			return errors.ErrRoleBuiltIn
+		}
+		if err := repos.Roles.Delete(id); err != nil {
+			return err
+		}
+		return recordAudit(repos, actor, domain.AuditActionDelete, domain.AuditEntityRole, id, before, nil)
+	})
+	if err != nil {
+		r.logger.Warn("Failed to delete role", zap.Error(err), zap.Int64("role_id", id))
+		return roleError(err, "Failed to delete role")
+	}
+
+	r.logger.Info("Role deleted successfully", zap.Int64("role_id", id))
+	return nil
+}
+
+// GetUserRoles implements domain.RoleUsecase. Operators manage the roles
+// of every tenant's users.
+func (r *roleUsecase) GetUserRoles(userID int64, actor domain.Actor) ([]domain.Role, error) {
+	if _, err := r.userRepo.SystemScope().GetByID(userID); err != nil {
+		r.logger.Warn("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(errors.ErrUserNotFound, "User not found", http.StatusNotFound)
+	}
+
+	roles, err := r.roleRepo.GetByUserID(userID)
+	if err != nil {
+		r.logger.Error("Failed to get user roles", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to get user roles", http.StatusInternalServerError)
+	}
+	return roles, nil
+}
+
+// SetUserRoles implements domain.RoleUsecase. The change takes effect on
+// the user's next request. The operator role is only given to users of the
+// default tenant and cannot be taken from its last holder.
+func (r *roleUsecase) SetUserRoles(userID int64, names []string, actor domain.Actor) ([]domain.Role, error) {
+	r.logger.Debug("SetUserRoles function called", zap.Int64("user_id", userID), zap.Strings("roles", names))
+
+	var after []domain.Role
+	err := r.transactor.WithinSystemTransaction(func(repos domain.Repositories) error {
+		user, err := repos.Users.GetByID(userID)
+		if err != nil {
+			return errors.ErrUserNotFound
+		}
+		before, err := repos.Roles.GetByUserID(userID)
+		if err != nil {
+			return err
+		}
+
+		roleIDs := make([]int64, 0, len(names))
+		keepsOperator := false
+		for _, name := range names {
+			role, err := repos.Roles.GetByName(name)
+			if err != nil {
+				return err
+			}
+			if role == nil {
+				return errors.ErrRoleNotFound
+			}
+			if !slices.Contains(roleIDs, role.ID) {
+				roleIDs = append(roleIDs, role.ID)
+			}
+			if role.Name == domain.RoleOperator {
+				keepsOperator = true
+			}
+		}
+
+		// An operator in another tenant would reach into every tenant
+		// through what they share.
+		if keepsOperator && user.TenantID != domain.DefaultTenantID {
+			return errors.ErrOperatorTenant
+		}
+
+		// Taking the operator role away from its last holder would leave no
+		// one who can manage roles.
+		if i := slices.IndexFunc(before, func(role domain.Role) bool { return role.Name == domain.RoleOperator }); i >= 0 && !keepsOperator {
+			operators, err := repos.Roles.CountUsers(before[i].ID)
+			if err != nil {
+				return err
+			}
+			if operators <= 1 {
+				return errors.ErrLastOperator
+			}
+		}
+
+		if err := repos.Roles.SetUserRoles(userID, roleIDs); err != nil {
+			return err
+		}
+		if after, err = repos.Roles.GetByUserID(userID); err != nil {
+			return err
+		}
+		return recordAudit(repos, actor, domain.AuditActionUpdate, domain.AuditEntityUser, userID, userRolesAuditState(before), userRolesAuditState(after))
+	})
+	switch err {
+	case nil:
+	case errors.ErrUserNotFound:
+		r.logger.Warn("Setting roles of non-existent user", zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "User not found", http.StatusNotFound)
+	case errors.ErrRoleNotFound:
+		r.logger.Warn("Setting unknown role", zap.Int64("user_id", userID), zap.Strings("roles", names))
+		return nil, errors.NewAppError(err, "Unknown role", http.StatusBadRequest)
+	default:
+		r.logger.Warn("Failed to set user roles", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, roleError(err, "Failed to set user roles")
+	}
+
+	r.logger.Info("User roles set successfully", zap.Int64("user_id", userID), zap.Strings("roles", names))
+	return after, nil
+}
+
+// normalizePermissions checks that every permission exists and drops
+// duplicates.
+func normalizePermissions(permissions []string) ([]string, error) {
+	normalized := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		if !domain.IsPermission(permission) {
+			return nil, errors.ErrUnknownPermission
+		}
+		if !slices.Contains(normalized, permission) {
+			normalized = append(normalized, permission)
+		}
+	}
+	slices.Sort(normalized)
+	return normalized, nil
+}
+
+// userRolesAuditState is what the audit trail records of a user's roles.
+func userRolesAuditState(roles []domain.Role) map[string]interface{} {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = role.Name
+	}
+	return map[string]interface{}{"roles": names}
+}
+
+func roleError(err error, message string) error {
+	switch err {
+	case errors.ErrRoleNotFound:
+		return errors.NewAppError(err, "Role not found", http.StatusNotFound)
+	case errors.ErrRoleExists:
+		return errors.NewAppError(err, "A role with this name already exists", http.StatusConflict)
+	case errors.ErrRoleBuiltIn:
+		return errors.NewAppError(err, "Built-in roles cannot be renamed or deleted, and the operator role cannot be changed", http.StatusConflict)
+	case errors.ErrUnknownPermission:
+		return errors.NewAppError(err, "Unknown permission", http.StatusBadRequest)
+	case errors.ErrLastOperator:
+		return errors.NewAppError(err, "The last operator cannot lose the operator role", http.StatusConflict)
+	case errors.ErrOperatorTenant:
+		return errors.NewAppError(err, "Only users of the default tenant can be operators", http.StatusConflict)
+	default:
+		return errors.NewAppError(err, message, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,267 @@
+package usecase
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/auth"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/hash"
+
+	"go.uber.org/zap"
+)
+
+type sessionUsecase struct {
+	userRepo    domain.UserRepository
+	sessionRepo domain.SessionRepository
+	transactor  domain.Transactor
+	jwtAuth     *auth.JWTAuth
+	refreshTTL  time.Duration
+	logger      *zap.Logger
+}
+
+// NewSessionUsecase creates the session usecase. A session expires when
+// its refresh token has not been used for refreshTTL.
+func NewSessionUsecase(userRepo domain.UserRepository, sessionRepo domain.SessionRepository, transactor domain.Transactor, jwtAuth *auth.JWTAuth, refreshTTL time.Duration, logger *zap.Logger) domain.SessionUsecase {
+	return &sessionUsecase{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		transactor:  transactor,
+		jwtAuth:     jwtAuth,
+		refreshTTL:  refreshTTL,
+		logger:      logger,
+	}
+}
+
+// Start implements domain.SessionUsecase.
+func (s *sessionUsecase) Start(user *domain.User, actor domain.Actor) (*domain.LoginResult, error) {
+	session := &domain.Session{
+		UserID:    user.ID,
+		UserAgent: actor.UserAgent,
+		IP:        actor.IP,
+		ExpiresAt: time.Now().Add(s.refreshTTL),
+	}
+
+	var refreshToken string
+	err := s.transactor.WithinTransaction(user.TenantID, func(repos domain.Repositories) error {
+		if err := repos.Sessions.Create(session); err != nil {
+			return err
+		}
+		var err error
+		refreshToken, err = addRefreshToken(repos.Sessions, session.ID)
+		return err
+	})
+	if err != nil {
+		s.logger.Error("Failed to create session", zap.Error(err), zap.Int64("user_id", user.ID))
+		return nil, errors.NewAppError(err, "Failed to create session", http.StatusInternalServerError)
+	}
+
+	accessToken, err := s.jwtAuth.GenerateAccessToken(user, session.ID)
+	if err != nil {
+		s.logger.Error("Failed to generate access token", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to generate access token", http.StatusInternalServerError)
+	}
+
+	s.logger.Info("Session started", zap.Int64("user_id", user.ID), zap.Int64("session_id", session.ID))
+	return &domain.LoginResult{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User:         user,
+	}, nil
+}
+
+// Refresh implements domain.SessionUsecase. The token row and its session
+// are locked while the token is swapped, so two refreshes with the same
+// token cannot both succeed; the second one counts as reuse.
+func (s *sessionUsecase) Refresh(refreshToken string, actor domain.Actor) (*domain.LoginResult, error) {
+	s.logger.Debug("Refresh function called")
+
+	var (
+		token    *domain.RefreshToken
+		newToken string
+		reused   bool
+	)
+	err := s.transactor.WithinSystemTransaction(func(repos domain.Repositories) error {
+		var err error
+		token, err = repos.Sessions.GetRefreshToken(hash.HashToken(refreshToken))
+		if err != nil {
+			return err
+		}
+		if token == nil || token.SessionRevokedAt != nil || !token.SessionExpiresAt.After(time.Now()) {
+			return errors.ErrInvalidRefreshToken
+		}
+
+		// A used token coming back means it was copied: either the
+		// client or an attacker holds a newer one. Revoke the whole
+		// session and commit that, so neither can continue.
+		if token.UsedAt != nil {
+			reused = true
+			_, err := repos.Sessions.Revoke(token.SessionID, domain.SessionRevokedTokenReuse)
+			return err
+		}
+
+		ok, err := repos.Sessions.UseRefreshToken(token.ID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.ErrInvalidRefreshToken
+		}
+		if err := repos.Sessions.Touch(token.SessionID, actor.IP, actor.UserAgent, time.Now().Add(s.refreshTTL)); err != nil {
+			return err
+		}
+		newToken, err = addRefreshToken(repos.Sessions, token.SessionID)
+		return err
+	})
+	if err == errors.ErrInvalidRefreshToken {
+		s.logger.Warn("Invalid refresh token")
+		return nil, errors.NewAppError(err, "Invalid refresh token", http.StatusUnauthorized)
+	}
+	if err != nil {
+		s.logger.Error("Failed to refresh session", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to refresh token", http.StatusInternalServerError)
+	}
+	if reused {
+		s.logger.Warn("Refresh token reused, session revoked", zap.Int64("user_id", token.UserID), zap.Int64("session_id", token.SessionID), zap.String("ip", actor.IP))
+		return nil, errors.NewAppError(errors.ErrInvalidRefreshToken, "Invalid refresh token", http.StatusUnauthorized)
+	}
+
+	user, err := s.userRepo.SystemScope().GetByID(token.UserID)
+	if err != nil {
+		s.logger.Warn("Failed to get user", zap.Error(err), zap.Int64("user_id", token.UserID))
+		return nil, errors.NewAppError(errors.ErrUserNotFound, "User not found", http.StatusNotFound)
+	}
+	if user.Status != "active" {
+		s.logger.Warn("Refresh by non-active user", zap.Int64("user_id", user.ID))
+		return nil, errors.NewAppError(errors.ErrUserNotActive, "User is not active", http.StatusUnauthorized)
+	}
+
+	accessToken, err := s.jwtAuth.GenerateAccessToken(user, token.SessionID)
+	if err != nil {
+		s.logger.Error("Failed to generate new access token", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to generate new access token", http.StatusInternalServerError)
+	}
+
+	s.logger.Info("Access token refreshed successfully", zap.Int64("user_id", user.ID), zap.Int64("session_id", token.SessionID))
+	return &domain.LoginResult{
+		AccessToken:  accessToken,
+		RefreshToken: newToken,
+		User:         user,
+	}, nil
+}
+
+// Logout implements domain.SessionUsecase. An unknown or already revoked
+// token is not an error, so logging out twice succeeds.
+func (s *sessionUsecase) Logout(refreshToken string) error {
+	s.logger.Debug("Logout function called")
+
+	err := s.transactor.WithinSystemTransaction(func(repos domain.Repositories) error {
+		token, err := repos.Sessions.GetRefreshToken(hash.HashToken(refreshToken))
+		if err != nil || token == nil {
+			return err
+		}
+		_, err = repos.Sessions.Revoke(token.SessionID, domain.SessionRevokedLogout)
+		return err
+	})
+	if err != nil {
+		s.logger.Error("Failed to revoke session", zap.Error(err))
+		return errors.NewAppError(err, "Failed to logout", http.StatusInternalServerError)
+	}
+
+	s.logger.Info("User logged out")
+	return nil
+}
+
+// GetActive implements domain.SessionUsecase.
+func (s *sessionUsecase) GetActive(userID, currentSessionID int64) ([]*domain.Session, error) {
+	sessions, err := s.sessionRepo.GetActive(userID)
+	if err != nil {
+		s.logger.Error("Failed to get sessions", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to get sessions", http.StatusInternalServerError)
+	}
+	for _, session := range sessions {
+		session.Current = session.ID == currentSessionID
+	}
+	return sessions, nil
+}
+
+// Revoke implements domain.SessionUsecase.
+func (s *sessionUsecase) Revoke(userID, sessionID int64, reason string) error {
+	s.logger.Debug("Revoke function called", zap.Int64("user_id", userID), zap.Int64("session_id", sessionID))
+
+	ok, err := s.sessionRepo.RevokeForUser(sessionID, userID, reason)
+	if err != nil {
+		s.logger.Error("Failed to revoke session", zap.Error(err), zap.Int64("session_id", sessionID))
+		return errors.NewAppError(err, "Failed to revoke session", http.StatusInternalServerError)
+	}
+	if !ok {
+		s.logger.Warn("Session not found", zap.Int64("user_id", userID), zap.Int64("session_id", sessionID))
+		return errors.NewAppError(errors.ErrSessionNotFound, "Session not found", http.StatusNotFound)
+	}
+
+	s.logger.Info("Session revoked", zap.Int64("user_id", userID), zap.Int64("session_id", sessionID), zap.String("reason", reason))
+	return nil
+}
+
+// RevokeAll implements domain.SessionUsecase.
+func (s *sessionUsecase) RevokeAll(userID int64, reason string) (int64, error) {
+	s.logger.Debug("RevokeAll function called", zap.Int64("user_id", userID))
+
+	revoked, err := s.sessionRepo.RevokeAll(userID, reason)
+	if err != nil {
+		s.logger.Error("Failed to revoke sessions", zap.Error(err), zap.Int64("user_id", userID))
+		return 0, errors.NewAppError(err, "Failed to revoke sessions", http.StatusInternalServerError)
+	}
+
+	s.logger.Info("All sessions revoked", zap.Int64("user_id", userID), zap.Int64("revoked", revoked), zap.String("reason", reason))
+	return revoked, nil
+}
+
+// CheckUser implements domain.SessionUsecase.
+func (s *sessionUsecase) CheckUser(userID int64, actor domain.Actor) error {
+	if _, err := s.userRepo.ForTenant(actor.TenantID).GetByID(userID); err != nil {
+		s.logger.Warn("Failed to get user", zap.Error(err), zap.Int64("user_id", userID))
+		return errors.NewAppError(errors.ErrUserNotFound, "User not found", http.StatusNotFound)
+	}
+	return nil
+}
+
+// Prune implements domain.SessionUsecase.
+func (s *sessionUsecase) Prune() (int64, error) {
+	deleted, err := s.sessionRepo.DeleteExpired(time.Now())
+	if err != nil {
+		s.logger.Error("Failed to prune sessions", zap.Error(err))
+		return 0, err
+	}
+	s.logger.Info("Sessions pruned", zap.Int64("deleted", deleted))
+	return deleted, nil
+}
+
+// CheckAccess implements domain.SessionUsecase. It runs on every
+// authenticated request, so it logs at debug level only.
+func (s *sessionUsecase) CheckAccess(userID, sessionID int64, tokenVersion int) ([]string, error) {
+	state, err := s.sessionRepo.GetAccessState(userID, sessionID)
+	if err != nil {
+		s.logger.Error("Failed to get access state", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to check token", http.StatusInternalServerError)
+	}
+	if state == nil || !state.SessionActive || state.TokenVersion != tokenVersion || state.Status != "active" {
+		s.logger.Debug("Access token revoked", zap.Int64("user_id", userID), zap.Int64("session_id", sessionID))
+		return nil, errors.NewAppError(errors.ErrTokenRevoked, "Token has been revoked", http.StatusUnauthorized)
+	}
+	return state.Permissions, nil
+}
+
+// addRefreshToken issues the next refresh token of a session. Only its
+// hash is stored.
+func addRefreshToken(repo domain.SessionRepository, sessionID int64) (string, error) {
+	token, tokenHash, err := hash.NewToken()
+	if err != nil {
+		return "", err
+	}
+	if err := repo.AddRefreshToken(sessionID, tokenHash); err != nil {
+		return "", err
+	}
+	return token, nil
+}
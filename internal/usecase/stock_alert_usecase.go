@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"net/http"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+
+	"go.uber.org/zap"
+)
+
+type stockAlertUsecase struct {
+	stockAlertRepo domain.StockAlertRepository
+	productRepo    domain.ProductRepository
+	logger         *zap.Logger
+}
+
+func NewStockAlertUsecase(stockAlertRepo domain.StockAlertRepository, productRepo domain.ProductRepository, logger *zap.Logger) domain.StockAlertUsecase {
+	return &stockAlertUsecase{stockAlertRepo: stockAlertRepo, productRepo: productRepo, logger: logger}
+}
+
+// Subscribe implements domain.StockAlertUsecase.
+func (s *stockAlertUsecase) Subscribe(userID, productID int64, actor domain.Actor) error {
+	s.logger.Debug("Subscribe function called", zap.Int64("user_id", userID), zap.Int64("product_id", productID))
+
+	if _, err := s.productRepo.ForTenant(actor.TenantID).GetByID(productID); err != nil {
+		s.logger.Warn("Failed to get product", zap.Error(err), zap.Int64("product_id", productID))
+		return errors.NewAppError(errors.ErrProductNotFound, "Product not found", http.StatusNotFound)
+	}
+
+	if err := s.stockAlertRepo.Subscribe(userID, productID); err != nil {
+		s.logger.Error("Failed to subscribe to product", zap.Error(err), zap.Int64("user_id", userID))
+		return errors.NewAppError(err, "Failed to subscribe to product", http.StatusInternalServerError)
+	}
+
+	s.logger.Info("Subscribed to product successfully", zap.Int64("user_id", userID), zap.Int64("product_id", productID))
+	return nil
+}
+
+// Unsubscribe implements domain.StockAlertUsecase.
+func (s *stockAlertUsecase) Unsubscribe(userID, productID int64) error {
+	found, err := s.stockAlertRepo.Unsubscribe(userID, productID)
+	if err != nil {
+		s.logger.Error("Failed to unsubscribe from product", zap.Error(err), zap.Int64("user_id", userID))
+		return errors.NewAppError(err, "Failed to unsubscribe from product", http.StatusInternalServerError)
+	}
+	if !found {
+		return errors.NewAppError(errors.ErrSubscriptionNotFound, "Not subscribed to the product", http.StatusNotFound)
+	}
+
+	s.logger.Info("Unsubscribed from product successfully", zap.Int64("user_id", userID), zap.Int64("product_id", productID))
+	return nil
+}
+
+// GetAlerts implements domain.StockAlertUsecase.
+func (s *stockAlertUsecase) GetAlerts(userID int64, page pagination.Params) (*pagination.Page[domain.StockAlert], error) {
+	page = page.Normalize()
+
+	afterID, err := decodeIDCursor(page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := s.stockAlertRepo.GetByUserID(userID, afterID, page.Limit+1)
+	if err != nil {
+		s.logger.Error("Failed to get stock alerts", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to get stock alerts", http.StatusInternalServerError)
+	}
+
+	total, err := s.stockAlertRepo.CountByUserID(userID)
+	if err != nil {
+		s.logger.Error("Failed to count stock alerts", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, errors.NewAppError(err, "Failed to count stock alerts", http.StatusInternalServerError)
+	}
+
+	result, err := pagination.NewPage(alerts, page.Limit, total, func(alert domain.StockAlert) interface{} {
+		return pagination.IDCursor{ID: alert.ID}
+	})
+	if err != nil {
+		return nil, errors.NewAppError(err, "Failed to get stock alerts", http.StatusInternalServerError)
+	}
+
+	return result, nil
+}
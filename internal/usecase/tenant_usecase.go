@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"net/http"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+
+	"go.uber.org/zap"
+)
+
+type tenantUsecase struct {
+	tenantRepo domain.TenantRepository
+	logger     *zap.Logger
+}
+
+func NewTenantUsecase(tenantRepo domain.TenantRepository, logger *zap.Logger) domain.TenantUsecase {
+	return &tenantUsecase{tenantRepo: tenantRepo, logger: logger}
+}
+
+// GetBySlug implements domain.TenantUsecase.
+func (t *tenantUsecase) GetBySlug(slug string) (*domain.Tenant, error) {
+	tenant, err := t.tenantRepo.GetBySlug(slug)
+	if err != nil {
+		t.logger.Error("Failed to get tenant", zap.Error(err), zap.String("slug", slug))
+		return nil, errors.NewAppError(err, "Failed to get tenant", http.StatusInternalServerError)
+	}
+	if tenant == nil {
+		t.logger.Warn("Request for unknown tenant", zap.String("slug", slug))
+		return nil, errors.NewAppError(errors.ErrTenantNotFound, "Tenant not found", http.StatusNotFound)
+	}
+	return tenant, nil
+}
@@ -0,0 +1,328 @@
+package usecase
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+)
+
+// The fakes below keep rows in memory and honour tenant and system scoping
+// as the postgres repositories do. Methods the tests do not reach are left
+// to the embedded interface and panic if called.
+
+// fakeScope is the scope of a fake repository: one tenant, or every tenant
+// when system is set.
+type fakeScope struct {
+	tenantID int64
+	system   bool
+}
+
+func (s fakeScope) matches(tenantID int64) bool {
+	return s.system || (s.tenantID != 0 && s.tenantID == tenantID)
+}
+
+type fakeUserRepository struct {
+	domain.UserRepository
+	users map[int64]*domain.User
+	// withPermission lists the active holders of each permission.
+	withPermission map[string][]int64
+	scope          fakeScope
+}
+
+func (f *fakeUserRepository) ForTenant(tenantID int64) domain.UserRepository {
+	return &fakeUserRepository{users: f.users, withPermission: f.withPermission, scope: fakeScope{tenantID: tenantID}}
+}
+
+func (f *fakeUserRepository) GetByID(id int64) (*domain.User, error) {
+	user, ok := f.users[id]
+	if !ok || !f.scope.matches(user.TenantID) {
+		return nil, pgx.ErrNoRows
+	}
+	found := *user
+	return &found, nil
+}
+
+func (f *fakeUserRepository) GetIDsWithPermission(permission string) ([]int64, error) {
+	var ids []int64
+	for _, id := range f.withPermission[permission] {
+		if user, ok := f.users[id]; ok && f.scope.matches(user.TenantID) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+type fakePurchaseOrderRepository struct {
+	domain.PurchaseOrderRepository
+	orders map[int64]*domain.PurchaseOrder
+	scope  fakeScope
+}
+
+func (f *fakePurchaseOrderRepository) ForTenant(tenantID int64) domain.PurchaseOrderRepository {
+	return &fakePurchaseOrderRepository{orders: f.orders, scope: fakeScope{tenantID: tenantID}}
+}
+
+func (f *fakePurchaseOrderRepository) GetByID(id int64) (*domain.PurchaseOrder, error) {
+	order, ok := f.orders[id]
+	if !ok || !f.scope.matches(order.TenantID) {
+		return nil, pgx.ErrNoRows
+	}
+	found := *order
+	return &found, nil
+}
+
+type fakeRFQRepository struct {
+	domain.RFQRepository
+	rfqs  map[int64]*domain.RFQ
+	scope fakeScope
+}
+
+func (f *fakeRFQRepository) ForTenant(tenantID int64) domain.RFQRepository {
+	return &fakeRFQRepository{rfqs: f.rfqs, scope: fakeScope{tenantID: tenantID}}
+}
+
+func (f *fakeRFQRepository) SystemScope() domain.RFQRepository {
+	return &fakeRFQRepository{rfqs: f.rfqs, scope: fakeScope{system: true}}
+}
+
+func (f *fakeRFQRepository) Create(rfq *domain.RFQ) error {
+	rfq.ID = int64(len(f.rfqs) + 1)
+	rfq.TenantID = f.scope.tenantID
+	rfq.Status = domain.RFQStatusOpen
+	stored := *rfq
+	f.rfqs[rfq.ID] = &stored
+	return nil
+}
+
+func (f *fakeRFQRepository) CloseDue(limit int) ([]domain.RFQ, error) {
+	now := time.Now()
+	var closed []domain.RFQ
+	for _, id := range sortedIDs(f.rfqs) {
+		rfq := f.rfqs[id]
+		if len(closed) == limit {
+			break
+		}
+		if !f.scope.matches(rfq.TenantID) || rfq.Status != domain.RFQStatusOpen || !rfq.Deadline.Before(now) {
+			continue
+		}
+		rfq.Status = domain.RFQStatusClosed
+		rfq.ClosedAt = &now
+		closed = append(closed, *rfq)
+	}
+	return closed, nil
+}
+
+type fakeApprovalRepository struct {
+	domain.ApprovalRepository
+	approvals map[int64]*domain.Approval
+	scope     fakeScope
+}
+
+func (f *fakeApprovalRepository) ForTenant(tenantID int64) domain.ApprovalRepository {
+	return &fakeApprovalRepository{approvals: f.approvals, scope: fakeScope{tenantID: tenantID}}
+}
+
+func (f *fakeApprovalRepository) SystemScope() domain.ApprovalRepository {
+	return &fakeApprovalRepository{approvals: f.approvals, scope: fakeScope{system: true}}
+}
+
+func (f *fakeApprovalRepository) Create(approval *domain.Approval) error {
+	approval.ID = int64(len(f.approvals) + 1)
+	approval.TenantID = f.scope.tenantID
+	approval.Status = domain.ApprovalStatusPending
+	approval.IdleSince = time.Now()
+	stored := *approval
+	f.approvals[approval.ID] = &stored
+	return nil
+}
+
+func (f *fakeApprovalRepository) GetByID(id int64) (*domain.Approval, error) {
+	approval, ok := f.approvals[id]
+	if !ok || !f.scope.matches(approval.TenantID) {
+		return nil, nil
+	}
+	found := *approval
+	return &found, nil
+}
+
+func (f *fakeApprovalRepository) Decide(id int64, status string) (*domain.Approval, error) {
+	approval, ok := f.approvals[id]
+	if !ok || !f.scope.matches(approval.TenantID) || approval.Status != domain.ApprovalStatusPending {
+		return nil, nil
+	}
+	approval.Status = status
+	approval.IdleSince = time.Now()
+	decided := *approval
+	return &decided, nil
+}
+
+func (f *fakeApprovalRepository) EscalateIdle(idleBefore time.Time, limit int) ([]domain.Approval, error) {
+	now := time.Now()
+	var escalated []domain.Approval
+	for _, id := range sortedIDs(f.approvals) {
+		approval := f.approvals[id]
+		if len(escalated) == limit {
+			break
+		}
+		if !f.scope.matches(approval.TenantID) || approval.Status != domain.ApprovalStatusPending || approval.EscalatedAt != nil || !approval.IdleSince.Before(idleBefore) {
+			continue
+		}
+		approval.EscalatedAt = &now
+		escalated = append(escalated, *approval)
+	}
+	return escalated, nil
+}
+
+type fakeVendorDocumentRepository struct {
+	domain.VendorDocumentRepository
+	documents map[int64]*domain.VendorDocument
+	scope     fakeScope
+}
+
+func (f *fakeVendorDocumentRepository) ForTenant(tenantID int64) domain.VendorDocumentRepository {
+	return &fakeVendorDocumentRepository{documents: f.documents, scope: fakeScope{tenantID: tenantID}}
+}
+
+func (f *fakeVendorDocumentRepository) SystemScope() domain.VendorDocumentRepository {
+	return &fakeVendorDocumentRepository{documents: f.documents, scope: fakeScope{system: true}}
+}
+
+func (f *fakeVendorDocumentRepository) Create(document *domain.VendorDocument) error {
+	document.ID = int64(len(f.documents) + 1)
+	document.TenantID = f.scope.tenantID
+	stored := *document
+	f.documents[document.ID] = &stored
+	return nil
+}
+
+func (f *fakeVendorDocumentRepository) ClaimExpiring(expiresBefore time.Time, limit int) ([]domain.VendorDocument, error) {
+	now := time.Now()
+	var claimed []domain.VendorDocument
+	for _, id := range sortedIDs(f.documents) {
+		document := f.documents[id]
+		if len(claimed) == limit {
+			break
+		}
+		if !f.scope.matches(document.TenantID) || document.RemindedAt != nil || !document.ExpiresAt.Before(expiresBefore) {
+			continue
+		}
+		document.RemindedAt = &now
+		claimed = append(claimed, *document)
+	}
+	return claimed, nil
+}
+
+type fakeJobRepository struct {
+	domain.JobRepository
+	jobs []*domain.Job
+}
+
+func (f *fakeJobRepository) Enqueue(jobs ...*domain.Job) error {
+	f.jobs = append(f.jobs, jobs...)
+	return nil
+}
+
+type fakeAuditRepository struct {
+	domain.AuditRepository
+	events []*domain.AuditEvent
+}
+
+func (f *fakeAuditRepository) Record(event *domain.AuditEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+// fakeTransactor runs fn straight away with the fakes scoped to the tenant,
+// or to every tenant for system transactions.
+type fakeTransactor struct {
+	users           *fakeUserRepository
+	orders          *fakePurchaseOrderRepository
+	roles           *fakeRoleRepository
+	rfqs            *fakeRFQRepository
+	approvals       *fakeApprovalRepository
+	vendorDocuments *fakeVendorDocumentRepository
+	jobs            *fakeJobRepository
+	audit           *fakeAuditRepository
+}
+
+// newFakeTransactor returns a transactor over empty fakes.
+func newFakeTransactor() *fakeTransactor {
+	return &fakeTransactor{
+		users:           &fakeUserRepository{users: map[int64]*domain.User{}, withPermission: map[string][]int64{}},
+		orders:          &fakePurchaseOrderRepository{orders: map[int64]*domain.PurchaseOrder{}},
+		roles:           &fakeRoleRepository{},
+		rfqs:            &fakeRFQRepository{rfqs: map[int64]*domain.RFQ{}},
+		approvals:       &fakeApprovalRepository{approvals: map[int64]*domain.Approval{}},
+		vendorDocuments: &fakeVendorDocumentRepository{documents: map[int64]*domain.VendorDocument{}},
+		jobs:            &fakeJobRepository{},
+		audit:           &fakeAuditRepository{},
+	}
+}
+
+func (t *fakeTransactor) WithinTransaction(tenantID int64, fn func(repos domain.Repositories) error) error {
+	return fn(domain.Repositories{
+		Users:           t.users.ForTenant(tenantID),
+		PurchaseOrders:  t.orders.ForTenant(tenantID),
+		Roles:           t.roles,
+		RFQs:            t.rfqs.ForTenant(tenantID),
+		Approvals:       t.approvals.ForTenant(tenantID),
+		VendorDocuments: t.vendorDocuments.ForTenant(tenantID),
+		Jobs:            t.jobs,
+		Audit:           t.audit,
+	})
+}
+
+func (t *fakeTransactor) WithinSystemTransaction(fn func(repos domain.Repositories) error) error {
+	return fn(domain.Repositories{
+		Users:           &fakeUserRepository{users: t.users.users, withPermission: t.users.withPermission, scope: fakeScope{system: true}},
+		PurchaseOrders:  &fakePurchaseOrderRepository{orders: t.orders.orders, scope: fakeScope{system: true}},
+		Roles:           t.roles,
+		RFQs:            t.rfqs.SystemScope(),
+		Approvals:       t.approvals.SystemScope(),
+		VendorDocuments: t.vendorDocuments.SystemScope(),
+		Jobs:            t.jobs,
+		Audit:           t.audit,
+	})
+}
+
+// sortedIDs returns the keys of rows in ascending order, the order the
+// postgres repositories claim rows in.
+func sortedIDs[T any](rows map[int64]*T) []int64 {
+	ids := make([]int64, 0, len(rows))
+	for id := range rows {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// notificationJobs decodes the notifications among jobs, failing the test
+// on any other job.
+func notificationJobs(t *testing.T, jobs []*domain.Job) []domain.NotificationJob {
+	t.Helper()
+	notifications := make([]domain.NotificationJob, 0, len(jobs))
+	for _, job := range jobs {
+		if job.Type != domain.JobNotificationDeliver {
+			t.Fatalf("job type = %s, want %s", job.Type, domain.JobNotificationDeliver)
+		}
+		var notification domain.NotificationJob
+		if err := json.Unmarshal(job.Payload, &notification); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications
+}
+
+// recipients returns who notifications go to, in order.
+func recipients(notifications []domain.NotificationJob) []int64 {
+	ids := make([]int64, 0, len(notifications))
+	for _, notification := range notifications {
+		ids = append(ids, notification.UserID)
+	}
+	return ids
+}
@@ -2,31 +2,42 @@ package usecase
 
 import (
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
-	"github.com/zulfikarmuzakir/e_procurement/pkg/auth"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain/converter"
 	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
 	"github.com/zulfikarmuzakir/e_procurement/pkg/hash"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
 
 	"go.uber.org/zap"
 )
 
 type userUsecase struct {
-	userRepo domain.UserRepository
-	jwtAuth  *auth.JWTAuth
-	logger   *zap.Logger
+	userRepo         domain.UserRepository
+	mfaRepo          domain.MFARepository
+	loginAttemptRepo domain.LoginAttemptRepository
+	roleRepo         domain.RoleRepository
+	transactor       domain.Transactor
+	sessions         domain.SessionUsecase
+	logger           *zap.Logger
 }
 
-func NewUserUsecase(userRepo domain.UserRepository, jwtAuth *auth.JWTAuth, logger *zap.Logger) domain.UserUsecase {
+func NewUserUsecase(userRepo domain.UserRepository, mfaRepo domain.MFARepository, loginAttemptRepo domain.LoginAttemptRepository, roleRepo domain.RoleRepository, transactor domain.Transactor, sessions domain.SessionUsecase, logger *zap.Logger) domain.UserUsecase {
 	return &userUsecase{
-		userRepo: userRepo,
-		jwtAuth:  jwtAuth,
-		logger:   logger,
+		userRepo:         userRepo,
+		mfaRepo:          mfaRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		roleRepo:         roleRepo,
+		transactor:       transactor,
+		sessions:         sessions,
+		logger:           logger,
 	}
 }
 
-func (u *userUsecase) Register(user *domain.User) error {
-	u.logger.Debug("Register function called", zap.String("email", user.Email), zap.String("password", user.Password))
+func (u *userUsecase) Register(user *domain.User, actor domain.Actor) error {
+	u.logger.Debug("Register function called", zap.String("email", user.Email))
 
 	hashedPassword, err := hash.HashPassword(user.Password)
 	if err != nil {
@@ -35,8 +46,23 @@ func (u *userUsecase) Register(user *domain.User) error {
 	}
 
 	user.Password = hashedPassword
+	user.TenantID = actor.TenantID
 
-	if err := u.userRepo.Create(user); err != nil {
+	err = u.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		if err := repos.Users.Create(user); err != nil {
+			return err
+		}
+		for _, role := range user.Roles {
+			if err := repos.Roles.AddUserRoleByName(user.ID, role); err != nil {
+				return err
+			}
+		}
+		if err := recordAudit(repos, actor, domain.AuditActionCreate, domain.AuditEntityUser, user.ID, nil, converter.UserToUserResponse(user)); err != nil {
+			return err
+		}
+		return queueVerificationEmail(repos, user.ID, time.Now())
+	})
+	if err != nil {
 		u.logger.Error("Failed to create user", zap.Error(err))
 		return errors.NewAppError(err, err.Error(), http.StatusInternalServerError)
 	}
@@ -45,75 +71,192 @@ func (u *userUsecase) Register(user *domain.User) error {
 	return nil
 }
 
-func (u *userUsecase) Login(email, password string) (string, string, error) {
-	u.logger.Debug("Login function called", zap.String("email", email), zap.String("password", password))
+func (u *userUsecase) Login(email, password string, actor domain.Actor) (*domain.LoginResult, error) {
+	u.logger.Debug("Login function called", zap.String("email", email))
 
-	user, err := u.userRepo.GetByEmail(email)
+	now := time.Now()
+	failures, oldest, err := u.loginAttemptRepo.CountRecentFailuresByIP(actor.IP, now.Add(-domain.LoginIPWindow))
+	if err != nil {
+		u.logger.Error("Failed to count login failures", zap.Error(err), zap.String("ip", actor.IP))
+		return nil, errors.NewAppError(err, "Failed to login", http.StatusInternalServerError)
+	}
+	if failures >= domain.LoginIPMaxFailures {
+		u.logger.Warn("Login attempt from blocked IP", zap.String("email", email), zap.String("ip", actor.IP))
+		u.recordLoginAttempt(nil, email, actor, domain.LoginFailedIPBlocked)
+		return nil, loginThrottled(oldest.Add(domain.LoginIPWindow).Sub(now))
+	}
+
+	// Emails are unique across the deployment, so users log in without
+	// naming their tenant.
+	user, err := u.userRepo.SystemScope().GetByEmail(email)
 	if err != nil {
 		u.logger.Warn("Login attempt with non-existent email", zap.String("email", email))
-		return "", "", errors.NewAppError(errors.ErrInvalidCredentials, "Invalid email or password", http.StatusUnauthorized)
+		u.recordLoginAttempt(nil, email, actor, domain.LoginFailedUnknownEmail)
+		return nil, errors.NewAppError(errors.ErrInvalidCredentials, "Invalid email or password", http.StatusUnauthorized)
+	}
+
+	// The password is not checked at all while the account has to wait.
+	if user.LockedUntil != nil && user.LockedUntil.After(now) {
+		u.logger.Warn("Login attempt on locked account", zap.String("email", email), zap.Time("locked_until", *user.LockedUntil))
+		u.recordLoginAttempt(user, email, actor, domain.LoginFailedLocked)
+		return nil, loginThrottled(user.LockedUntil.Sub(now))
 	}
 
 	// check status active or not
 	if user.Status != "active" {
 		u.logger.Warn("Login attempt with non-active user", zap.String("email", email))
-		return "", "", errors.NewAppError(errors.ErrUserNotActive, "trying to login with non-active user", http.StatusUnauthorized)
+		u.recordLoginAttempt(user, email, actor, domain.LoginFailedInactive)
+		return nil, errors.NewAppError(errors.ErrUserNotActive, "trying to login with non-active user", http.StatusUnauthorized)
 	}
 
-	u.logger.Debug("User retrieved from database", zap.String("storedPassword", user.Password))
-
 	err = hash.CheckPasswordHash(password, user.Password)
 	if err != nil {
 		u.logger.Warn("Login attempt with incorrect password",
 			zap.String("email", email),
-			zap.String("inputPassword", password),
-			zap.String("storedHash", user.Password),
+			zap.Int64("user_id", user.ID),
 			zap.Error(err))
-		return "", "", errors.NewAppError(errors.ErrInvalidCredentials, "Invalid email or password", http.StatusUnauthorized)
+		u.recordLoginAttempt(user, email, actor, domain.LoginFailedPassword)
+		if err := u.recordFailedLogin(user, now); err != nil {
+			u.logger.Error("Failed to record failed login", zap.Error(err), zap.Int64("user_id", user.ID))
+			return nil, errors.NewAppError(err, "Failed to login", http.StatusInternalServerError)
+		}
+		return nil, errors.NewAppError(errors.ErrInvalidCredentials, "Invalid email or password", http.StatusUnauthorized)
+	}
+
+	if user.FailedLogins > 0 || user.LockedUntil != nil {
+		if err := u.userRepo.ForTenant(user.TenantID).ResetFailedLogins(user.ID); err != nil {
+			u.logger.Error("Failed to reset failed logins", zap.Error(err), zap.Int64("user_id", user.ID))
+			return nil, errors.NewAppError(err, "Failed to login", http.StatusInternalServerError)
+		}
 	}
 
-	accessToken, err := u.jwtAuth.GenerateAccessToken(user)
+	mfa, err := u.mfaRepo.Get(user.ID)
 	if err != nil {
-		u.logger.Error("Failed to generate access token", zap.Error(err))
-		return "", "", errors.NewAppError(err, "Failed to generate access token", http.StatusInternalServerError)
+		u.logger.Error("Failed to get MFA enrollment", zap.Error(err), zap.Int64("user_id", user.ID))
+		return nil, errors.NewAppError(err, "Failed to login", http.StatusInternalServerError)
 	}
 
-	refreshToken, err := u.jwtAuth.GenerateRefreshToken(user)
+	required, err := mfaRequired(u.roleRepo, user)
 	if err != nil {
-		u.logger.Error("Failed to generate refresh token", zap.Error(err))
-		return "", "", errors.NewAppError(err, "Failed to generate refresh token", http.StatusInternalServerError)
+		u.logger.Error("Failed to get user permissions", zap.Error(err), zap.Int64("user_id", user.ID))
+		return nil, errors.NewAppError(err, "Failed to login", http.StatusInternalServerError)
+	}
+
+	// Users with MFA, and admins, who must set it up, finish the login
+	// with a code at POST /login/mfa.
+	enrolled := mfa != nil && mfa.ConfirmedAt != nil
+	if enrolled || required {
+		token, tokenHash, err := hash.NewToken()
+		if err != nil {
+			u.logger.Error("Failed to generate MFA challenge", zap.Error(err))
+			return nil, errors.NewAppError(err, "Failed to login", http.StatusInternalServerError)
+		}
+		expiresAt := time.Now().Add(domain.MFAChallengeTTL)
+		if err := u.mfaRepo.CreateChallenge(user.ID, tokenHash, expiresAt); err != nil {
+			u.logger.Error("Failed to create MFA challenge", zap.Error(err), zap.Int64("user_id", user.ID))
+			return nil, errors.NewAppError(err, "Failed to login", http.StatusInternalServerError)
+		}
+
+		u.recordLoginAttempt(user, email, actor, domain.LoginMFARequired)
+		u.logger.Info("MFA challenge issued", zap.String("email", email))
+		return &domain.LoginResult{
+			MFARequired:           true,
+			MFAEnrollmentRequired: !enrolled,
+			ChallengeToken:        token,
+			ChallengeExpiresAt:    &expiresAt,
+		}, nil
 	}
 
+	result, err := u.sessions.Start(user, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	u.recordLoginAttempt(user, email, actor, "")
 	u.logger.Info("User logged in successfully", zap.String("email", email))
-	return accessToken, refreshToken, nil
+	return result, nil
 }
 
-// New feature: Refresh token
-func (u *userUsecase) RefreshToken(refreshToken string) (string, error) {
-	claims, err := u.jwtAuth.ValidateToken(refreshToken, false)
+// recordFailedLogin counts a wrong password against the user and makes the
+// account wait before the next attempt. When the account is locked the user
+// is told by a notification, enqueued with the lock.
+func (u *userUsecase) recordFailedLogin(user *domain.User, now time.Time) error {
+	userRepo := u.userRepo.ForTenant(user.TenantID)
+	failures, err := userRepo.IncrementFailedLogins(user.ID)
 	if err != nil {
-		u.logger.Warn("Invalid refresh token", zap.Error(err))
-		return "", errors.NewAppError(err, "Invalid refresh token", http.StatusUnauthorized)
+		return err
+	}
+	wait, locked := domain.LoginBackoff(failures)
+	if wait == 0 {
+		return nil
+	}
+	until := now.Add(wait)
+	if !locked {
+		return userRepo.Lock(user.ID, until)
 	}
 
-	user, err := u.userRepo.GetByID(claims.UserID)
-	if err != nil {
-		u.logger.Warn("Failed to get user", zap.Error(err), zap.Int64("user_id", claims.UserID))
-		return "", errors.NewAppError(errors.ErrUserNotFound, "User not found", http.StatusNotFound)
+	u.logger.Warn("Account locked after failed logins", zap.Int64("user_id", user.ID), zap.Int("failures", failures), zap.Time("locked_until", until))
+	return u.transactor.WithinTransaction(user.TenantID, func(repos domain.Repositories) error {
+		if err := repos.Users.Lock(user.ID, until); err != nil {
+			return err
+		}
+		notification, err := domain.NewNotificationJob(user.ID, domain.NotificationAccountLocked, map[string]interface{}{
+			"Failures":    failures,
+			"LockedUntil": until.UTC().Format(time.RFC1123),
+		})
+		if err != nil {
+			return err
+		}
+		return repos.Jobs.Enqueue(notification)
+	})
+}
+
+// recordLoginAttempt logs a login attempt; success has no reason. The
+// attempt is only bookkeeping, so failing to record it does not fail the
+// login.
+func (u *userUsecase) recordLoginAttempt(user *domain.User, email string, actor domain.Actor, reason string) {
+	attempt := &domain.LoginAttempt{
+		Email:     email,
+		IP:        actor.IP,
+		UserAgent: actor.UserAgent,
+		Success:   reason == "" || reason == domain.LoginMFARequired,
+		Reason:    reason,
+	}
+	if user != nil {
+		attempt.UserID = &user.ID
 	}
+	if err := u.loginAttemptRepo.Record(attempt); err != nil {
+		u.logger.Error("Failed to record login attempt", zap.Error(err), zap.String("email", email))
+	}
+}
+
+func loginThrottled(retryAfter time.Duration) error {
+	return errors.NewAppError(&domain.LoginThrottledError{RetryAfter: retryAfter}, "Too many failed login attempts, try again later", http.StatusTooManyRequests)
+}
 
-	newAccessToken, err := u.jwtAuth.GenerateAccessToken(user)
+// mfaRequired reports whether the user cannot sign in without MFA: holders
+// of the admin or operator role, and anyone who can manage roles and so
+// grant themselves anything.
+func mfaRequired(roleRepo domain.RoleRepository, user *domain.User) (bool, error) {
+	if user.HasRole(domain.RoleAdmin) || user.HasRole(domain.RoleOperator) {
+		return true, nil
+	}
+	permissions, err := roleRepo.GetUserPermissions(user.ID)
 	if err != nil {
-		u.logger.Error("Failed to generate new access token", zap.Error(err))
-		return "", errors.NewAppError(err, "Failed to generate new access token", http.StatusInternalServerError)
+		return false, err
 	}
-
-	u.logger.Info("Access token refreshed successfully", zap.Int64("user_id", user.ID))
-	return newAccessToken, nil
+	return slices.Contains(permissions, domain.PermissionRoleManage), nil
 }
 
-func (u *userUsecase) GetByID(id int64) (*domain.User, error) {
-	user, err := u.userRepo.GetByID(id)
+// GetByID implements domain.UserUsecase. Users can get themselves, and
+// holders of user:read anyone.
+func (u *userUsecase) GetByID(id int64, actor domain.Actor) (*domain.User, error) {
+	if err := authorize(actor, id, "", domain.PermissionUserRead); err != nil {
+		u.logger.Warn("Getting another user without permission", zap.Int64("user_id", id), zap.Int64("actor_id", actor.UserID))
+		return nil, errors.NewAppError(err, "You cannot view this user", http.StatusForbidden)
+	}
+
+	user, err := u.userRepo.ForTenant(actor.TenantID).GetByID(id)
 	if err != nil {
 		u.logger.Warn("Failed to get user", zap.Error(err), zap.Int64("user_id", id))
 		return nil, errors.NewAppError(errors.ErrUserNotFound, "User not found", http.StatusNotFound)
@@ -121,8 +264,10 @@ func (u *userUsecase) GetByID(id int64) (*domain.User, error) {
 	return user, nil
 }
 
+// GetByEmail looks the user up in every tenant: emails are unique across
+// the deployment.
 func (u *userUsecase) GetByEmail(email string) (*domain.User, error) {
-	user, err := u.userRepo.GetByEmail(email)
+	user, err := u.userRepo.SystemScope().GetByEmail(email)
 	if err != nil {
 		u.logger.Warn("Failed to get user", zap.Error(err), zap.String("email", email))
 		return nil, errors.NewAppError(errors.ErrUserNotFound, "User not found", http.StatusNotFound)
@@ -130,17 +275,80 @@ func (u *userUsecase) GetByEmail(email string) (*domain.User, error) {
 	return user, nil
 }
 
-func (u *userUsecase) GetAllByRole(role string) ([]*domain.User, error) {
-	users, err := u.userRepo.GetAllByRole(role)
+func (u *userUsecase) GetAllByRole(role string, verifiedOnly bool, page pagination.Params, actor domain.Actor) (*pagination.Page[*domain.User], error) {
+	page = page.Normalize()
+
+	afterID, err := decodeIDCursor(page.Cursor)
+	if err != nil {
+		u.logger.Warn("Invalid user cursor", zap.String("cursor", page.Cursor))
+		return nil, err
+	}
+
+	userRepo := u.userRepo.ForTenant(actor.TenantID)
+	users, err := userRepo.GetAllByRole(role, verifiedOnly, afterID, page.Limit+1)
 	if err != nil {
 		u.logger.Warn("Failed to get users", zap.Error(err), zap.String("role", role))
 		return nil, errors.NewAppError(errors.ErrUserNotFound, "Users not found", http.StatusNotFound)
 	}
-	return users, nil
+
+	total, err := userRepo.CountByRole(role, verifiedOnly)
+	if err != nil {
+		u.logger.Error("Failed to count users", zap.Error(err), zap.String("role", role))
+		return nil, errors.NewAppError(err, "Failed to count users", http.StatusInternalServerError)
+	}
+
+	result, err := pagination.NewPage(users, page.Limit, total, func(user *domain.User) interface{} {
+		return pagination.IDCursor{ID: user.ID}
+	})
+	if err != nil {
+		u.logger.Error("Failed to build user page", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to get users", http.StatusInternalServerError)
+	}
+	return result, nil
 }
 
-func (u *userUsecase) Update(user *domain.User) error {
-	if err := u.userRepo.Update(user); err != nil {
+// Update implements domain.UserUsecase. Users can update themselves, and
+// holders of user:write anyone; only the latter can change the status.
+// Roles are not updated here but through RoleUsecase.SetUserRoles, and the
+// password is kept: it only changes hashed, through the password reset
+// flow.
+func (u *userUsecase) Update(user *domain.User, actor domain.Actor) error {
+	if err := authorize(actor, user.ID, "", domain.PermissionUserWrite); err != nil {
+		u.logger.Warn("Updating another user without permission", zap.Int64("user_id", user.ID), zap.Int64("actor_id", actor.UserID))
+		return errors.NewAppError(err, "You cannot update this user", http.StatusForbidden)
+	}
+
+	err := u.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		before, err := repos.Users.GetByID(user.ID)
+		if err != nil {
+			return errors.ErrUserNotFound
+		}
+		user.Password = before.Password
+		if !actor.Can(domain.PermissionUserWrite) {
+			user.Status = before.Status
+		}
+		if err := repos.Users.Update(user); err != nil {
+			return err
+		}
+		after, err := repos.Users.GetByID(user.ID)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(repos, actor, domain.AuditActionUpdate, domain.AuditEntityUser, user.ID, converter.UserToUserResponse(before), converter.UserToUserResponse(after)); err != nil {
+			return err
+		}
+		// A new email address has to be verified again.
+		if after.Email != before.Email {
+			return queueVerificationEmail(repos, user.ID, time.Now())
+		}
+		return nil
+	})
+	switch err {
+	case nil:
+	case errors.ErrUserNotFound:
+		u.logger.Warn("Updating non-existent user", zap.Int64("user_id", user.ID))
+		return errors.NewAppError(err, "User not found", http.StatusNotFound)
+	default:
 		u.logger.Error("Failed to update user", zap.Error(err), zap.Int64("user_id", user.ID))
 		return errors.NewAppError(err, "Failed to update user", http.StatusInternalServerError)
 	}
@@ -148,14 +356,19 @@ func (u *userUsecase) Update(user *domain.User) error {
 	return nil
 }
 
-func (u *userUsecase) ApproveVendor(id int64) error {
-	user, err := u.userRepo.GetByID(id)
+func (u *userUsecase) ApproveVendor(id int64, actor domain.Actor) error {
+	user, err := u.userRepo.ForTenant(actor.TenantID).GetByID(id)
 	if err != nil {
 		u.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", id))
 		return errors.NewAppError(err, "Failed to get user", http.StatusInternalServerError)
 	}
+	if user.EmailVerifiedAt == nil {
+		u.logger.Warn("Approving vendor with unverified email", zap.Int64("user_id", id))
+		return errors.NewAppError(errors.ErrEmailNotVerified, "Vendor has not verified their email", http.StatusConflict)
+	}
+	before := converter.UserToUserResponse(user)
 	user.Status = "active"
-	if err := u.userRepo.Update(user); err != nil {
+	if err := u.updateVendorStatus(user, before, actor, domain.NotificationVendorApproved, domain.EventVendorApproved); err != nil {
 		u.logger.Error("Failed to update user status", zap.Error(err), zap.Int64("user_id", id))
 		return errors.NewAppError(err, "Failed to update user status", http.StatusInternalServerError)
 	}
@@ -163,14 +376,15 @@ func (u *userUsecase) ApproveVendor(id int64) error {
 	return nil
 }
 
-func (u *userUsecase) RejectVendor(id int64) error {
-	user, err := u.userRepo.GetByID(id)
+func (u *userUsecase) RejectVendor(id int64, actor domain.Actor) error {
+	user, err := u.userRepo.ForTenant(actor.TenantID).GetByID(id)
 	if err != nil {
 		u.logger.Error("Failed to get user", zap.Error(err), zap.Int64("user_id", id))
 		return errors.NewAppError(err, "Failed to get user", http.StatusInternalServerError)
 	}
+	before := converter.UserToUserResponse(user)
 	user.Status = "rejected"
-	if err := u.userRepo.Update(user); err != nil {
+	if err := u.updateVendorStatus(user, before, actor, domain.NotificationVendorRejected, domain.EventVendorRejected); err != nil {
 		u.logger.Error("Failed to update user status", zap.Error(err), zap.Int64("user_id", id))
 		return errors.NewAppError(err, "Failed to update user status", http.StatusInternalServerError)
 	}
@@ -178,11 +392,77 @@ func (u *userUsecase) RejectVendor(id int64) error {
 	return nil
 }
 
-func (u *userUsecase) Delete(id int64) error {
-	if err := u.userRepo.Delete(id); err != nil {
+// updateVendorStatus saves the vendor's new status and records the audit
+// event, notification and webhook event for it in the same transaction.
+func (u *userUsecase) updateVendorStatus(user *domain.User, before *domain.UserResponse, actor domain.Actor, notificationEvent, webhookEvent string) error {
+	return u.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		if err := repos.Users.Update(user); err != nil {
+			return err
+		}
+		if err := recordAudit(repos, actor, domain.AuditActionUpdate, domain.AuditEntityUser, user.ID, before, converter.UserToUserResponse(user)); err != nil {
+			return err
+		}
+
+		notification, err := domain.NewNotificationJob(user.ID, notificationEvent, nil)
+		if err != nil {
+			return err
+		}
+		event, err := domain.NewWebhookEventJob(webhookEvent, converter.UserToUserResponse(user))
+		if err != nil {
+			return err
+		}
+		return repos.Jobs.Enqueue(notification, event)
+	})
+}
+
+func (u *userUsecase) Delete(id int64, actor domain.Actor) error {
+	err := u.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		before, err := repos.Users.GetByID(id)
+		if err != nil {
+			return errors.ErrUserNotFound
+		}
+		if err := repos.Users.Delete(id); err != nil {
+			return err
+		}
+		return recordAudit(repos, actor, domain.AuditActionDelete, domain.AuditEntityUser, id, converter.UserToUserResponse(before), nil)
+	})
+	switch err {
+	case nil:
+	case errors.ErrUserNotFound:
+		u.logger.Warn("Deleting non-existent user", zap.Int64("user_id", id))
+		return errors.NewAppError(err, "User not found", http.StatusNotFound)
+	default:
 		u.logger.Error("Failed to delete user", zap.Error(err), zap.Int64("user_id", id))
 		return errors.NewAppError(err, "Failed to delete user", http.StatusInternalServerError)
 	}
 	u.logger.Info("User deleted successfully", zap.Int64("user_id", id))
 	return nil
 }
+
+func (u *userUsecase) Unlock(id int64, actor domain.Actor) error {
+	err := u.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		before, err := repos.Users.GetByID(id)
+		if err != nil {
+			return errors.ErrUserNotFound
+		}
+		if err := repos.Users.ResetFailedLogins(id); err != nil {
+			return err
+		}
+		after, err := repos.Users.GetByID(id)
+		if err != nil {
+			return err
+		}
+		return recordAudit(repos, actor, domain.AuditActionUpdate, domain.AuditEntityUser, id, converter.UserToUserResponse(before), converter.UserToUserResponse(after))
+	})
+	switch err {
+	case nil:
+	case errors.ErrUserNotFound:
+		u.logger.Warn("Unlocking non-existent user", zap.Int64("user_id", id))
+		return errors.NewAppError(err, "User not found", http.StatusNotFound)
+	default:
+		u.logger.Error("Failed to unlock user", zap.Error(err), zap.Int64("user_id", id))
+		return errors.NewAppError(err, "Failed to unlock user", http.StatusInternalServerError)
+	}
+	u.logger.Info("User unlocked successfully", zap.Int64("user_id", id))
+	return nil
+}
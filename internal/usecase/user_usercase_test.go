@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+)
+
+type fakeRoleRepository struct {
+	domain.RoleRepository
+	permissions []string
+}
+
+func (f *fakeRoleRepository) GetUserPermissions(userID int64) ([]string, error) {
+	return f.permissions, nil
+}
+
+func TestMFARequired(t *testing.T) {
+	tests := []struct {
+		name        string
+		roles       []string
+		permissions []string
+		want        bool
+	}{
+		{name: "admin role", roles: []string{domain.RoleAdmin}, want: true},
+		{name: "operator role", roles: []string{domain.RoleOperator}, want: true},
+		{name: "admin among other roles", roles: []string{domain.RoleUser, domain.RoleAdmin}, want: true},
+		{name: "role:manage through a custom role", roles: []string{"auditor"}, permissions: []string{domain.PermissionRoleManage}, want: true},
+		{name: "buyer", roles: []string{domain.RoleUser}, permissions: []string{domain.PermissionCartManage}},
+		{name: "vendor", roles: []string{domain.RoleVendor}, permissions: []string{domain.PermissionProductWrite}},
+		{name: "no roles"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &domain.User{ID: 7, Roles: tt.roles}
+			got, err := mfaRequired(&fakeRoleRepository{permissions: tt.permissions}, user)
+			if err != nil {
+				t.Fatalf("mfaRequired() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("mfaRequired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+
+	"go.uber.org/zap"
+)
+
+type vendorDocumentUsecase struct {
+	transactor domain.Transactor
+	logger     *zap.Logger
+}
+
+func NewVendorDocumentUsecase(transactor domain.Transactor, logger *zap.Logger) domain.VendorDocumentUsecase {
+	return &vendorDocumentUsecase{transactor: transactor, logger: logger}
+}
+
+// Create implements domain.VendorDocumentUsecase.
+func (v *vendorDocumentUsecase) Create(request domain.VendorDocumentRequest, actor domain.Actor) (*domain.VendorDocument, error) {
+	v.logger.Debug("Create vendor document function called", zap.String("name", request.Name))
+
+	if !request.ExpiresAt.After(time.Now()) {
+		v.logger.Warn("Vendor document has already expired", zap.Time("expires_at", request.ExpiresAt))
+		return nil, errors.NewAppError(errors.ErrInvalidInput, "The document has already expired", http.StatusBadRequest)
+	}
+
+	document := &domain.VendorDocument{VendorID: actor.UserID, Name: request.Name, ExpiresAt: request.ExpiresAt, TenantID: actor.TenantID}
+	err := v.transactor.WithinTransaction(actor.TenantID, func(repos domain.Repositories) error {
+		if err := repos.VendorDocuments.Create(document); err != nil {
+			return err
+		}
+		return recordAudit(repos, actor, domain.AuditActionCreate, domain.AuditEntityVendorDocument, document.ID, nil, document)
+	})
+	if err != nil {
+		v.logger.Error("Failed to create vendor document", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to create vendor document", http.StatusInternalServerError)
+	}
+
+	v.logger.Info("Vendor document created successfully", zap.Int64("vendor_document_id", document.ID), zap.Int64("vendor_id", document.VendorID))
+	return document, nil
+}
+
+// RemindExpiring implements domain.VendorDocumentUsecase. A document is
+// claimed and its reminder queued in the same transaction, so each vendor
+// is reminded once per document.
+func (v *vendorDocumentUsecase) RemindExpiring(within time.Duration) (int, error) {
+	const batchSize = 100
+
+	expiresBefore := time.Now().Add(within)
+	reminded := 0
+	for {
+		var documents []domain.VendorDocument
+		err := v.transactor.WithinSystemTransaction(func(repos domain.Repositories) error {
+			var err error
+			if documents, err = repos.VendorDocuments.ClaimExpiring(expiresBefore, batchSize); err != nil {
+				return err
+			}
+			jobs := make([]*domain.Job, 0, len(documents))
+			for _, document := range documents {
+				notification, err := domain.NewNotificationJob(document.VendorID, domain.NotificationVendorDocumentExpiring, map[string]interface{}{
+					"DocumentID":   document.ID,
+					"DocumentName": document.Name,
+					"ExpiresAt":    document.ExpiresAt.UTC().Format(time.RFC1123),
+				})
+				if err != nil {
+					return err
+				}
+				jobs = append(jobs, notification)
+			}
+			if len(jobs) == 0 {
+				return nil
+			}
+			return repos.Jobs.Enqueue(jobs...)
+		})
+		if err != nil {
+			v.logger.Error("Failed to remind vendors of expiring documents", zap.Error(err))
+			return reminded, errors.NewAppError(err, "Failed to remind vendors of expiring documents", http.StatusInternalServerError)
+		}
+		reminded += len(documents)
+		for _, document := range documents {
+			v.logger.Info("Vendor reminded of expiring document", zap.Int64("vendor_document_id", document.ID), zap.Int64("vendor_id", document.VendorID))
+		}
+		if len(documents) < batchSize {
+			return reminded, nil
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"go.uber.org/zap"
+)
+
+func TestVendorDocumentUsecaseCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		wantCode  int
+	}{
+		{name: "valid document", expiresAt: time.Now().Add(30 * 24 * time.Hour)},
+		{name: "expired document", expiresAt: time.Now().Add(-time.Hour), wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactor := newFakeTransactor()
+			u := NewVendorDocumentUsecase(transactor, zap.NewNop())
+
+			document, err := u.Create(domain.VendorDocumentRequest{Name: "Insurance", ExpiresAt: tt.expiresAt}, domain.Actor{UserID: 7, TenantID: 1})
+			if tt.wantCode != 0 {
+				if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != tt.wantCode {
+					t.Fatalf("Create() error = %v, want code %d", err, tt.wantCode)
+				}
+				if len(transactor.vendorDocuments.documents) != 0 || len(transactor.audit.events) != 0 {
+					t.Errorf("stored %d documents and %d audit events, want none", len(transactor.vendorDocuments.documents), len(transactor.audit.events))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			stored := transactor.vendorDocuments.documents[document.ID]
+			if stored == nil || stored.VendorID != 7 || stored.TenantID != 1 {
+				t.Errorf("stored document = %+v, want a document of vendor 7 in tenant 1", stored)
+			}
+			if len(transactor.audit.events) != 1 || transactor.audit.events[0].EntityType != domain.AuditEntityVendorDocument {
+				t.Errorf("audit events = %d, want one for the document", len(transactor.audit.events))
+			}
+		})
+	}
+}
+
+func TestVendorDocumentUsecaseRemindExpiring(t *testing.T) {
+	day := 24 * time.Hour
+	reminded := time.Now().Add(-day)
+	transactor := newFakeTransactor()
+	transactor.vendorDocuments.documents[1] = &domain.VendorDocument{ID: 1, TenantID: 1, VendorID: 7, Name: "Insurance", ExpiresAt: time.Now().Add(2 * day)}
+	transactor.vendorDocuments.documents[2] = &domain.VendorDocument{ID: 2, TenantID: 2, VendorID: 20, Name: "Licence", ExpiresAt: time.Now().Add(3 * day)}
+	transactor.vendorDocuments.documents[3] = &domain.VendorDocument{ID: 3, TenantID: 1, VendorID: 7, Name: "Certificate", ExpiresAt: time.Now().Add(30 * day)}
+	transactor.vendorDocuments.documents[4] = &domain.VendorDocument{ID: 4, TenantID: 1, VendorID: 8, Name: "Permit", ExpiresAt: time.Now().Add(day), RemindedAt: &reminded}
+	u := NewVendorDocumentUsecase(transactor, zap.NewNop())
+
+	count, err := u.RemindExpiring(7 * day)
+	if err != nil {
+		t.Fatalf("RemindExpiring() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("reminded = %d, want 2", count)
+	}
+	notifications := notificationJobs(t, transactor.jobs.jobs)
+	if got := recipients(notifications); !slices.Equal(got, []int64{7, 20}) {
+		t.Errorf("notified %v, want [7 20]", got)
+	}
+	for _, notification := range notifications {
+		if notification.Event != domain.NotificationVendorDocumentExpiring {
+			t.Errorf("notification event = %s, want %s", notification.Event, domain.NotificationVendorDocumentExpiring)
+		}
+	}
+
+	// Each vendor is reminded once per document.
+	if count, err := u.RemindExpiring(7 * day); err != nil || count != 0 {
+		t.Errorf("second RemindExpiring() = %d, %v, want 0, nil", count, err)
+	}
+}
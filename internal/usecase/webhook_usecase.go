@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/errors"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/pagination"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/webhook"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// A delivery is tried webhookMaxAttempts times, waiting twice as long
+	// after each failure, which spreads the retries over about an hour.
+	webhookMaxAttempts = 8
+	webhookBaseBackoff = 30 * time.Second
+	webhookMaxBackoff  = 6 * time.Hour
+	// webhookLease must outlast sending a whole batch.
+	webhookBatchSize = 20
+	webhookLease     = 5 * time.Minute
+)
+
+type webhookUsecase struct {
+	webhookRepo domain.WebhookRepository
+	sender      *webhook.Sender
+	logger      *zap.Logger
+}
+
+func NewWebhookUsecase(webhookRepo domain.WebhookRepository, sender *webhook.Sender, logger *zap.Logger) domain.WebhookUsecase {
+	return &webhookUsecase{webhookRepo: webhookRepo, sender: sender, logger: logger}
+}
+
+// Publish implements domain.WebhookUsecase. A delivery of the event's
+// envelope is queued for every active subscription to it; the dispatcher
+// sends them in the background.
+func (w *webhookUsecase) Publish(job domain.WebhookEventJob) error {
+	count, err := w.webhookRepo.Enqueue(job.Event, job.Envelope)
+	if err != nil {
+		w.logger.Error("Failed to enqueue webhook deliveries", zap.Error(err), zap.String("event", job.Event))
+		return err
+	}
+
+	w.logger.Debug("Webhook event published", zap.String("event", job.Event), zap.Int64("deliveries", count))
+	return nil
+}
+
+// CreateSubscription implements domain.WebhookUsecase. The generated signing
+// secret is returned once.
+func (w *webhookUsecase) CreateSubscription(request domain.WebhookSubscriptionRequest, createdBy int64) (*domain.WebhookSubscription, error) {
+	w.logger.Debug("CreateSubscription function called", zap.String("url", request.URL))
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		w.logger.Error("Failed to generate webhook secret", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to create webhook", http.StatusInternalServerError)
+	}
+
+	subscription := &domain.WebhookSubscription{
+		URL:       request.URL,
+		Events:    request.Events,
+		Active:    request.Active == nil || *request.Active,
+		Secret:    secret,
+		CreatedBy: &createdBy,
+	}
+	if err := w.webhookRepo.CreateSubscription(subscription); err != nil {
+		w.logger.Error("Failed to create webhook", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to create webhook", http.StatusInternalServerError)
+	}
+
+	w.logger.Info("Webhook created successfully", zap.Int64("webhook_id", subscription.ID), zap.Strings("events", subscription.Events))
+	return subscription, nil
+}
+
+// GetSubscription implements domain.WebhookUsecase.
+func (w *webhookUsecase) GetSubscription(id int64) (*domain.WebhookSubscription, error) {
+	subscription, err := w.webhookRepo.GetSubscriptionByID(id)
+	if err != nil {
+		w.logger.Warn("Failed to get webhook", zap.Error(err), zap.Int64("webhook_id", id))
+		return nil, errors.NewAppError(errors.ErrWebhookNotFound, "Webhook not found", http.StatusNotFound)
+	}
+
+	subscription.Secret = ""
+	return subscription, nil
+}
+
+// GetSubscriptions implements domain.WebhookUsecase.
+func (w *webhookUsecase) GetSubscriptions(page pagination.Params) (*pagination.Page[domain.WebhookSubscription], error) {
+	page = page.Normalize()
+
+	afterID, err := decodeIDCursor(page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := w.webhookRepo.GetSubscriptions(afterID, page.Limit+1)
+	if err != nil {
+		w.logger.Error("Failed to get webhooks", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to get webhooks", http.StatusInternalServerError)
+	}
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+
+	total, err := w.webhookRepo.CountSubscriptions()
+	if err != nil {
+		w.logger.Error("Failed to count webhooks", zap.Error(err))
+		return nil, errors.NewAppError(err, "Failed to count webhooks", http.StatusInternalServerError)
+	}
+
+	result, err := pagination.NewPage(subscriptions, page.Limit, total, func(subscription domain.WebhookSubscription) interface{} {
+		return pagination.IDCursor{ID: subscription.ID}
+	})
+	if err != nil {
+		return nil, errors.NewAppError(err, "Failed to get webhooks", http.StatusInternalServerError)
+	}
+
+	return result, nil
+}
+
+// UpdateSubscription implements domain.WebhookUsecase. The secret is kept.
+func (w *webhookUsecase) UpdateSubscription(id int64, request domain.WebhookSubscriptionRequest) (*domain.WebhookSubscription, error) {
+	w.logger.Debug("UpdateSubscription function called", zap.Int64("webhook_id", id))
+
+	subscription := &domain.WebhookSubscription{
+		ID:     id,
+		URL:    request.URL,
+		Events: request.Events,
+		Active: request.Active == nil || *request.Active,
+	}
+	found, err := w.webhookRepo.UpdateSubscription(subscription)
+	if err != nil {
+		w.logger.Error("Failed to update webhook", zap.Error(err), zap.Int64("webhook_id", id))
+		return nil, errors.NewAppError(err, "Failed to update webhook", http.StatusInternalServerError)
+	}
+	if !found {
+		return nil, errors.NewAppError(errors.ErrWebhookNotFound, "Webhook not found", http.StatusNotFound)
+	}
+
+	w.logger.Info("Webhook updated successfully", zap.Int64("webhook_id", id))
+	return w.GetSubscription(id)
+}
+
+// DeleteSubscription implements domain.WebhookUsecase.
+func (w *webhookUsecase) DeleteSubscription(id int64) error {
+	found, err := w.webhookRepo.DeleteSubscription(id)
+	if err != nil {
+		w.logger.Error("Failed to delete webhook", zap.Error(err), zap.Int64("webhook_id", id))
+		return errors.NewAppError(err, "Failed to delete webhook", http.StatusInternalServerError)
+	}
+	if !found {
+		return errors.NewAppError(errors.ErrWebhookNotFound, "Webhook not found", http.StatusNotFound)
+	}
+
+	w.logger.Info("Webhook deleted successfully", zap.Int64("webhook_id", id))
+	return nil
+}
+
+// GetDeliveries implements domain.WebhookUsecase.
+func (w *webhookUsecase) GetDeliveries(subscriptionID int64, page pagination.Params) (*pagination.Page[domain.WebhookDelivery], error) {
+	if _, err := w.GetSubscription(subscriptionID); err != nil {
+		return nil, err
+	}
+
+	page = page.Normalize()
+
+	afterID, err := decodeIDCursor(page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := w.webhookRepo.GetDeliveries(subscriptionID, afterID, page.Limit+1)
+	if err != nil {
+		w.logger.Error("Failed to get webhook deliveries", zap.Error(err), zap.Int64("webhook_id", subscriptionID))
+		return nil, errors.NewAppError(err, "Failed to get webhook deliveries", http.StatusInternalServerError)
+	}
+
+	total, err := w.webhookRepo.CountDeliveries(subscriptionID)
+	if err != nil {
+		w.logger.Error("Failed to count webhook deliveries", zap.Error(err), zap.Int64("webhook_id", subscriptionID))
+		return nil, errors.NewAppError(err, "Failed to count webhook deliveries", http.StatusInternalServerError)
+	}
+
+	result, err := pagination.NewPage(deliveries, page.Limit, total, func(delivery domain.WebhookDelivery) interface{} {
+		return pagination.IDCursor{ID: delivery.ID}
+	})
+	if err != nil {
+		return nil, errors.NewAppError(err, "Failed to get webhook deliveries", http.StatusInternalServerError)
+	}
+
+	return result, nil
+}
+
+// Redeliver implements domain.WebhookUsecase. The same payload is queued
+// again as a new delivery.
+func (w *webhookUsecase) Redeliver(deliveryID int64) (*domain.WebhookDelivery, error) {
+	w.logger.Debug("Redeliver function called", zap.Int64("delivery_id", deliveryID))
+
+	if _, err := w.webhookRepo.GetDeliveryByID(deliveryID); err != nil {
+		w.logger.Warn("Failed to get webhook delivery", zap.Error(err), zap.Int64("delivery_id", deliveryID))
+		return nil, errors.NewAppError(errors.ErrWebhookDeliveryNotFound, "Webhook delivery not found", http.StatusNotFound)
+	}
+
+	delivery, err := w.webhookRepo.Redeliver(deliveryID)
+	if err != nil {
+		w.logger.Error("Failed to redeliver webhook", zap.Error(err), zap.Int64("delivery_id", deliveryID))
+		return nil, errors.NewAppError(err, "Failed to redeliver webhook", http.StatusInternalServerError)
+	}
+
+	w.logger.Info("Webhook redelivery queued", zap.Int64("delivery_id", deliveryID), zap.Int64("redelivery_id", delivery.ID))
+	return delivery, nil
+}
+
+// DispatchDue implements domain.WebhookUsecase.
+func (w *webhookUsecase) DispatchDue() (int, error) {
+	deliveries, err := w.webhookRepo.ClaimDue(webhookBatchSize, webhookLease)
+	if err != nil {
+		w.logger.Error("Failed to claim webhook deliveries", zap.Error(err))
+		return 0, errors.NewAppError(err, "Failed to claim webhook deliveries", http.StatusInternalServerError)
+	}
+
+	subscriptions := map[int64]*domain.WebhookSubscription{}
+	for _, delivery := range deliveries {
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			if subscription, err = w.webhookRepo.GetSubscriptionByID(delivery.SubscriptionID); err != nil {
+				w.logger.Error("Failed to get webhook", zap.Error(err), zap.Int64("webhook_id", delivery.SubscriptionID))
+				continue
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		attempt := w.attempt(subscription, delivery)
+		if err := w.webhookRepo.RecordAttempt(delivery.ID, attempt); err != nil {
+			w.logger.Error("Failed to record webhook attempt", zap.Error(err), zap.Int64("delivery_id", delivery.ID))
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// attempt sends one delivery and works out its next state.
+func (w *webhookUsecase) attempt(subscription *domain.WebhookSubscription, delivery domain.WebhookDelivery) domain.WebhookAttempt {
+	now := time.Now()
+	attempts := delivery.Attempts + 1
+
+	response, err := w.sender.Send(webhook.Request{
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		Event:      delivery.EventType,
+		DeliveryID: delivery.ID,
+		Body:       delivery.Payload,
+	})
+
+	var attempt domain.WebhookAttempt
+	if response != nil && response.StatusCode != 0 {
+		attempt.StatusCode = &response.StatusCode
+	}
+	switch {
+	case err != nil:
+		attempt.Error = err.Error()
+	case !response.Succeeded():
+		attempt.Error = fmt.Sprintf("unexpected status %d: %s", response.StatusCode, response.Body)
+	default:
+		w.logger.Info("Webhook delivered", zap.Int64("delivery_id", delivery.ID), zap.String("event", delivery.EventType))
+		attempt.Status = domain.WebhookDeliverySucceeded
+		attempt.NextAttemptAt = now
+		attempt.DeliveredAt = &now
+		return attempt
+	}
+
+	if attempts >= webhookMaxAttempts {
+		w.logger.Warn("Webhook delivery failed permanently", zap.Int64("delivery_id", delivery.ID), zap.Int("attempts", attempts), zap.String("error", attempt.Error))
+		attempt.Status = domain.WebhookDeliveryFailed
+		attempt.NextAttemptAt = now
+		return attempt
+	}
+
+	w.logger.Warn("Webhook delivery failed, will retry", zap.Int64("delivery_id", delivery.ID), zap.Int("attempts", attempts), zap.String("error", attempt.Error))
+	attempt.Status = domain.WebhookDeliveryPending
+	attempt.NextAttemptAt = now.Add(webhookBackoff(attempts))
+	return attempt
+}
+
+// webhookBackoff is the wait before the next try after the given number of
+// failed attempts.
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff << (attempts - 1)
+	if backoff <= 0 || backoff > webhookMaxBackoff {
+		return webhookMaxBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,197 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// pollInterval is how long an idle worker waits before looking for
+	// due jobs again.
+	pollInterval = time.Second
+	// jobLease must outlast any single job. A job whose worker dies is
+	// claimed again once its lease runs out.
+	jobLease = 5 * time.Minute
+	// A failed job is retried after baseBackoff, waiting twice as long after
+	// each further failure, up to maxBackoff.
+	baseBackoff = 10 * time.Second
+	maxBackoff  = time.Hour
+)
+
+// Handler runs one job. Returning an error schedules a retry, or dead-letters
+// the job when it is a *domain.PermanentJobError or the job is out of
+// attempts.
+type Handler func(job *domain.Job) error
+
+// Typed adapts fn to a Handler that decodes the job payload into T. Numbers
+// in untyped fields decode as json.Number so IDs keep their formatting. A
+// payload that does not decode is a permanent failure.
+func Typed[T any](fn func(payload T) error) Handler {
+	return func(job *domain.Job) error {
+		var payload T
+		decoder := json.NewDecoder(bytes.NewReader(job.Payload))
+		decoder.UseNumber()
+		if err := decoder.Decode(&payload); err != nil {
+			return &domain.PermanentJobError{Err: fmt.Errorf("decode %s payload: %w", job.Type, err)}
+		}
+		return fn(payload)
+	}
+}
+
+// Pool runs outbox jobs on a fixed number of workers, each claiming and
+// running one job at a time.
+type Pool struct {
+	jobRepo  domain.JobRepository
+	workers  int
+	handlers map[string]Handler
+	logger   *zap.Logger
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewPool(jobRepo domain.JobRepository, workers int, logger *zap.Logger) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Pool{
+		jobRepo:  jobRepo,
+		workers:  workers,
+		handlers: make(map[string]Handler),
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Register sets the handler for jobType. It must be called before Start.
+func (p *Pool) Register(jobType string, handler Handler) {
+	p.handlers[jobType] = handler
+}
+
+// Start launches the workers.
+func (p *Pool) Start() {
+	p.logger.Info("Starting worker pool", zap.Int("workers", p.workers))
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+}
+
+// Shutdown stops claiming jobs and waits for the running ones to finish,
+// or for ctx to be done. Jobs still running when ctx is done are claimed
+// again after their lease runs out.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	close(p.stop)
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.logger.Info("Worker pool stopped")
+		return nil
+	case <-ctx.Done():
+		p.logger.Warn("Worker pool did not stop in time", zap.Error(ctx.Err()))
+		return ctx.Err()
+	}
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		default:
+		}
+
+		job, err := p.jobRepo.Claim(jobLease)
+		if err != nil {
+			p.logger.Error("Failed to claim job", zap.Error(err))
+		}
+		if job == nil {
+			select {
+			case <-p.stop:
+				return
+			case <-time.After(pollInterval):
+			}
+			continue
+		}
+
+		p.run(job)
+	}
+}
+
+func (p *Pool) run(job *domain.Job) {
+	logger := p.logger.With(zap.Int64("job_id", job.ID), zap.String("job_type", job.Type), zap.Int("attempt", job.Attempts))
+
+	handler, ok := p.handlers[job.Type]
+	if !ok {
+		p.fail(logger, job, &domain.PermanentJobError{Err: fmt.Errorf("no handler for job type %q", job.Type)})
+		return
+	}
+
+	if err := p.call(handler, job); err != nil {
+		p.fail(logger, job, err)
+		return
+	}
+
+	if err := p.jobRepo.Complete(job.ID); err != nil {
+		logger.Error("Failed to mark job succeeded", zap.Error(err))
+		return
+	}
+	logger.Debug("Job succeeded")
+}
+
+// call runs handler, turning a panic into an error so one bad job does not
+// take the worker down.
+func (p *Pool) call(handler Handler, job *domain.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(job)
+}
+
+func (p *Pool) fail(logger *zap.Logger, job *domain.Job, jobErr error) {
+	var permanent *domain.PermanentJobError
+	if errors.As(jobErr, &permanent) || job.Attempts >= job.MaxAttempts {
+		if err := p.jobRepo.Fail(job.ID, domain.JobStatusDead, time.Now(), jobErr.Error()); err != nil {
+			logger.Error("Failed to dead-letter job", zap.Error(err))
+			return
+		}
+		logger.Error("Job dead-lettered", zap.Error(jobErr))
+		return
+	}
+
+	runAt := time.Now().Add(backoff(job.Attempts))
+	if err := p.jobRepo.Fail(job.ID, domain.JobStatusPending, runAt, jobErr.Error()); err != nil {
+		logger.Error("Failed to reschedule job", zap.Error(err))
+		return
+	}
+	logger.Warn("Job failed, retrying", zap.Error(jobErr), zap.Time("run_at", runAt))
+}
+
+// backoff is the wait before the next try after the given number of failed
+// attempts.
+func backoff(attempts int) time.Duration {
+	delay := baseBackoff << (attempts - 1)
+	if delay <= 0 || delay > maxBackoff {
+		return maxBackoff
+	}
+	return delay
+}
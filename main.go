@@ -2,16 +2,32 @@ package main
 
 import (
 	"context"
+	"crypto"
+	"crypto/ed25519"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/zulfikarmuzakir/e_procurement/config"
 	"github.com/zulfikarmuzakir/e_procurement/internal/app"
 	"github.com/zulfikarmuzakir/e_procurement/internal/delivery/http/router"
+	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/internal/repository/file"
+	"github.com/zulfikarmuzakir/e_procurement/internal/repository/memory"
 	"github.com/zulfikarmuzakir/e_procurement/internal/repository/postgres"
+	"github.com/zulfikarmuzakir/e_procurement/internal/scheduler"
 	"github.com/zulfikarmuzakir/e_procurement/internal/usecase"
+	"github.com/zulfikarmuzakir/e_procurement/internal/worker"
 	"github.com/zulfikarmuzakir/e_procurement/pkg/auth"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/keys"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/mailer"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/secretbox"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/signedtoken"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/webhook"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
@@ -39,21 +55,249 @@ func main() {
 	}
 	defer db.Close()
 
-	jwtAuth := auth.NewJWTAuth(cfg.JWTSecret, cfg.JWTSecret)
+	jwtAuth := auth.NewJWTAuth(cfg.JWTSecret, cfg.AccessTokenTTL)
+	if cfg.JWTSigningKey != "" {
+		signingKey, err := keys.LoadPrivateKey(cfg.JWTSigningKey)
+		if err != nil {
+			logger.Fatal("Failed to load JWT signing key", zap.Error(err))
+		}
+		var verificationKeys []crypto.PublicKey
+		for _, path := range cfg.JWTVerificationKeys {
+			key, err := keys.LoadPublicKey(path)
+			if err != nil {
+				logger.Fatal("Failed to load JWT verification key", zap.Error(err))
+			}
+			verificationKeys = append(verificationKeys, key)
+		}
+		jwtAuth, err = auth.NewJWTAuthWithKeys(cfg.AccessTokenTTL, signingKey, verificationKeys...)
+		if err != nil {
+			logger.Fatal("Failed to initialize JWT keys", zap.Error(err))
+		}
+	} else {
+		logger.Warn("JWT_SIGNING_KEY is not set, access tokens are signed with JWT_SECRET and no public keys are published")
+	}
+
+	var smtpMailer mailer.Mailer
+	if cfg.SMTPHost != "" {
+		smtpMailer = mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+	} else {
+		logger.Warn("SMTP_HOST is not set, email notifications are disabled")
+	}
+
+	var checkpointKey ed25519.PrivateKey
+	if cfg.AuditCheckpointKey != "" {
+		signer, err := keys.LoadPrivateKey(cfg.AuditCheckpointKey)
+		if err != nil {
+			logger.Fatal("Failed to load audit checkpoint key", zap.Error(err))
+		}
+		var ok bool
+		if checkpointKey, ok = signer.(ed25519.PrivateKey); !ok {
+			logger.Fatal("Audit checkpoint key must be an Ed25519 key", zap.String("path", cfg.AuditCheckpointKey))
+		}
+	} else {
+		logger.Warn("AUDIT_CHECKPOINT_KEY is not set, audit checkpoints are disabled")
+	}
+
+	var rateLimitStore domain.RateLimitStore
+	switch cfg.RateLimitStore {
+	case "memory":
+		rateLimitStore = memory.NewRateLimitStore()
+	case "postgres":
+		rateLimitStore = postgres.NewRateLimitStore(db)
+	default:
+		logger.Fatal("RATE_LIMIT_STORE must be memory or postgres", zap.String("store", cfg.RateLimitStore))
+	}
+	parseRateLimit := func(name, value string) domain.RateLimit {
+		limit, err := domain.ParseRateLimit(value)
+		if err != nil {
+			logger.Fatal("Invalid rate limit", zap.String("name", name), zap.Error(err))
+		}
+		return limit
+	}
+	rateLimits := domain.RateLimits{
+		Public:   parseRateLimit("RATE_LIMIT_PUBLIC", cfg.RateLimitPublic),
+		Login:    parseRateLimit("RATE_LIMIT_LOGIN", cfg.RateLimitLogin),
+		Register: parseRateLimit("RATE_LIMIT_REGISTER", cfg.RateLimitRegister),
+		User:     parseRateLimit("RATE_LIMIT_USER", cfg.RateLimitUser),
+	}
+
+	transactor := postgres.NewTransactor(db)
+
+	jobRepo := postgres.NewJobRepository(db)
+	jobUsecase := usecase.NewJobUsecase(jobRepo, logger)
+
+	auditRepo := postgres.NewAuditRepository(db)
+	auditUsecase, err := usecase.NewAuditUsecase(auditRepo, checkpointKey, file.NewAuditCheckpointExporter(cfg.AuditCheckpointFile), logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize audit usecase", zap.Error(err))
+	}
 
 	userRepo := postgres.NewUserRepository(db)
-	userUsecase := usecase.NewUserUsecase(userRepo, jwtAuth, logger)
+
+	notificationRepo := postgres.NewNotificationRepository(db)
+	notificationUsecase := usecase.NewNotificationUsecase(notificationRepo, userRepo, transactor, smtpMailer, logger)
+
+	webhookRepo := postgres.NewWebhookRepository(db)
+	webhookUsecase := usecase.NewWebhookUsecase(webhookRepo, webhook.NewSender(10*time.Second), logger)
+
+	// TOTP secrets get a key of their own, so the token signing key does
+	// not decrypt them.
+	if cfg.MFAEncryptionKey == "" {
+		logger.Fatal("MFA_ENCRYPTION_KEY is not set")
+	}
+	if cfg.MFAEncryptionKey == cfg.JWTSecret {
+		logger.Fatal("MFA_ENCRYPTION_KEY must differ from JWT_SECRET")
+	}
+	mfaBox, err := secretbox.New(cfg.MFAEncryptionKey)
+	if err != nil {
+		logger.Fatal("Failed to initialize MFA encryption", zap.Error(err))
+	}
+	mfaRepo := postgres.NewMFARepository(db)
+
+	sessionRepo := postgres.NewSessionRepository(db)
+	sessionUsecase := usecase.NewSessionUsecase(userRepo, sessionRepo, transactor, jwtAuth, cfg.RefreshTokenTTL, logger)
+
+	loginAttemptRepo := postgres.NewLoginAttemptRepository(db)
+	loginAttemptUsecase := usecase.NewLoginAttemptUsecase(loginAttemptRepo, logger)
+
+	roleRepo := postgres.NewRoleRepository(db)
+	roleUsecase := usecase.NewRoleUsecase(roleRepo, userRepo, transactor, logger)
+
+	userUsecase := usecase.NewUserUsecase(userRepo, mfaRepo, loginAttemptRepo, roleRepo, transactor, sessionUsecase, logger)
+	mfaUsecase := usecase.NewMFAUsecase(userRepo, mfaRepo, roleRepo, transactor, mfaBox, cfg.MFAIssuer, sessionUsecase, logger)
+
+	// Without a secret of its own, the link key is derived from JWT_SECRET
+	// rather than reusing it, so a link signature is never a valid token
+	// signature or the other way round.
+	verificationSecret := []byte(cfg.EmailVerificationSecret)
+	if cfg.EmailVerificationSecret == "" {
+		verificationSecret = signedtoken.DeriveKey(cfg.JWTSecret, "e_procurement email verification")
+	}
+	emailVerificationUsecase := usecase.NewEmailVerificationUsecase(userRepo, jobRepo, transactor, verificationSecret, cfg.EmailVerificationURL, cfg.EmailVerificationTTL, logger)
+
+	passwordResetRepo := postgres.NewPasswordResetRepository(db)
+	passwordResetUsecase := usecase.NewPasswordResetUsecase(userRepo, passwordResetRepo, transactor, cfg.PasswordResetTTL, cfg.PasswordResetURL, logger)
 
 	productRepo := postgres.NewProductRepository(db)
-	productUsecase := usecase.NewProductUsecase(productRepo, logger)
+	productUsecase := usecase.NewProductUsecase(productRepo, transactor, logger)
+
+	cartRepo := postgres.NewCartRepository(db)
+	cartUsecase := usecase.NewCartUsecase(cartRepo, productRepo, transactor, cfg.ReservationTTL, logger)
+
+	organizationRepo := postgres.NewOrganizationRepository(db)
+	organizationUsecase := usecase.NewOrganizationUsecase(organizationRepo, userRepo, transactor, logger)
+
+	purchaseOrderRepo := postgres.NewPurchaseOrderRepository(db)
+	purchaseOrderUsecase := usecase.NewPurchaseOrderUsecase(purchaseOrderRepo, organizationRepo, transactor, logger)
+	rfqUsecase := usecase.NewRFQUsecase(transactor, logger)
+	approvalUsecase := usecase.NewApprovalUsecase(transactor, logger)
+	vendorDocumentUsecase := usecase.NewVendorDocumentUsecase(transactor, logger)
+
+	inventoryRepo := postgres.NewInventoryRepository(db)
+	inventoryUsecase := usecase.NewInventoryUsecase(inventoryRepo, productRepo, logger)
+
+	stockAlertRepo := postgres.NewStockAlertRepository(db)
+	stockAlertUsecase := usecase.NewStockAlertUsecase(stockAlertRepo, productRepo, logger)
 
-	app := app.NewApp(userUsecase, productUsecase, jwtAuth, logger)
+	tenantRepo := postgres.NewTenantRepository(db)
+	tenantUsecase := usecase.NewTenantUsecase(tenantRepo, logger)
+
+	workers := worker.NewPool(jobRepo, cfg.WorkerCount, logger)
+	workers.Register(domain.JobNotificationDeliver, worker.Typed(notificationUsecase.Deliver))
+	workers.Register(domain.JobEmailSend, worker.Typed(notificationUsecase.SendEmail))
+	workers.Register(domain.JobEmailVerification, worker.Typed(emailVerificationUsecase.SendVerificationEmail))
+	workers.Register(domain.JobWebhookPublish, worker.Typed(webhookUsecase.Publish))
+	workers.Start()
+
+	// Time-driven rules. Each runs on one replica at a time.
+	sched := scheduler.New(postgres.NewLeaderLock(db), logger)
+	// Release the stock held by pending purchase orders that were never
+	// confirmed.
+	sched.Add("expire-reservations", scheduler.Every(time.Minute), func() error {
+		_, err := purchaseOrderUsecase.ExpirePending()
+		return err
+	})
+	// Close requests for quotation at their deadline.
+	sched.Add("close-rfqs", scheduler.Every(time.Minute), func() error {
+		_, err := rfqUsecase.CloseDue()
+		return err
+	})
+	// Escalate approvals left idle to the organization managers.
+	sched.Add("escalate-approvals", scheduler.Every(time.Hour), func() error {
+		_, err := approvalUsecase.EscalateIdle(cfg.ApprovalEscalationAfter)
+		return err
+	})
+	// Remind vendors of documents about to expire.
+	sched.Add("remind-vendor-documents", scheduler.Daily(8, 0), func() error {
+		_, err := vendorDocumentUsecase.RemindExpiring(cfg.VendorDocumentReminder)
+		return err
+	})
+	// Send queued webhook deliveries, including retries that are due.
+	sched.Add("dispatch-webhooks", scheduler.Every(5*time.Second), func() error {
+		_, err := webhookUsecase.DispatchDue()
+		return err
+	})
+	sched.Add("prune-jobs", scheduler.Daily(3, 0), func() error {
+		_, err := jobUsecase.Prune(cfg.JobRetention)
+		return err
+	})
+	sched.Add("prune-password-reset-tokens", scheduler.Daily(3, 10), func() error {
+		_, err := passwordResetUsecase.Prune()
+		return err
+	})
+	sched.Add("prune-sessions", scheduler.Daily(3, 20), func() error {
+		_, err := sessionUsecase.Prune()
+		return err
+	})
+	sched.Add("prune-login-attempts", scheduler.Daily(3, 30), func() error {
+		_, err := loginAttemptUsecase.Prune(cfg.LoginAttemptRetention)
+		return err
+	})
+	sched.Add("prune-mfa-challenges", scheduler.Every(time.Hour), func() error {
+		_, err := mfaUsecase.Prune()
+		return err
+	})
+	// Buckets in memory are pruned as they are used.
+	if cfg.RateLimitStore == "postgres" {
+		sched.Add("prune-rate-limits", scheduler.Every(10*time.Minute), func() error {
+			_, err := rateLimitStore.Prune(time.Now())
+			return err
+		})
+	}
+	// Sign the head of the audit chain so later edits can be proven.
+	if checkpointKey != nil {
+		sched.Add("audit-checkpoint", scheduler.Every(cfg.AuditCheckpointInterval), func() error {
+			_, err := auditUsecase.CreateCheckpoint()
+			return err
+		})
+	}
+	sched.Start()
+
+	app := app.NewApp(userUsecase, productUsecase, cartUsecase, purchaseOrderUsecase, inventoryUsecase, stockAlertUsecase, notificationUsecase, webhookUsecase, jobUsecase, auditUsecase, passwordResetUsecase, emailVerificationUsecase, mfaUsecase, sessionUsecase, loginAttemptUsecase, roleUsecase, organizationUsecase, tenantUsecase, rfqUsecase, approvalUsecase, vendorDocumentUsecase, rateLimitStore, rateLimits, workers, sched, jwtAuth, logger)
 
 	r := router.SetupRouter(app)
 
-	logger.Info("Starting server", zap.String("port", "8080"))
-	err = http.ListenAndServe(":8080", r)
-	if err != nil {
-		logger.Fatal("Failed to start server: %v", zap.Error(err))
+	srv := &http.Server{Addr: ":8080", Handler: r}
+	go func() {
+		logger.Info("Starting server", zap.String("port", "8080"))
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Fatal("Failed to start server: %v", zap.Error(err))
+		}
+	}()
+
+	// Stop on SIGINT/SIGTERM: finish in-flight requests first, then let the
+	// scheduler and workers finish their running jobs.
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Error("Failed to shut down server", zap.Error(err))
+	}
+	if err := app.Shutdown(ctx); err != nil {
+		logger.Error("Failed to shut down application", zap.Error(err))
 	}
 }
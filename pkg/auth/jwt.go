@@ -1,16 +1,38 @@
 package auth
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/zulfikarmuzakir/e_procurement/internal/domain"
+	"github.com/zulfikarmuzakir/e_procurement/pkg/keys"
 )
 
+// JWTAuth signs and checks access tokens. Refresh tokens are opaque and
+// kept as sessions in the database.
+//
+// Tokens are signed either with a shared HS256 secret, or with an RSA
+// (RS256) or Ed25519 (EdDSA) private key. Asymmetric tokens carry the
+// signing key's ID in the kid header, and are accepted when signed by any
+// of the verification keys, so a new signing key can be rolled out while
+// tokens signed by the old one are still valid.
 type JWTAuth struct {
-	accessSecret  []byte
-	refreshSecret []byte
+	accessTTL  time.Duration
+	method     jwt.SigningMethod
+	signingKey interface{}
+	signingKID string
+	// verificationKeys maps key IDs to public keys. It is empty for HS256.
+	verificationKeys map[string]verificationKey
+}
+
+type verificationKey struct {
+	method jwt.SigningMethod
+	key    crypto.PublicKey
 }
 
 var (
@@ -18,10 +40,61 @@ var (
 	ErrExpiredToken = errors.New("expired token")
 )
 
-func NewJWTAuth(accessSecret, refreshSecret string) *JWTAuth {
+// NewJWTAuth signs and verifies tokens with HS256 and accessSecret. Other
+// services can only verify such tokens by holding the secret; use
+// NewJWTAuthWithKeys to publish public keys instead. Tokens expire after
+// accessTTL.
+func NewJWTAuth(accessSecret string, accessTTL time.Duration) *JWTAuth {
 	return &JWTAuth{
-		accessSecret:  []byte(accessSecret),
-		refreshSecret: []byte(refreshSecret),
+		accessTTL:  accessTTL,
+		method:     jwt.SigningMethodHS256,
+		signingKey: []byte(accessSecret),
+	}
+}
+
+// NewJWTAuthWithKeys signs tokens with signingKey, which must be an RSA or
+// Ed25519 key, and accepts tokens signed by it or by any of
+// verificationKeys, e.g. the previous signing key during a rotation.
+func NewJWTAuthWithKeys(accessTTL time.Duration, signingKey crypto.Signer, verificationKeys ...crypto.PublicKey) (*JWTAuth, error) {
+	method, err := signingMethod(signingKey.Public())
+	if err != nil {
+		return nil, err
+	}
+	kid, err := keys.ID(signingKey.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	j := &JWTAuth{
+		accessTTL:        accessTTL,
+		method:           method,
+		signingKey:       signingKey,
+		signingKID:       kid,
+		verificationKeys: make(map[string]verificationKey),
+	}
+	for _, key := range append([]crypto.PublicKey{signingKey.Public()}, verificationKeys...) {
+		method, err := signingMethod(key)
+		if err != nil {
+			return nil, err
+		}
+		kid, err := keys.ID(key)
+		if err != nil {
+			return nil, err
+		}
+		j.verificationKeys[kid] = verificationKey{method: method, key: key}
+	}
+	return j, nil
+}
+
+// signingMethod returns the JWT algorithm used with a public key.
+func signingMethod(key crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported JWT key type %T, use an RSA or Ed25519 key", key)
 	}
 }
 
@@ -30,63 +103,46 @@ type Claims struct {
 	Name     string
 	Username string
 	Email    string
-	Role     string
-	Status   string
+	// Roles are the names of the user's roles when the token was issued.
+	Roles  []string
+	Status string
+	// SessionID is the session the token was issued for.
+	SessionID int64
+	// TokenVersion is the user's token version when the token was issued.
+	TokenVersion int
+	// TenantID is the user's tenant. It is zero in tokens issued before
+	// tenants existed.
+	TenantID int64
 	jwt.RegisteredClaims
 }
 
-func (j *JWTAuth) GenerateAccessToken(user *domain.User) (string, error) {
+func (j *JWTAuth) GenerateAccessToken(user *domain.User, sessionID int64) (string, error) {
 	claims := &Claims{
-		UserID:   user.ID,
-		Name:     user.Name,
-		Username: user.Username,
-		Email:    user.Email,
-		Role:     user.Role,
-		Status:   user.Status,
+		UserID:       user.ID,
+		Name:         user.Name,
+		Username:     user.Username,
+		Email:        user.Email,
+		Roles:        user.Roles,
+		Status:       user.Status,
+		SessionID:    sessionID,
+		TokenVersion: user.TokenVersion,
+		TenantID:     user.TenantID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24 * 7)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.accessTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(j.accessSecret)
-}
-
-func (j *JWTAuth) GenerateRefreshToken(user *domain.User) (string, error) {
-	claims := &Claims{
-		UserID:   user.ID,
-		Name:     user.Name,
-		Username: user.Username,
-		Email:    user.Email,
-		Role:     user.Role,
-		Status:   user.Status,
-		RegisteredClaims: jwt.RegisteredClaims{
-			// ExpiresAt: Set the expiration time to 7 days from now
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24 * 7)),
-			// IssuedAt: Set the issued time to the current time
-			IssuedAt: jwt.NewNumericDate(time.Now()),
-		},
+	token := jwt.NewWithClaims(j.method, claims)
+	if j.signingKID != "" {
+		token.Header["kid"] = j.signingKID
 	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(j.refreshSecret)
+	return token.SignedString(j.signingKey)
 }
 
-func (j *JWTAuth) ValidateToken(tokenString string, isAccessToken bool) (*Claims, error) {
-	// Choose the appropriate secret key based on token type
-	secretKey := j.accessSecret
-	if !isAccessToken {
-		secretKey = j.refreshSecret
-	}
-
+func (j *JWTAuth) ValidateToken(tokenString string) (*Claims, error) {
 	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidToken
-		}
-		return secretKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, j.keyFunc)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -101,3 +157,22 @@ func (j *JWTAuth) ValidateToken(tokenString string, isAccessToken bool) (*Claims
 
 	return claims, nil
 }
+
+// keyFunc picks the key a token must verify with. The algorithm has to be
+// the one of that key, so a public key can never be used as an HMAC
+// secret.
+func (j *JWTAuth) keyFunc(token *jwt.Token) (interface{}, error) {
+	if j.verificationKeys == nil {
+		if token.Method != j.method {
+			return nil, ErrInvalidToken
+		}
+		return j.signingKey, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := j.verificationKeys[kid]
+	if !ok || token.Method != key.method {
+		return nil, ErrInvalidToken
+	}
+	return key.key, nil
+}
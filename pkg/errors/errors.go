@@ -6,12 +6,54 @@ import (
 )
 
 var (
-	ErrInvalidInput       = errors.New("invalid input")
-	ErrUserNotFound       = errors.New("user not found")
-	ErrEmailAlreadyExists = errors.New("email already exists")
-	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrInternalServer     = errors.New("internal server error")
-	ErrUserNotActive      = errors.New("user not active")
+	ErrInvalidInput            = errors.New("invalid input")
+	ErrUserNotFound            = errors.New("user not found")
+	ErrEmailAlreadyExists      = errors.New("email already exists")
+	ErrInvalidCredentials      = errors.New("invalid credentials")
+	ErrInternalServer          = errors.New("internal server error")
+	ErrUserNotActive           = errors.New("user not active")
+	ErrProductNotFound         = errors.New("product not found")
+	ErrCartItemNotFound        = errors.New("cart item not found")
+	ErrCartEmpty               = errors.New("cart is empty")
+	ErrPurchaseOrderNotFound   = errors.New("purchase order not found")
+	ErrInsufficientStock       = errors.New("insufficient stock")
+	ErrInvalidTransition       = errors.New("invalid status transition")
+	ErrPurchaseOrderExpired    = errors.New("purchase order expired")
+	ErrSubscriptionNotFound    = errors.New("subscription not found")
+	ErrNotificationNotFound    = errors.New("notification not found")
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrJobNotFound             = errors.New("job not found")
+	ErrCheckpointsDisabled     = errors.New("audit checkpoints are disabled")
+	ErrAuditChainBroken        = errors.New("audit chain is broken")
+	ErrInvalidResetToken       = errors.New("invalid or expired reset token")
+	ErrEmailNotVerified        = errors.New("email not verified")
+	ErrInvalidVerification     = errors.New("invalid verification link")
+	ErrMFANotEnrolled          = errors.New("MFA is not set up")
+	ErrMFAAlreadyEnabled       = errors.New("MFA is already enabled")
+	ErrMFARequired             = errors.New("MFA is required")
+	ErrInvalidMFACode          = errors.New("invalid MFA code")
+	ErrInvalidMFAChallenge     = errors.New("invalid or expired MFA challenge")
+	ErrInvalidRefreshToken     = errors.New("invalid refresh token")
+	ErrSessionNotFound         = errors.New("session not found")
+	ErrTokenRevoked            = errors.New("token revoked")
+	ErrRoleNotFound            = errors.New("role not found")
+	ErrRoleExists              = errors.New("role already exists")
+	ErrRoleBuiltIn             = errors.New("built-in role cannot be changed")
+	ErrUnknownPermission       = errors.New("unknown permission")
+	ErrLastOperator            = errors.New("cannot remove the last operator")
+	ErrOperatorTenant          = errors.New("operators must belong to the default tenant")
+	ErrForbidden               = errors.New("forbidden")
+	ErrOrganizationNotFound    = errors.New("organization not found")
+	ErrOrganizationExists      = errors.New("organization already exists")
+	ErrDepartmentNotFound      = errors.New("department not found")
+	ErrDepartmentExists        = errors.New("department already exists")
+	ErrNotBuyer                = errors.New("user is not a buyer")
+	ErrOtherOrganization       = errors.New("user belongs to another organization")
+	ErrLastOrganizationAdmin   = errors.New("cannot remove the last organization admin")
+	ErrTenantNotFound          = errors.New("tenant not found")
+	ErrApprovalNotFound        = errors.New("approval not found")
+	ErrInvalidApprover         = errors.New("invalid approver")
 )
 
 type AppError struct {
@@ -0,0 +1,90 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+// LoadPrivateKey reads a PEM-encoded private key from path. PKCS #8 keys
+// ("PRIVATE KEY") of any type are accepted, as are PKCS #1 RSA and SEC 1
+// EC keys. Generate an Ed25519 key with:
+//
+//	openssl genpkey -algorithm ed25519 -out key.pem
+func LoadPrivateKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", path)
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: unsupported PEM block %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: key cannot sign", path)
+	}
+	return signer, nil
+}
+
+// LoadPublicKey reads a PEM-encoded public key ("PUBLIC KEY") from path.
+// A private key file is accepted too, and its public half returned. Get
+// the public key of a private key with:
+//
+//	openssl pkey -in key.pem -pubout -out public.pem
+func LoadPublicKey(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", path)
+	}
+	if block.Type != "PUBLIC KEY" {
+		signer, err := LoadPrivateKey(path)
+		if err != nil {
+			return nil, err
+		}
+		return signer.Public(), nil
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// ID derives a stable key identifier from a public key: the first 16 hex
+// digits of the SHA-256 of its PKIX encoding.
+func ID(public crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:8]), nil
+}
@@ -0,0 +1,115 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	DefaultLimit = 10
+	MaxLimit     = 100
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Params is a page request: how many items to return and the opaque cursor
+// of the page to start from. An empty cursor means the first page.
+type Params struct {
+	Limit  int
+	Cursor string
+}
+
+// Page is one page of a keyset-paginated listing.
+type Page[T any] struct {
+	Items      []T    `json:"data"`
+	Total      int64  `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// IDCursor is the keyset position for listings ordered by id only.
+type IDCursor struct {
+	ID int64 `json:"id"`
+}
+
+// ParseParams reads the limit and cursor query parameters, clamping the limit
+// to [1, MaxLimit].
+func ParseParams(r *http.Request) Params {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	return Params{
+		Limit:  clampLimit(limit),
+		Cursor: r.URL.Query().Get("cursor"),
+	}
+}
+
+func clampLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultLimit
+	}
+	if limit > MaxLimit {
+		return MaxLimit
+	}
+	return limit
+}
+
+// Normalize clamps the limit of params built outside ParseParams.
+func (p Params) Normalize() Params {
+	p.Limit = clampLimit(p.Limit)
+	return p
+}
+
+// EncodeCursor serializes a keyset position into an opaque URL-safe string.
+func EncodeCursor(position interface{}) (string, error) {
+	data, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor into position.
+func DecodeCursor(cursor string, position interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, position); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// NewPage builds a page from items fetched with limit+1 rows. The extra row,
+// if present, is dropped and signals that a next page exists; the next cursor
+// is then built from the last item kept.
+func NewPage[T any](items []T, limit int, total int64, positionOf func(T) interface{}) (*Page[T], error) {
+	page := &Page[T]{Items: items, Total: total}
+	if len(items) <= limit {
+		return page, nil
+	}
+
+	page.Items = items[:limit]
+	cursor, err := EncodeCursor(positionOf(page.Items[limit-1]))
+	if err != nil {
+		return nil, err
+	}
+	page.NextCursor = cursor
+	return page, nil
+}
+
+// SetLinkHeader advertises the next page through an RFC 8288 Link header
+// pointing at the current URL with the cursor replaced.
+func SetLinkHeader(w http.ResponseWriter, r *http.Request, nextCursor string) {
+	if nextCursor == "" {
+		return
+	}
+	next := *r.URL
+	query := next.Query()
+	query.Set("cursor", nextCursor)
+	query.Del("offset")
+	next.RawQuery = query.Encode()
+	w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.RequestURI()))
+}
@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// maxResponseBody caps how much of a receiver's response is read.
+const maxResponseBody = 4 << 10
+
+// Sign returns the signature sent in the X-Webhook-Signature header: the
+// hex-encoded HMAC-SHA256 of "<timestamp>.<body>" keyed with the
+// subscription secret, prefixed with "sha256=". Receivers should recompute
+// it and reject stale timestamps to prevent replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature matches body and timestamp.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// NewSecret generates a random signing secret.
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// NewEventID generates the id that identifies an event across deliveries
+// and redeliveries, so receivers can drop duplicates.
+func NewEventID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "evt_" + hex.EncodeToString(b), nil
+}
+
+type Request struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID int64
+	Body       []byte
+}
+
+// Response is what the receiver answered. StatusCode is zero when no
+// response was received.
+type Response struct {
+	StatusCode int
+	Body       string
+}
+
+// Sender posts signed webhook payloads.
+type Sender struct {
+	client *http.Client
+}
+
+func NewSender(timeout time.Duration) *Sender {
+	return &Sender{client: &http.Client{Timeout: timeout}}
+}
+
+// Send posts the request body. Any 2xx status counts as delivered; other
+// statuses are returned without an error so the caller can log them.
+func (s *Sender) Send(request Request) (*Response, error) {
+	timestamp := time.Now().Unix()
+	httpRequest, err := http.NewRequest(http.MethodPost, request.URL, bytes.NewReader(request.Body))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("User-Agent", "e-procurement-webhooks/1.0")
+	httpRequest.Header.Set(HeaderEvent, request.Event)
+	httpRequest.Header.Set(HeaderDelivery, strconv.FormatInt(request.DeliveryID, 10))
+	httpRequest.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	httpRequest.Header.Set(HeaderSignature, Sign(request.Secret, timestamp, request.Body))
+
+	httpResponse, err := s.client.Do(httpRequest)
+	if err != nil {
+		return &Response{}, err
+	}
+	defer httpResponse.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(httpResponse.Body, maxResponseBody))
+	return &Response{StatusCode: httpResponse.StatusCode, Body: string(body)}, nil
+}
+
+// Succeeded reports whether the receiver accepted the delivery.
+func (r *Response) Succeeded() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}